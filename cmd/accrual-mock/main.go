@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/caarlos0/env/v10"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sodiqit/gophermart/internal/accrualmock"
+	"github.com/sodiqit/gophermart/internal/logger"
+)
+
+type config struct {
+	Address   string `env:"RUN_ADDRESS"`
+	Scenarios string `env:"ACCRUAL_MOCK_SCENARIOS"`
+	LogLevel  string `env:"LOG_LEVEL"`
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.Address, "a", ":8081", "address and port to run the fake accrual system")
+	flag.StringVar(&cfg.Scenarios, "f", "", "scenarios file, without it every order is unknown")
+	flag.StringVar(&cfg.LogLevel, "l", "info", "log level")
+	flag.Parse()
+
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	logger := logger.New(cfg.LogLevel)
+
+	defer logger.Sync()
+
+	var scenarios accrualmock.Scenarios
+
+	if cfg.Scenarios != "" {
+		var err error
+
+		scenarios, err = accrualmock.LoadScenarios(cfg.Scenarios)
+
+		if err != nil {
+			log.Fatalf("Error while load scenarios: %s", err)
+		}
+	}
+
+	server := accrualmock.NewServer(logger, scenarios)
+
+	handler := middleware.Logger(server.Route())
+
+	srv := http.Server{Addr: cfg.Address, Handler: handler}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Println("Error while shutdown server", err.Error())
+		}
+	}()
+
+	logger.Infow("start fake accrual system", "address", cfg.Address, "scenarios", cfg.Scenarios, "orders", len(scenarios.Orders), "rateLimit", scenarios.RateLimit)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Errorw("error while running fake accrual system", "err", err)
+		os.Exit(1)
+	}
+}
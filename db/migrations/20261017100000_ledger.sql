@@ -0,0 +1,99 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ledger_transactions(
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(32) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    user_id INTEGER NOT NULL,
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (type, reference),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries(
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    account VARCHAR(32) NOT NULL,
+    user_id INTEGER,
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES ledger_transactions (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_user_id_idx ON ledger_entries (user_id, created_at);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_transaction_id_idx ON ledger_entries (transaction_id);
+
+CREATE TABLE IF NOT EXISTS user_balances(
+    user_id INTEGER PRIMARY KEY,
+    current DOUBLE PRECISION NOT NULL DEFAULT 0,
+    withdrawn DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- ledger is append-only: posted transactions and entries are never changed
+CREATE OR REPLACE FUNCTION ledger_forbid_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only, % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_transactions_forbid_update BEFORE UPDATE ON ledger_transactions
+    FOR EACH ROW EXECUTE FUNCTION ledger_forbid_update();
+
+CREATE TRIGGER ledger_entries_forbid_update BEFORE UPDATE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_forbid_update();
+
+-- move existing accruals and withdrawals to the ledger
+INSERT INTO ledger_transactions (type, reference, user_id, amount, created_at)
+SELECT 'ACCRUAL', id, user_id, accrual, updated_at FROM orders WHERE status = 'PROCESSED' AND accrual > 0;
+
+INSERT INTO ledger_transactions (type, reference, user_id, amount, created_at)
+SELECT 'WITHDRAWAL', order_id, user_id, amount, created_at FROM withdraws WHERE amount > 0;
+
+INSERT INTO ledger_entries (transaction_id, account, user_id, direction, amount, created_at)
+SELECT
+    id,
+    'USER',
+    user_id,
+    CASE type WHEN 'ACCRUAL' THEN 'CREDIT' ELSE 'DEBIT' END,
+    amount,
+    created_at
+FROM ledger_transactions;
+
+INSERT INTO ledger_entries (transaction_id, account, user_id, direction, amount, created_at)
+SELECT
+    id,
+    CASE type WHEN 'ACCRUAL' THEN 'ACCRUALS' ELSE 'REDEMPTIONS' END,
+    NULL,
+    CASE type WHEN 'ACCRUAL' THEN 'DEBIT' ELSE 'CREDIT' END,
+    amount,
+    created_at
+FROM ledger_transactions;
+
+INSERT INTO user_balances (user_id, current, withdrawn)
+SELECT
+    u.id,
+    COALESCE(SUM(CASE e.direction WHEN 'CREDIT' THEN e.amount ELSE -e.amount END), 0),
+    COALESCE(SUM(CASE WHEN t.type = 'WITHDRAWAL' THEN e.amount ELSE 0 END), 0)
+FROM users u
+LEFT JOIN ledger_entries e ON e.user_id = u.id AND e.account = 'USER'
+LEFT JOIN ledger_transactions t ON t.id = e.transaction_id
+GROUP BY u.id;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_balances CASCADE;
+
+DROP TABLE IF EXISTS ledger_entries CASCADE;
+
+DROP TABLE IF EXISTS ledger_transactions CASCADE;
+
+DROP FUNCTION IF EXISTS ledger_forbid_update();
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- points are stored as integer minor units (1/100 of a point), see pkg/points
+ALTER TABLE orders ALTER COLUMN accrual TYPE BIGINT USING ROUND((accrual * 100)::NUMERIC);
+
+ALTER TABLE withdraws ALTER COLUMN amount TYPE BIGINT USING ROUND((amount * 100)::NUMERIC);
+
+ALTER TABLE ledger_transactions ALTER COLUMN amount TYPE BIGINT USING ROUND((amount * 100)::NUMERIC);
+
+ALTER TABLE ledger_entries ALTER COLUMN amount TYPE BIGINT USING ROUND((amount * 100)::NUMERIC);
+
+ALTER TABLE user_balances
+    ALTER COLUMN current TYPE BIGINT USING ROUND((current * 100)::NUMERIC),
+    ALTER COLUMN withdrawn TYPE BIGINT USING ROUND((withdrawn * 100)::NUMERIC);
+
+COMMENT ON COLUMN orders.accrual IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN withdraws.amount IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN ledger_transactions.amount IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN ledger_entries.amount IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN user_balances.current IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN user_balances.withdrawn IS 'minor units, 1/100 of a point';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders ALTER COLUMN accrual TYPE DOUBLE PRECISION USING accrual / 100.0;
+
+ALTER TABLE withdraws ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 100.0;
+
+ALTER TABLE ledger_transactions ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 100.0;
+
+ALTER TABLE ledger_entries ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 100.0;
+
+ALTER TABLE user_balances
+    ALTER COLUMN current TYPE DOUBLE PRECISION USING current / 100.0,
+    ALTER COLUMN withdrawn TYPE DOUBLE PRECISION USING withdrawn / 100.0;
+
+-- +goose StatementEnd
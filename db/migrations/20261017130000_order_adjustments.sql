@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS accrual_checked_at TIMESTAMP;
+
+-- every change of a processed order accrual reported by the accrual system, amount = accrual - previous_accrual
+CREATE TABLE IF NOT EXISTS order_adjustments(
+    id BIGSERIAL PRIMARY KEY,
+    order_id VARCHAR(255) NOT NULL,
+    user_id INTEGER NOT NULL,
+    previous_accrual BIGINT NOT NULL,
+    accrual BIGINT NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    reason VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN order_adjustments.previous_accrual IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN order_adjustments.accrual IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN order_adjustments.amount IS 'minor units, 1/100 of a point';
+
+CREATE INDEX IF NOT EXISTS order_adjustments_user_id_idx ON order_adjustments (user_id, created_at);
+
+CREATE INDEX IF NOT EXISTS order_adjustments_order_id_idx ON order_adjustments (order_id);
+
+CREATE INDEX IF NOT EXISTS orders_accrual_checked_at_idx ON orders (status, accrual_checked_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_adjustments;
+
+DROP INDEX IF EXISTS orders_accrual_checked_at_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS accrual_checked_at;
+-- +goose StatementEnd
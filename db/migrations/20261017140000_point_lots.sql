@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every credit of a user creates a lot, debits consume lots oldest first, see ledgerRepo.post
+CREATE TABLE IF NOT EXISTS point_lots(
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    transaction_id BIGINT,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    remaining BIGINT NOT NULL CHECK (remaining >= 0),
+    earned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expired_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES ledger_transactions (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN point_lots.amount IS 'minor units, 1/100 of a point';
+
+COMMENT ON COLUMN point_lots.remaining IS 'minor units, 1/100 of a point';
+
+CREATE INDEX IF NOT EXISTS point_lots_active_idx ON point_lots (user_id, earned_at) WHERE remaining > 0;
+
+CREATE INDEX IF NOT EXISTS point_lots_earned_at_idx ON point_lots (earned_at) WHERE remaining > 0;
+
+-- points earned before expiration was introduced start their lifetime now
+INSERT INTO point_lots (user_id, amount, remaining)
+SELECT user_id, current, current FROM user_balances WHERE current > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS point_lots;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS balance_holds(
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    status VARCHAR(16) NOT NULL CHECK (status IN ('HELD', 'CAPTURED', 'VOIDED', 'EXPIRED')),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN balance_holds.amount IS 'minor units, 1/100 of a point';
+
+-- an order number can be reserved by one hold at a time and is consumed by its capture
+CREATE UNIQUE INDEX IF NOT EXISTS balance_holds_order_id_idx ON balance_holds (order_id) WHERE status IN ('HELD', 'CAPTURED');
+
+CREATE INDEX IF NOT EXISTS balance_holds_user_id_idx ON balance_holds (user_id, status);
+
+CREATE INDEX IF NOT EXISTS balance_holds_expires_at_idx ON balance_holds (expires_at) WHERE status = 'HELD';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS balance_holds;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS point_transfers(
+    id BIGSERIAL PRIMARY KEY,
+    sender_id INTEGER NOT NULL,
+    recipient_id INTEGER NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (sender_id <> recipient_id),
+    FOREIGN KEY (sender_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (recipient_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN point_transfers.amount IS 'minor units, 1/100 of a point';
+
+CREATE INDEX IF NOT EXISTS point_transfers_sender_id_idx ON point_transfers (sender_id, created_at);
+
+CREATE INDEX IF NOT EXISTS point_transfers_recipient_id_idx ON point_transfers (recipient_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS point_transfers;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(128);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP;
+
+COMMENT ON COLUMN orders.lease_owner IS 'processor instance polling the accrual system for the order until lease_expires_at';
+
+CREATE INDEX IF NOT EXISTS orders_processing_lease_idx ON orders (lease_expires_at NULLS FIRST, created_at) WHERE status IN ('NEW', 'PROCESSING');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_processing_lease_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS lease_expires_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS lease_owner;
+-- +goose StatementEnd
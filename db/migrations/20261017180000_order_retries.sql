@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS queued_at TIMESTAMP;
+
+UPDATE orders SET queued_at = created_at WHERE queued_at IS NULL;
+
+ALTER TABLE orders ALTER COLUMN queued_at SET DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE orders ALTER COLUMN queued_at SET NOT NULL;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS last_error TEXT;
+
+COMMENT ON COLUMN orders.attempts IS 'unsuccessful polls of the accrual system since the order was queued';
+
+COMMENT ON COLUMN orders.queued_at IS 'when the order was uploaded or last requeued, the max age of the order counts from it';
+
+COMMENT ON COLUMN orders.dead_lettered_at IS 'when the order stopped being polled, it is polled again once requeued';
+
+DROP INDEX IF EXISTS orders_processing_lease_idx;
+
+CREATE INDEX IF NOT EXISTS orders_processing_lease_idx ON orders (lease_expires_at NULLS FIRST, created_at) WHERE status IN ('NEW', 'PROCESSING') AND dead_lettered_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS orders_dead_lettered_idx ON orders (dead_lettered_at) WHERE dead_lettered_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_dead_lettered_idx;
+
+DROP INDEX IF EXISTS orders_processing_lease_idx;
+
+CREATE INDEX IF NOT EXISTS orders_processing_lease_idx ON orders (lease_expires_at NULLS FIRST, created_at) WHERE status IN ('NEW', 'PROCESSING');
+
+ALTER TABLE orders DROP COLUMN IF EXISTS last_error;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS dead_lettered_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS queued_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS next_attempt_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS attempts;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pushed_at TIMESTAMP;
+
+COMMENT ON COLUMN orders.pushed_at IS 'when the accrual system last pushed the order to the webhook, the order is polled only when pushes stop';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS pushed_at;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- REGISTERED of the accrual system was written as is, it is NEW for gophermart
+UPDATE orders SET status = 'NEW' WHERE status = 'REGISTERED';
+
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN ('NEW', 'PROCESSING', 'INVALID', 'PROCESSED'));
+
+-- every status transition of an order, from_status is NULL for the upload
+CREATE TABLE IF NOT EXISTS order_status_history(
+    id BIGSERIAL PRIMARY KEY,
+    order_id VARCHAR(255) NOT NULL,
+    from_status VARCHAR(32),
+    to_status VARCHAR(32) NOT NULL,
+    accrual BIGINT,
+    source VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN order_status_history.accrual IS 'minor units, 1/100 of a point, accrual of the order after the transition';
+
+COMMENT ON COLUMN order_status_history.source IS 'UPLOAD, ACCRUAL for results of the accrual system or RECHECK for changes of processed orders';
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON order_status_history (order_id, id);
+
+-- the timeline of existing orders is restored from their upload and last update
+INSERT INTO order_status_history (order_id, from_status, to_status, source, created_at)
+SELECT id, NULL, 'NEW', 'UPLOAD', created_at FROM orders;
+
+INSERT INTO order_status_history (order_id, from_status, to_status, accrual, source, created_at)
+SELECT id, 'NEW', status, accrual, 'ACCRUAL', updated_at FROM orders WHERE status <> 'NEW';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_status_history;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+-- +goose StatementEnd
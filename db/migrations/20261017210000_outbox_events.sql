@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- domain events written in the transaction of the change they describe and published by the outbox relay
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_error TEXT
+);
+
+COMMENT ON COLUMN outbox_events.aggregate_id IS 'order number for order events, user id for balance events';
+
+COMMENT ON COLUMN outbox_events.attempts IS 'failed publish attempts, the event is retried with backoff until published';
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (id) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox_events;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- urls users registered to be notified about their orders and withdrawals
+CREATE TABLE IF NOT EXISTS user_webhooks(
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, url),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN user_webhooks.secret IS 'shared with the user, signs the delivered payloads';
+
+-- every notification sent to a webhook, kept as the delivery log of the webhook
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    response_status INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP,
+    FOREIGN KEY (webhook_id) REFERENCES user_webhooks (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN webhook_deliveries.status IS 'PENDING until delivered, FAILED once the delivery is retried for longer than the max age';
+
+COMMENT ON COLUMN webhook_deliveries.response_status IS 'HTTP status of the last attempt, NULL when no response was received';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (id) WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP TABLE IF EXISTS user_webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- access tokens revoked before their expiry by logout, rows are useless once the token has expired
+CREATE TABLE IF NOT EXISTS revoked_tokens(
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_revoked_before TIMESTAMP;
+
+COMMENT ON COLUMN users.tokens_revoked_before IS 'access tokens of the user issued before are revoked, set when all sessions are logged out';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_revoked_before;
+
+DROP TABLE IF EXISTS revoked_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- single-use tokens resetting the password of a user, a reset uses up all outstanding tokens of the user
+CREATE TABLE IF NOT EXISTS password_reset_tokens(
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN password_reset_tokens.token_hash IS 'hex SHA-256 of the token, the token itself is only sent to the user';
+
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS password_reset_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ids of the status history are taken at insert, transitions of one user committed out of id order would be
+-- skipped by streams resuming after an id. seq numbers transitions of the user under the lock of the user row
+-- taken to increment users.status_change_seq, so they commit in seq order, see orderRepo.createStatusChange
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_change_seq BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_status_history ADD COLUMN IF NOT EXISTS user_id INTEGER;
+
+ALTER TABLE order_status_history ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+UPDATE order_status_history h
+SET user_id = s.user_id, seq = s.seq
+FROM (
+    SELECT h.id, o.user_id, ROW_NUMBER() OVER (PARTITION BY o.user_id ORDER BY h.id) AS seq
+    FROM order_status_history h
+    JOIN orders o ON o.id = h.order_id
+) s
+WHERE s.id = h.id;
+
+UPDATE users u
+SET status_change_seq = s.seq
+FROM (SELECT user_id, MAX(seq) AS seq FROM order_status_history GROUP BY user_id) s
+WHERE s.user_id = u.id;
+
+ALTER TABLE order_status_history ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE order_status_history ALTER COLUMN seq SET NOT NULL;
+
+ALTER TABLE order_status_history ADD CONSTRAINT order_status_history_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS order_status_history_user_seq_idx ON order_status_history (user_id, seq);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS order_status_history_user_seq_idx;
+
+ALTER TABLE order_status_history DROP COLUMN IF EXISTS seq;
+
+ALTER TABLE order_status_history DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE users DROP COLUMN IF EXISTS status_change_seq;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- events are claimed with a lease and published outside the claiming transaction, so a slow publisher
+-- does not hold row locks and an open transaction for the whole batch
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(128);
+
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP;
+
+COMMENT ON COLUMN outbox_events.lease_owner IS 'relay instance publishing the event until lease_expires_at';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS lease_expires_at;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS lease_owner;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- deliveries are claimed with a lease and sent outside the claiming transaction, so slow webhooks
+-- do not hold row locks and an open transaction for the whole batch
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(128);
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP;
+
+COMMENT ON COLUMN webhook_deliveries.lease_owner IS 'dispatcher instance sending the delivery until lease_expires_at';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS lease_expires_at;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS lease_owner;
+-- +goose StatementEnd
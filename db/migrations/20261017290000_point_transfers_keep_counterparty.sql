@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- transfers belong to the history of both users, deleting one of them keeps the transfer for the other
+ALTER TABLE point_transfers ALTER COLUMN sender_id DROP NOT NULL;
+
+ALTER TABLE point_transfers ALTER COLUMN recipient_id DROP NOT NULL;
+
+ALTER TABLE point_transfers DROP CONSTRAINT IF EXISTS point_transfers_sender_id_fkey;
+
+ALTER TABLE point_transfers DROP CONSTRAINT IF EXISTS point_transfers_recipient_id_fkey;
+
+ALTER TABLE point_transfers ADD CONSTRAINT point_transfers_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE point_transfers ADD CONSTRAINT point_transfers_recipient_id_fkey FOREIGN KEY (recipient_id) REFERENCES users (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN point_transfers.sender_id IS 'NULL once the sender deleted the account';
+
+COMMENT ON COLUMN point_transfers.recipient_id IS 'NULL once the recipient deleted the account';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM point_transfers WHERE sender_id IS NULL OR recipient_id IS NULL;
+
+ALTER TABLE point_transfers DROP CONSTRAINT IF EXISTS point_transfers_sender_id_fkey;
+
+ALTER TABLE point_transfers DROP CONSTRAINT IF EXISTS point_transfers_recipient_id_fkey;
+
+ALTER TABLE point_transfers ADD CONSTRAINT point_transfers_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE point_transfers ADD CONSTRAINT point_transfers_recipient_id_fkey FOREIGN KEY (recipient_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE point_transfers ALTER COLUMN sender_id SET NOT NULL;
+
+ALTER TABLE point_transfers ALTER COLUMN recipient_id SET NOT NULL;
+-- +goose StatementEnd
@@ -12,10 +12,19 @@ import (
 )
 
 type Orders struct {
-	ID        string `sql:"primary_key"`
-	UserID    int32
-	Status    string
-	Accrual   *float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID               string `sql:"primary_key"`
+	UserID           int32
+	Status           string
+	Accrual          *int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	AccrualCheckedAt *time.Time
+	LeaseOwner       *string
+	LeaseExpiresAt   *time.Time
+	Attempts         int32
+	NextAttemptAt    *time.Time
+	QueuedAt         time.Time
+	DeadLetteredAt   *time.Time
+	LastError        *string
+	PushedAt         *time.Time
 }
@@ -12,8 +12,10 @@ import (
 )
 
 type Users struct {
-	ID           int32 `sql:"primary_key"`
-	Login        string
-	PasswordHash string
-	CreatedAt    time.Time
+	ID                  int32 `sql:"primary_key"`
+	Login               string
+	PasswordHash        string
+	CreatedAt           time.Time
+	TokensRevokedBefore *time.Time
+	StatusChangeSeq     int64
 }
@@ -14,7 +14,7 @@ import (
 type Withdraws struct {
 	ID        int32 `sql:"primary_key"`
 	UserID    int32
-	Amount    float64
+	Amount    int64
 	OrderID   string
 	CreatedAt time.Time
 }
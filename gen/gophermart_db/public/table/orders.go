@@ -17,12 +17,21 @@ type ordersTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnString
-	UserID    postgres.ColumnInteger
-	Status    postgres.ColumnString
-	Accrual   postgres.ColumnFloat
-	CreatedAt postgres.ColumnTimestamp
-	UpdatedAt postgres.ColumnTimestamp
+	ID               postgres.ColumnString
+	UserID           postgres.ColumnInteger
+	Status           postgres.ColumnString
+	Accrual          postgres.ColumnInteger
+	CreatedAt        postgres.ColumnTimestamp
+	UpdatedAt        postgres.ColumnTimestamp
+	AccrualCheckedAt postgres.ColumnTimestamp
+	LeaseOwner       postgres.ColumnString
+	LeaseExpiresAt   postgres.ColumnTimestamp
+	Attempts         postgres.ColumnInteger
+	NextAttemptAt    postgres.ColumnTimestamp
+	QueuedAt         postgres.ColumnTimestamp
+	DeadLetteredAt   postgres.ColumnTimestamp
+	LastError        postgres.ColumnString
+	PushedAt         postgres.ColumnTimestamp
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +72,44 @@ func newOrdersTable(schemaName, tableName, alias string) *OrdersTable {
 
 func newOrdersTableImpl(schemaName, tableName, alias string) ordersTable {
 	var (
-		IDColumn        = postgres.StringColumn("id")
-		UserIDColumn    = postgres.IntegerColumn("user_id")
-		StatusColumn    = postgres.StringColumn("status")
-		AccrualColumn   = postgres.FloatColumn("accrual")
-		CreatedAtColumn = postgres.TimestampColumn("created_at")
-		UpdatedAtColumn = postgres.TimestampColumn("updated_at")
-		allColumns      = postgres.ColumnList{IDColumn, UserIDColumn, StatusColumn, AccrualColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns  = postgres.ColumnList{UserIDColumn, StatusColumn, AccrualColumn, CreatedAtColumn, UpdatedAtColumn}
+		IDColumn               = postgres.StringColumn("id")
+		UserIDColumn           = postgres.IntegerColumn("user_id")
+		StatusColumn           = postgres.StringColumn("status")
+		AccrualColumn          = postgres.IntegerColumn("accrual")
+		CreatedAtColumn        = postgres.TimestampColumn("created_at")
+		UpdatedAtColumn        = postgres.TimestampColumn("updated_at")
+		AccrualCheckedAtColumn = postgres.TimestampColumn("accrual_checked_at")
+		LeaseOwnerColumn       = postgres.StringColumn("lease_owner")
+		LeaseExpiresAtColumn   = postgres.TimestampColumn("lease_expires_at")
+		AttemptsColumn         = postgres.IntegerColumn("attempts")
+		NextAttemptAtColumn    = postgres.TimestampColumn("next_attempt_at")
+		QueuedAtColumn         = postgres.TimestampColumn("queued_at")
+		DeadLetteredAtColumn   = postgres.TimestampColumn("dead_lettered_at")
+		LastErrorColumn        = postgres.StringColumn("last_error")
+		PushedAtColumn         = postgres.TimestampColumn("pushed_at")
+		allColumns             = postgres.ColumnList{IDColumn, UserIDColumn, StatusColumn, AccrualColumn, CreatedAtColumn, UpdatedAtColumn, AccrualCheckedAtColumn, LeaseOwnerColumn, LeaseExpiresAtColumn, AttemptsColumn, NextAttemptAtColumn, QueuedAtColumn, DeadLetteredAtColumn, LastErrorColumn, PushedAtColumn}
+		mutableColumns         = postgres.ColumnList{UserIDColumn, StatusColumn, AccrualColumn, CreatedAtColumn, UpdatedAtColumn, AccrualCheckedAtColumn, LeaseOwnerColumn, LeaseExpiresAtColumn, AttemptsColumn, NextAttemptAtColumn, QueuedAtColumn, DeadLetteredAtColumn, LastErrorColumn, PushedAtColumn}
 	)
 
 	return ordersTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		UserID:    UserIDColumn,
-		Status:    StatusColumn,
-		Accrual:   AccrualColumn,
-		CreatedAt: CreatedAtColumn,
-		UpdatedAt: UpdatedAtColumn,
+		ID:               IDColumn,
+		UserID:           UserIDColumn,
+		Status:           StatusColumn,
+		Accrual:          AccrualColumn,
+		CreatedAt:        CreatedAtColumn,
+		UpdatedAt:        UpdatedAtColumn,
+		AccrualCheckedAt: AccrualCheckedAtColumn,
+		LeaseOwner:       LeaseOwnerColumn,
+		LeaseExpiresAt:   LeaseExpiresAtColumn,
+		Attempts:         AttemptsColumn,
+		NextAttemptAt:    NextAttemptAtColumn,
+		QueuedAt:         QueuedAtColumn,
+		DeadLetteredAt:   DeadLetteredAtColumn,
+		LastError:        LastErrorColumn,
+		PushedAt:         PushedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
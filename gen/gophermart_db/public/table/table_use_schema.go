@@ -10,8 +10,23 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	BalanceHolds = BalanceHolds.FromSchema(schema)
 	GooseDbVersion = GooseDbVersion.FromSchema(schema)
+	IdempotencyKeys = IdempotencyKeys.FromSchema(schema)
+	LedgerEntries = LedgerEntries.FromSchema(schema)
+	LedgerTransactions = LedgerTransactions.FromSchema(schema)
+	OrderAdjustments = OrderAdjustments.FromSchema(schema)
+	OrderStatusHistory = OrderStatusHistory.FromSchema(schema)
 	Orders = Orders.FromSchema(schema)
+	OutboxEvents = OutboxEvents.FromSchema(schema)
+	PasswordResetTokens = PasswordResetTokens.FromSchema(schema)
+	PointLots = PointLots.FromSchema(schema)
+	PointTransfers = PointTransfers.FromSchema(schema)
+	RefreshTokens = RefreshTokens.FromSchema(schema)
+	RevokedTokens = RevokedTokens.FromSchema(schema)
+	UserBalances = UserBalances.FromSchema(schema)
+	UserWebhooks = UserWebhooks.FromSchema(schema)
 	Users = Users.FromSchema(schema)
+	WebhookDeliveries = WebhookDeliveries.FromSchema(schema)
 	Withdraws = Withdraws.FromSchema(schema)
 }
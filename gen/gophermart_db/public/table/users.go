@@ -17,10 +17,12 @@ type usersTable struct {
 	postgres.Table
 
 	// Columns
-	ID           postgres.ColumnInteger
-	Login        postgres.ColumnString
-	PasswordHash postgres.ColumnString
-	CreatedAt    postgres.ColumnTimestamp
+	ID                  postgres.ColumnInteger
+	Login               postgres.ColumnString
+	PasswordHash        postgres.ColumnString
+	CreatedAt           postgres.ColumnTimestamp
+	TokensRevokedBefore postgres.ColumnTimestamp
+	StatusChangeSeq     postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -61,22 +63,26 @@ func newUsersTable(schemaName, tableName, alias string) *UsersTable {
 
 func newUsersTableImpl(schemaName, tableName, alias string) usersTable {
 	var (
-		IDColumn           = postgres.IntegerColumn("id")
-		LoginColumn        = postgres.StringColumn("login")
-		PasswordHashColumn = postgres.StringColumn("password_hash")
-		CreatedAtColumn    = postgres.TimestampColumn("created_at")
-		allColumns         = postgres.ColumnList{IDColumn, LoginColumn, PasswordHashColumn, CreatedAtColumn}
-		mutableColumns     = postgres.ColumnList{LoginColumn, PasswordHashColumn, CreatedAtColumn}
+		IDColumn                  = postgres.IntegerColumn("id")
+		LoginColumn               = postgres.StringColumn("login")
+		PasswordHashColumn        = postgres.StringColumn("password_hash")
+		CreatedAtColumn           = postgres.TimestampColumn("created_at")
+		TokensRevokedBeforeColumn = postgres.TimestampColumn("tokens_revoked_before")
+		StatusChangeSeqColumn     = postgres.IntegerColumn("status_change_seq")
+		allColumns                = postgres.ColumnList{IDColumn, LoginColumn, PasswordHashColumn, CreatedAtColumn, TokensRevokedBeforeColumn, StatusChangeSeqColumn}
+		mutableColumns            = postgres.ColumnList{LoginColumn, PasswordHashColumn, CreatedAtColumn, TokensRevokedBeforeColumn, StatusChangeSeqColumn}
 	)
 
 	return usersTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:           IDColumn,
-		Login:        LoginColumn,
-		PasswordHash: PasswordHashColumn,
-		CreatedAt:    CreatedAtColumn,
+		ID:                  IDColumn,
+		Login:               LoginColumn,
+		PasswordHash:        PasswordHashColumn,
+		CreatedAt:           CreatedAtColumn,
+		TokensRevokedBefore: TokensRevokedBeforeColumn,
+		StatusChangeSeq:     StatusChangeSeqColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -19,7 +19,7 @@ type withdrawsTable struct {
 	// Columns
 	ID        postgres.ColumnInteger
 	UserID    postgres.ColumnInteger
-	Amount    postgres.ColumnFloat
+	Amount    postgres.ColumnInteger
 	OrderID   postgres.ColumnString
 	CreatedAt postgres.ColumnTimestamp
 
@@ -64,7 +64,7 @@ func newWithdrawsTableImpl(schemaName, tableName, alias string) withdrawsTable {
 	var (
 		IDColumn        = postgres.IntegerColumn("id")
 		UserIDColumn    = postgres.IntegerColumn("user_id")
-		AmountColumn    = postgres.FloatColumn("amount")
+		AmountColumn    = postgres.IntegerColumn("amount")
 		OrderIDColumn   = postgres.StringColumn("order_id")
 		CreatedAtColumn = postgres.TimestampColumn("created_at")
 		allColumns      = postgres.ColumnList{IDColumn, UserIDColumn, AmountColumn, OrderIDColumn, CreatedAtColumn}
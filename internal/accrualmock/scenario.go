@@ -0,0 +1,133 @@
+package accrualmock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const (
+	StatusRegistered = "REGISTERED"
+	StatusProcessing = "PROCESSING"
+	StatusInvalid    = "INVALID"
+	StatusProcessed  = "PROCESSED"
+)
+
+var ErrInvalidScenario = errors.New("invalid scenario")
+
+// Scenarios is the file describing how the fake accrual system answers.
+//
+// RateLimit is the number of requests per minute answered before 429 is returned, zero disables the limit.
+// Orders map order numbers to their scenarios, Default is used for other orders. Orders without a scenario
+// are unknown and answered with 204.
+type Scenarios struct {
+	RateLimit int                 `json:"rate_limit"`
+	Orders    map[string]Scenario `json:"orders"`
+	Default   *Scenario           `json:"default,omitempty"`
+}
+
+// Scenario is the sequence of answers for an order. Every step answers Repeat requests, the last step
+// answers all the following ones.
+type Scenario struct {
+	Steps []Step `json:"steps"`
+}
+
+// Step is an answer of the accrual system. With HTTPStatus other than 200 the request fails with that status,
+// e.g. 500 to simulate an outage, otherwise the order is answered with Status and Accrual.
+type Step struct {
+	Status     string         `json:"status,omitempty"`
+	Accrual    *points.Points `json:"accrual,omitempty"`
+	Repeat     int            `json:"repeat,omitempty"`
+	HTTPStatus int            `json:"http_status,omitempty"`
+}
+
+// LoadScenarios reads and validates the scenarios file.
+func LoadScenarios(path string) (Scenarios, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Scenarios{}, err
+	}
+
+	var scenarios Scenarios
+
+	if err := json.Unmarshal(data, &scenarios); err != nil {
+		return Scenarios{}, fmt.Errorf("%w: %s", ErrInvalidScenario, err)
+	}
+
+	if err := scenarios.Validate(); err != nil {
+		return Scenarios{}, err
+	}
+
+	return scenarios, nil
+}
+
+func (s Scenarios) Validate() error {
+	if s.RateLimit < 0 {
+		return fmt.Errorf("%w: negative rate_limit", ErrInvalidScenario)
+	}
+
+	for orderID, scenario := range s.Orders {
+		if err := scenario.validate(); err != nil {
+			return fmt.Errorf("%w: order %s: %s", ErrInvalidScenario, orderID, err)
+		}
+	}
+
+	if s.Default != nil {
+		if err := s.Default.validate(); err != nil {
+			return fmt.Errorf("%w: default: %s", ErrInvalidScenario, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Scenario) validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("no steps")
+	}
+
+	for i, step := range s.Steps {
+		if step.Repeat < 0 {
+			return fmt.Errorf("step %d: negative repeat", i)
+		}
+
+		if step.HTTPStatus != 0 && step.HTTPStatus != 200 {
+			continue
+		}
+
+		switch step.Status {
+		case StatusRegistered, StatusProcessing, StatusInvalid:
+			if step.Accrual != nil {
+				return fmt.Errorf("step %d: accrual is allowed for %s only", i, StatusProcessed)
+			}
+		case StatusProcessed:
+		default:
+			return fmt.Errorf("step %d: unknown status %q", i, step.Status)
+		}
+	}
+
+	return nil
+}
+
+// step returns the step answering the request with the zero-based number.
+func (s Scenario) step(request int) Step {
+	for _, step := range s.Steps {
+		repeat := step.Repeat
+
+		if repeat == 0 {
+			repeat = 1
+		}
+
+		if request < repeat {
+			return step
+		}
+
+		request -= repeat
+	}
+
+	return s.Steps[len(s.Steps)-1]
+}
@@ -0,0 +1,135 @@
+package accrualmock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const tooManyRequestsTemplate = "No more than %d requests per minute allowed"
+
+const rateLimitWindow = time.Minute
+
+type orderResponse struct {
+	Order   string         `json:"order"`
+	Status  string         `json:"status"`
+	Accrual *points.Points `json:"accrual,omitempty"`
+}
+
+// Server is a fake accrual system answering GET /api/orders/{number} by the scenarios. Requests of an order
+// move it through its scenario steps, so gophermart sees the order progress as it polls it.
+type Server struct {
+	logger      logger.Logger
+	scenarios   Scenarios
+	mu          sync.Mutex
+	requests    map[string]int
+	windowStart time.Time
+	windowCount int
+}
+
+func (s *Server) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get("/api/orders/{number}", s.handleGetOrder)
+
+	return r
+}
+
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "number")
+
+	if retryAfter, limited := s.limit(time.Now()); limited {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, tooManyRequestsTemplate, s.scenarios.RateLimit)
+		return
+	}
+
+	step, ok := s.next(orderID)
+
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.logger.Debugw("answer order", "orderID", orderID, "status", step.Status, "httpStatus", step.HTTPStatus)
+
+	if step.HTTPStatus != 0 && step.HTTPStatus != http.StatusOK {
+		http.Error(w, http.StatusText(step.HTTPStatus), step.HTTPStatus)
+		return
+	}
+
+	result, err := json.Marshal(orderResponse{Order: orderID, Status: step.Status, Accrual: step.Accrual})
+
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(result)
+}
+
+// limit counts the request in the current window and returns whether it exceeds the rate limit
+// and the seconds left until the window ends.
+func (s *Server) limit(now time.Time) (int, bool) {
+	if s.scenarios.RateLimit == 0 {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= rateLimitWindow {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+
+	if s.windowCount >= s.scenarios.RateLimit {
+		left := s.windowStart.Add(rateLimitWindow).Sub(now)
+
+		return int(math.Ceil(left.Seconds())), true
+	}
+
+	s.windowCount++
+
+	return 0, false
+}
+
+// next returns the step of the order scenario answering the request and moves the order forward.
+func (s *Server) next(orderID string) (Step, bool) {
+	scenario, ok := s.scenarios.Orders[orderID]
+
+	if !ok && s.scenarios.Default != nil {
+		scenario, ok = *s.scenarios.Default, true
+	}
+
+	if !ok {
+		return Step{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request := s.requests[orderID]
+	s.requests[orderID] = request + 1
+
+	return scenario.step(request), true
+}
+
+func NewServer(logger logger.Logger, scenarios Scenarios) *Server {
+	return &Server{
+		logger:    logger,
+		scenarios: scenarios,
+		requests:  make(map[string]int),
+	}
+}
@@ -0,0 +1,123 @@
+package accrualmock_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/accrualmock"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/stretchr/testify/require"
+)
+
+const scenariosJSON = `{
+	"orders": {
+		"2377225624": {"steps": [{"status": "REGISTERED"}, {"status": "PROCESSING", "repeat": 2}, {"status": "PROCESSED", "accrual": 729.98}]},
+		"49927398716": {"steps": [{"http_status": 500}, {"status": "INVALID"}]}
+	}
+}`
+
+func loadScenarios(t *testing.T, data string) accrualmock.Scenarios {
+	path := filepath.Join(t.TempDir(), "scenarios.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	scenarios, err := accrualmock.LoadScenarios(path)
+
+	require.NoError(t, err)
+
+	return scenarios
+}
+
+func TestServer_handleGetOrder(t *testing.T) {
+	server := accrualmock.NewServer(logger.New("info"), loadScenarios(t, scenariosJSON))
+
+	ts := httptest.NewServer(server.Route())
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	tests := []struct {
+		name           string
+		orderID        string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "should register order", orderID: "2377225624", expectedStatus: http.StatusOK, expectedBody: `{"order":"2377225624","status":"REGISTERED"}`},
+		{name: "should process order", orderID: "2377225624", expectedStatus: http.StatusOK, expectedBody: `{"order":"2377225624","status":"PROCESSING"}`},
+		{name: "should repeat step", orderID: "2377225624", expectedStatus: http.StatusOK, expectedBody: `{"order":"2377225624","status":"PROCESSING"}`},
+		{name: "should accrue points", orderID: "2377225624", expectedStatus: http.StatusOK, expectedBody: `{"order":"2377225624","status":"PROCESSED","accrual":729.98}`},
+		{name: "should keep last step", orderID: "2377225624", expectedStatus: http.StatusOK, expectedBody: `{"order":"2377225624","status":"PROCESSED","accrual":729.98}`},
+		{name: "should fail by scenario", orderID: "49927398716", expectedStatus: http.StatusInternalServerError},
+		{name: "should invalidate order", orderID: "49927398716", expectedStatus: http.StatusOK, expectedBody: `{"order":"49927398716","status":"INVALID"}`},
+		{name: "should return 204 for unknown order", orderID: "12345678903", expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := client.R().Get("/api/orders/" + tc.orderID)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, res.String())
+			}
+		})
+	}
+}
+
+func TestServer_rateLimit(t *testing.T) {
+	server := accrualmock.NewServer(logger.New("info"), loadScenarios(t, `{"rate_limit": 2, "default": {"steps": [{"status": "PROCESSING"}]}}`))
+
+	ts := httptest.NewServer(server.Route())
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	for i := 0; i < 2; i++ {
+		res, err := client.R().Get("/api/orders/2377225624")
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+	}
+
+	res, err := client.R().Get("/api/orders/2377225624")
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, res.StatusCode())
+	require.Equal(t, "No more than 2 requests per minute allowed", res.String())
+
+	retryAfter, err := strconv.Atoi(res.Header().Get("Retry-After"))
+
+	require.NoError(t, err)
+	require.True(t, retryAfter > 0 && retryAfter <= 60)
+}
+
+func TestLoadScenarios_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "unknown status", data: `{"orders": {"1": {"steps": [{"status": "DONE"}]}}}`},
+		{name: "no steps", data: `{"orders": {"1": {"steps": []}}}`},
+		{name: "accrual of not processed order", data: `{"orders": {"1": {"steps": [{"status": "PROCESSING", "accrual": 1}]}}}`},
+		{name: "malformed json", data: `{"orders":`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scenarios.json")
+
+			require.NoError(t, os.WriteFile(path, []byte(tc.data), 0o600))
+
+			_, err := accrualmock.LoadScenarios(path)
+
+			require.ErrorIs(t, err, accrualmock.ErrInvalidScenario)
+		})
+	}
+}
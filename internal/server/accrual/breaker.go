@@ -0,0 +1,198 @@
+package accrual
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+)
+
+const (
+	BreakerStateClosed   = "closed"
+	BreakerStateOpen     = "open"
+	BreakerStateHalfOpen = "half-open"
+)
+
+var ErrCircuitOpen = errors.New("accrual system circuit is open")
+
+// BreakerConfig configures CircuitBreakerClient. The circuit opens after FailureThreshold consecutive failures
+// and stays open for OpenTimeout. Then requests are let through one at a time and the circuit closes after
+// HalfOpenSuccesses successful ones. Zero FailureThreshold disables the breaker.
+type BreakerConfig struct {
+	FailureThreshold  int
+	OpenTimeout       time.Duration
+	HalfOpenSuccesses int
+}
+
+// CircuitBreakerState is the state of the breaker reported by health checks.
+type CircuitBreakerState struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
+}
+
+// CircuitBreakerClient stops calling the accrual system while it is down. Requests made while the circuit is open
+// fail with ErrCircuitOpen without reaching the accrual system. Unregistered orders and rate limiting are answers
+// of a working accrual system, they are not counted as failures.
+type CircuitBreakerClient struct {
+	client    AccrualClient
+	logger    logger.Logger
+	config    BreakerConfig
+	mu        sync.Mutex
+	state     string
+	failures  int
+	successes int
+	openUntil time.Time
+	probing   bool
+}
+
+var _ AccrualClient = (*CircuitBreakerClient)(nil)
+
+func (b *CircuitBreakerClient) GetOrderInfo(ctx context.Context, orderID string) (OrderInfoDTO, error) {
+	probe, err := b.acquire()
+
+	if err != nil {
+		return OrderInfoDTO{}, err
+	}
+
+	info, err := b.client.GetOrderInfo(ctx, orderID)
+
+	b.release(ctx, probe, err)
+
+	return info, err
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreakerClient) State() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := CircuitBreakerState{State: b.currentState(time.Now()), ConsecutiveFailures: b.failures}
+
+	if state.State == BreakerStateOpen {
+		openUntil := b.openUntil
+		state.OpenUntil = &openUntil
+	}
+
+	return state
+}
+
+// UnavailableFor returns how long the circuit stays open, zero when requests are let through.
+func (b *CircuitBreakerClient) UnavailableFor() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.currentState(now) != BreakerStateOpen {
+		return 0
+	}
+
+	return b.openUntil.Sub(now)
+}
+
+// currentState moves an open circuit whose timeout elapsed to half-open. It must be called with mu held.
+func (b *CircuitBreakerClient) currentState(now time.Time) string {
+	if b.state == BreakerStateOpen && !now.Before(b.openUntil) {
+		b.setState(BreakerStateHalfOpen)
+	}
+
+	return b.state
+}
+
+// acquire lets the request through unless the circuit is open. In the half-open state a single probe
+// request is let through at a time.
+func (b *CircuitBreakerClient) acquire() (bool, error) {
+	if b.config.FailureThreshold <= 0 {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState(time.Now()) {
+	case BreakerStateOpen:
+		return false, ErrCircuitOpen
+	case BreakerStateHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (b *CircuitBreakerClient) release(ctx context.Context, probe bool, err error) {
+	if b.config.FailureThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	halfOpen := probe && b.state == BreakerStateHalfOpen
+
+	// a canceled request tells nothing about the accrual system
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	if err != nil && !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, ErrRateLimit) {
+		b.failures++
+		b.successes = 0
+
+		if halfOpen || (b.state == BreakerStateClosed && b.failures >= b.config.FailureThreshold) {
+			b.open(err)
+		}
+
+		return
+	}
+
+	b.failures = 0
+
+	if !halfOpen {
+		return
+	}
+
+	b.successes++
+
+	if b.successes >= b.config.HalfOpenSuccesses {
+		b.setState(BreakerStateClosed)
+	}
+}
+
+func (b *CircuitBreakerClient) open(err error) {
+	b.openUntil = time.Now().Add(b.config.OpenTimeout)
+	b.setState(BreakerStateOpen)
+	b.logger.Warnw("accrual system circuit opened", "failures", b.failures, "openUntil", b.openUntil, "err", err)
+}
+
+func (b *CircuitBreakerClient) setState(state string) {
+	if b.state == state {
+		return
+	}
+
+	if state != BreakerStateOpen {
+		b.logger.Infow("accrual system circuit state changed", "from", b.state, "to", state)
+	}
+
+	b.state = state
+	b.successes = 0
+}
+
+func NewCircuitBreakerClient(client AccrualClient, logger logger.Logger, config BreakerConfig) *CircuitBreakerClient {
+	return &CircuitBreakerClient{
+		client: client,
+		logger: logger,
+		config: config,
+		state:  BreakerStateClosed,
+	}
+}
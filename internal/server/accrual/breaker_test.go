@@ -0,0 +1,96 @@
+package accrual_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCircuitBreakerClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := "2377225624"
+	errUnavailable := errors.New("connection refused")
+
+	clientMock := accrual.NewMockAccrualClient(ctrl)
+
+	b := accrual.NewCircuitBreakerClient(clientMock, logger.New("info"), accrual.BreakerConfig{FailureThreshold: 2, OpenTimeout: 20 * time.Millisecond, HalfOpenSuccesses: 2})
+
+	ctx := context.Background()
+
+	// answers of a working accrual system are not failures
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, accrual.ErrOrderNotFound)
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, accrual.ErrRateLimit)
+
+	_, err := b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, accrual.ErrOrderNotFound)
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, accrual.ErrRateLimit)
+	require.Equal(t, accrual.CircuitBreakerState{State: accrual.BreakerStateClosed}, b.State())
+
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, errUnavailable).Times(2)
+
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, errUnavailable)
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, errUnavailable)
+
+	state := b.State()
+	require.Equal(t, accrual.BreakerStateOpen, state.State)
+	require.Equal(t, 2, state.ConsecutiveFailures)
+	require.NotNil(t, state.OpenUntil)
+	require.Greater(t, b.UnavailableFor(), time.Duration(0))
+
+	// open circuit does not reach the accrual system
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, accrual.ErrCircuitOpen)
+
+	time.Sleep(30 * time.Millisecond)
+
+	require.Equal(t, accrual.BreakerStateHalfOpen, b.State().State)
+	require.Equal(t, time.Duration(0), b.UnavailableFor())
+
+	// failed probe opens the circuit again
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, errUnavailable)
+
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.ErrorIs(t, err, errUnavailable)
+	require.Equal(t, accrual.BreakerStateOpen, b.State().State)
+
+	time.Sleep(30 * time.Millisecond)
+
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID}, nil).Times(2)
+
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, accrual.BreakerStateHalfOpen, b.State().State)
+
+	_, err = b.GetOrderInfo(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, accrual.CircuitBreakerState{State: accrual.BreakerStateClosed}, b.State())
+}
+
+func TestCircuitBreakerClient_disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clientMock := accrual.NewMockAccrualClient(ctrl)
+
+	b := accrual.NewCircuitBreakerClient(clientMock, logger.New("info"), accrual.BreakerConfig{})
+
+	clientMock.EXPECT().GetOrderInfo(gomock.Any(), gomock.Any()).Return(accrual.OrderInfoDTO{}, errors.New("connection refused")).Times(10)
+
+	for i := 0; i < 10; i++ {
+		_, err := b.GetOrderInfo(context.Background(), "2377225624")
+		require.NotErrorIs(t, err, accrual.ErrCircuitOpen)
+	}
+
+	require.Equal(t, accrual.BreakerStateClosed, b.State().State)
+}
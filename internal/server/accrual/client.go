@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/pkg/points"
 	"golang.org/x/time/rate"
 )
 
@@ -19,26 +21,44 @@ var ErrOrderIncorrectResponseBody = errors.New("order response body not match")
 var ErrRateLimit = errors.New("rate limit")
 
 type OrderInfoDTO struct {
-	OrderID string   `json:"order"`
-	Accrual *float64 `json:"accrual,omitempty"`
-	Status  string   `json:"status"`
+	OrderID string         `json:"order"`
+	Accrual *points.Points `json:"accrual,omitempty"`
+	Status  string         `json:"status"`
 }
 
 type AccrualClient interface {
 	GetOrderInfo(ctx context.Context, orderID string) (OrderInfoDTO, error)
 }
 
+// RateLimitState is the rate limit the accrual system reported last. Limit is in requests per minute,
+// zero until the first 429 response. PausedUntil is set while all requests wait for Retry-After to elapse.
+type RateLimitState struct {
+	Limit       int        `json:"limit"`
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+}
+
 type HTTPAccrualClient struct {
 	endpointTemplate string
 	limiter          *rate.Limiter
 	limiterMutex     sync.Mutex
 	httpClient       *resty.Client
 	rl               int
+	pausedUntil      time.Time
 }
 
+// GetOrderInfo waits while the client is paused by Retry-After and then for the adaptive rate limiter,
+// so every worker sharing the client backs off together after a 429 response.
 func (c *HTTPAccrualClient) GetOrderInfo(ctx context.Context, orderID string) (OrderInfoDTO, error) {
-	if c.limiter != nil {
-		err := c.limiter.Wait(ctx)
+	if err := c.waitPause(ctx); err != nil {
+		return OrderInfoDTO{}, err
+	}
+
+	c.limiterMutex.Lock()
+	limiter := c.limiter
+	c.limiterMutex.Unlock()
+
+	if limiter != nil {
+		err := limiter.Wait(ctx)
 		if err != nil {
 			return OrderInfoDTO{}, err
 		}
@@ -56,21 +76,30 @@ func (c *HTTPAccrualClient) GetOrderInfo(ctx context.Context, orderID string) (O
 	}
 
 	if response.StatusCode() == http.StatusTooManyRequests {
+		retryAfter, hasRetryAfter := parseRetryAfter(response.Header().Get("Retry-After"), time.Now())
+
+		if hasRetryAfter {
+			c.pause(retryAfter)
+		}
+
 		var rl int
 		_, err = fmt.Sscanf(response.String(), tooManyRequestTemplate, &rl)
 
-		if err != nil {
+		if err != nil && !hasRetryAfter {
 			return OrderInfoDTO{}, err
 		}
 
-		c.limiterMutex.Lock()
+		if err == nil && rl > 0 {
+			c.limiterMutex.Lock()
+
+			if c.rl != rl || c.limiter == nil {
+				c.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(rl)), rl)
+				c.rl = rl
+			}
 
-		if c.rl != rl || c.limiter == nil {
-			c.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(rl)), rl)
-			c.rl = rl
+			c.limiterMutex.Unlock()
 		}
 
-		c.limiterMutex.Unlock()
 		return OrderInfoDTO{}, ErrRateLimit
 	}
 
@@ -91,6 +120,81 @@ func (c *HTTPAccrualClient) GetOrderInfo(ctx context.Context, orderID string) (O
 	return *result, nil
 }
 
+// RateLimitState returns the current rate limit and pause of the client.
+func (c *HTTPAccrualClient) RateLimitState() RateLimitState {
+	c.limiterMutex.Lock()
+	defer c.limiterMutex.Unlock()
+
+	state := RateLimitState{Limit: c.rl}
+
+	if c.pausedUntil.After(time.Now()) {
+		pausedUntil := c.pausedUntil
+		state.PausedUntil = &pausedUntil
+	}
+
+	return state
+}
+
+// pause stops requests for d. A longer pause set by a concurrent response is kept.
+func (c *HTTPAccrualClient) pause(d time.Duration) {
+	until := time.Now().Add(d)
+
+	c.limiterMutex.Lock()
+	defer c.limiterMutex.Unlock()
+
+	if until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+}
+
+func (c *HTTPAccrualClient) waitPause(ctx context.Context) error {
+	for {
+		c.limiterMutex.Lock()
+		wait := time.Until(c.pausedUntil)
+		c.limiterMutex.Unlock()
+
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// parseRetryAfter parses Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+
+	if err != nil {
+		return 0, false
+	}
+
+	if !at.After(now) {
+		return 0, true
+	}
+
+	return at.Sub(now), true
+}
+
 func NewHTTPAccrualClient(endpointTemplate string) *HTTPAccrualClient {
 	return &HTTPAccrualClient{
 		endpointTemplate: endpointTemplate,
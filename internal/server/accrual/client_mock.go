@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/accrual/client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/accrual/client.go -destination=./internal/server/accrual/client_mock.go -package=accrual
+//
+
+// Package accrual is a generated GoMock package.
+package accrual
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccrualClient is a mock of AccrualClient interface.
+type MockAccrualClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccrualClientMockRecorder
+}
+
+// MockAccrualClientMockRecorder is the mock recorder for MockAccrualClient.
+type MockAccrualClientMockRecorder struct {
+	mock *MockAccrualClient
+}
+
+// NewMockAccrualClient creates a new mock instance.
+func NewMockAccrualClient(ctrl *gomock.Controller) *MockAccrualClient {
+	mock := &MockAccrualClient{ctrl: ctrl}
+	mock.recorder = &MockAccrualClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccrualClient) EXPECT() *MockAccrualClientMockRecorder {
+	return m.recorder
+}
+
+// GetOrderInfo mocks base method.
+func (m *MockAccrualClient) GetOrderInfo(ctx context.Context, orderID string) (OrderInfoDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderInfo", ctx, orderID)
+	ret0, _ := ret[0].(OrderInfoDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderInfo indicates an expected call of GetOrderInfo.
+func (mr *MockAccrualClientMockRecorder) GetOrderInfo(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderInfo", reflect.TypeOf((*MockAccrualClient)(nil).GetOrderInfo), ctx, orderID)
+}
@@ -0,0 +1,89 @@
+package accrual_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPAccrualClient_rateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAfter    string
+		body          string
+		expectedLimit int
+		expectedPause time.Duration
+	}{
+		{
+			name:          "should pause for Retry-After seconds and limit rate",
+			retryAfter:    "60",
+			body:          "No more than 10 requests per minute allowed",
+			expectedLimit: 10,
+			expectedPause: time.Minute,
+		},
+		{
+			name:          "should pause until Retry-After date",
+			retryAfter:    time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat),
+			body:          "",
+			expectedLimit: 0,
+			expectedPause: 2 * time.Minute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.Header().Set("Retry-After", tc.retryAfter)
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			client := accrual.NewHTTPAccrualClient(fmt.Sprintf("%s/api/orders/", ts.URL) + "%s")
+
+			_, err := client.GetOrderInfo(context.Background(), "2377225624")
+
+			require.True(t, errors.Is(err, accrual.ErrRateLimit))
+
+			state := client.RateLimitState()
+
+			require.Equal(t, tc.expectedLimit, state.Limit)
+			require.NotNil(t, state.PausedUntil)
+			require.WithinDuration(t, time.Now().Add(tc.expectedPause), *state.PausedUntil, 2*time.Second)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err = client.GetOrderInfo(ctx, "2377225624")
+
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestHTTPAccrualClient_rateLimitWithoutRetryAfter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("No more than 600 requests per minute allowed"))
+	}))
+	defer ts.Close()
+
+	client := accrual.NewHTTPAccrualClient(fmt.Sprintf("%s/api/orders/", ts.URL) + "%s")
+
+	_, err := client.GetOrderInfo(context.Background(), "2377225624")
+
+	require.True(t, errors.Is(err, accrual.ErrRateLimit))
+	require.Equal(t, accrual.RateLimitState{Limit: 600}, client.RateLimitState())
+}
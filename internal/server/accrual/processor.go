@@ -3,10 +3,13 @@ package accrual
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/order"
 	"github.com/sodiqit/gophermart/internal/server/repository"
 )
 
@@ -14,13 +17,162 @@ type AccrualProcessor interface {
 	Run(ctx context.Context) error
 }
 
+// pollInterval is how long the processor waits when there are no orders to claim.
+const pollInterval = 5 * time.Second
+
+// availability is implemented by clients which know that the accrual system is down, e.g. CircuitBreakerClient.
+type availability interface {
+	UnavailableFor() time.Duration
+}
+
+// OrderProcessor polls the accrual system for orders leased to it. Leases let several gophermart replicas
+// process orders side by side: an order is claimed by one processor at a time and can be reclaimed
+// by another one once the lease expires, e.g. when its processor crashed. Orders the accrual system does not
+// resolve are polled less and less often and finally dead-lettered, so they do not starve the queue. When the accrual
+// system pushes results to the webhook, orders are polled only as a fallback once pushes stop for pushTimeout.
 type OrderProcessor struct {
-	poolSize   int
-	orderQueue chan string
-	orderRepo  repository.OrderRepository
-	wg         sync.WaitGroup
-	logger     logger.Logger
-	client     AccrualClient
+	poolSize    int
+	orderQueue  chan string
+	orderRepo   repository.OrderRepository
+	ledgerRepo  repository.LedgerRepository
+	outboxRepo  repository.OutboxRepository
+	webhookRepo repository.WebhookRepository
+	notifier    order.StatusNotifier
+	transactor  repository.Transactor
+	wg          sync.WaitGroup
+	logger      logger.Logger
+	client      AccrualClient
+	owner       string
+	leaseTTL    time.Duration
+	retryPolicy dtos.RetryPolicy
+	pushTimeout time.Duration
+}
+
+// applyOrderInfo moves the order to the status reported by the accrual system, records the transition, notifies
+// the user webhooks and, once the order is processed, posts the accrual to the ledger and emits
+// OutboxEventOrderProcessed in the same transaction. The status must already be mapped with order.MapAccrualStatus.
+// Posting is idempotent, so reprocessing an order never accrues twice. It returns the owner of the order if its
+// status changed, zero otherwise. The owner streams are notified by the caller once the transaction commits.
+func (p *OrderProcessor) applyOrderInfo(ctx context.Context, info OrderInfoDTO) (int, error) {
+	op := "orderProcessor.applyOrderInfo"
+
+	var changedFor int
+
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := p.orderRepo.LockOrder(ctx, info.OrderID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		// changes of finalized orders are handled by Rechecker
+		if order.IsFinal(current.Status) || current.Status == info.Status {
+			return nil
+		}
+
+		if err := order.ValidateTransition(current.Status, info.Status); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = p.orderRepo.UpdateOrder(ctx, info.OrderID, info.Status, info.Accrual)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = p.orderRepo.CreateStatusChange(ctx, dtos.OrderStatusChange{
+			OrderID:    info.OrderID,
+			FromStatus: current.Status,
+			Status:     info.Status,
+			Accrual:    info.Accrual,
+			Source:     repository.OrderStatusSourceAccrual,
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		updated := current
+		updated.Status = info.Status
+		updated.Accrual = info.Accrual
+
+		if err := p.webhookRepo.EnqueueDeliveries(ctx, current.UserID, repository.WebhookEventOrderStatusChanged, updated); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		changedFor = current.UserID
+
+		if info.Status != repository.OrderStatusProcessed {
+			return nil
+		}
+
+		err = p.outboxRepo.Add(ctx, repository.OutboxEventOrderProcessed, current.ID, dtos.OrderProcessedEvent{
+			OrderID:     current.ID,
+			UserID:      current.UserID,
+			Accrual:     info.Accrual,
+			ProcessedAt: time.Now(),
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if info.Accrual == nil || *info.Accrual <= 0 {
+			return nil
+		}
+
+		_, err = p.ledgerRepo.Post(ctx, repository.NewAccrualPosting(current.UserID, current.ID, *info.Accrual))
+
+		if err != nil && !errors.Is(err, repository.ErrLedgerDuplicatePosting) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return changedFor, nil
+}
+
+// ApplyPushedOrderInfo applies the order info pushed by the accrual system the same way as polled one.
+// Polling the order is postponed for the push timeout. ErrOrderNotFound of repository is returned for unknown orders.
+func (p *OrderProcessor) ApplyPushedOrderInfo(ctx context.Context, info OrderInfoDTO) error {
+	op := "orderProcessor.applyPushedOrderInfo"
+
+	status, err := order.MapAccrualStatus(info.Status)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	info.Status = status
+
+	var changedFor int
+
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.orderRepo.MarkOrderPushed(ctx, info.OrderID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		changedFor, err = p.applyOrderInfo(ctx, info)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	p.notify(changedFor)
+
+	return nil
 }
 
 func (p *OrderProcessor) worker(ctx context.Context, workerID int) {
@@ -34,28 +186,81 @@ func (p *OrderProcessor) worker(ctx context.Context, workerID int) {
 			if !ok {
 				return
 			}
-			logger.Debugw("process order", "orderID", orderID)
+			p.processOrder(ctx, logger, orderID)
+			p.wg.Done()
+		}
+	}
+}
 
-			result, err := p.client.GetOrderInfo(ctx, orderID)
+// processOrder polls the accrual system for the order. The lease of an order with a final status is released,
+// an order the accrual system has not resolved yet is retried with backoff until it is dead-lettered.
+// Rate limited orders and orders not sent because the circuit is open are released right away, they were not attempted.
+func (p *OrderProcessor) processOrder(ctx context.Context, logger logger.Logger, orderID string) {
+	logger.Debugw("process order", "orderID", orderID)
 
-			if err != nil {
-				if !(errors.Is(err, ErrOrderNotFound) || errors.Is(err, ErrRateLimit)) {
-					logger.Errorw("failed to get order info", "err", err)
-				}
-				p.wg.Done()
-				continue
-			}
+	result, err := p.client.GetOrderInfo(ctx, orderID)
 
-			err = p.orderRepo.UpdateOrder(ctx, result.OrderID, result.Status, result.Accrual)
+	switch {
+	case errors.Is(err, ErrRateLimit) || errors.Is(err, ErrCircuitOpen):
+		p.releaseOrder(ctx, logger, orderID)
+		return
+	case errors.Is(err, ErrOrderNotFound):
+		p.retryOrder(ctx, logger, orderID, "order is not registered in accrual system")
+		return
+	case err != nil && ctx.Err() != nil:
+		return
+	case err != nil:
+		logger.Errorw("failed to get order info", "err", err)
+		p.retryOrder(ctx, logger, orderID, err.Error())
+		return
+	}
 
-			if err != nil {
-				logger.Errorw("failed to update order", "err", err)
-			} else {
-				logger.Debugw("success process order", "orderID", orderID)
-			}
+	externalStatus := result.Status
 
-			p.wg.Done()
+	result.Status, err = order.MapAccrualStatus(externalStatus)
+
+	var changedFor int
+
+	if err == nil {
+		changedFor, err = p.applyOrderInfo(ctx, result)
+	}
+
+	if err != nil {
+		logger.Errorw("failed to update order", "err", err)
+		p.retryOrder(ctx, logger, orderID, err.Error())
+		return
+	}
+
+	p.notify(changedFor)
+
+	if !order.IsFinal(result.Status) {
+		p.retryOrder(ctx, logger, orderID, fmt.Sprintf("order is %s in accrual system", externalStatus))
+		return
+	}
+
+	logger.Debugw("success process order", "orderID", orderID)
+	p.releaseOrder(ctx, logger, orderID)
+}
+
+// on shutdown releasing fails with ctx, the lease then expires by itself
+func (p *OrderProcessor) releaseOrder(ctx context.Context, logger logger.Logger, orderID string) {
+	if err := p.orderRepo.ReleaseOrder(ctx, orderID, p.owner); err != nil && ctx.Err() == nil {
+		logger.Errorw("failed to release order", "orderID", orderID, "err", err)
+	}
+}
+
+func (p *OrderProcessor) retryOrder(ctx context.Context, logger logger.Logger, orderID string, reason string) {
+	deadLettered, err := p.orderRepo.ScheduleRetry(ctx, orderID, p.owner, p.retryPolicy, reason)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Errorw("failed to schedule order retry", "orderID", orderID, "err", err)
 		}
+		return
+	}
+
+	if deadLettered {
+		logger.Warnw("order moved to dead letter", "orderID", orderID, "reason", reason)
 	}
 }
 
@@ -64,7 +269,7 @@ func (p *OrderProcessor) Run(ctx context.Context) error {
 		go p.worker(ctx, i)
 	}
 
-	p.logger.Infow("start processing orders")
+	p.logger.Infow("start processing orders", "owner", p.owner)
 
 	for {
 		select {
@@ -74,10 +279,27 @@ func (p *OrderProcessor) Run(ctx context.Context) error {
 			p.wg.Wait()
 			return ctx.Err()
 		default:
-			orderList, err := p.orderRepo.GetOrdersForProcessing(ctx, int64(p.poolSize)) // TODO: handle if orders deadlock in accrual system
+			if wait := p.unavailableFor(); wait > 0 {
+				p.logger.Infow("accrual system is unavailable, pause processing orders", "wait", wait)
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(wait):
+				}
+				continue
+			}
+
+			orderList, err := p.orderRepo.ClaimOrdersForProcessing(ctx, p.owner, p.leaseTTL, p.pushTimeout, int64(p.poolSize))
+
+			if err != nil {
+				p.logger.Errorw("failed to claim orders", "err", err)
+			}
 
 			if err != nil || len(orderList) == 0 {
-				time.Sleep(5 * time.Second)
+				select {
+				case <-ctx.Done():
+				case <-time.After(pollInterval):
+				}
 				continue
 			}
 
@@ -90,13 +312,37 @@ func (p *OrderProcessor) Run(ctx context.Context) error {
 	}
 }
 
-func NewOrderProcessor(poolSize int, orderRepo repository.OrderRepository, logger logger.Logger, client AccrualClient) *OrderProcessor {
+// notify wakes up the order streams of the user, zero userID means no order changed.
+func (p *OrderProcessor) notify(userID int) {
+	if userID != 0 && p.notifier != nil {
+		p.notifier.Notify(userID)
+	}
+}
+
+func (p *OrderProcessor) unavailableFor() time.Duration {
+	if a, ok := p.client.(availability); ok {
+		return a.UnavailableFor()
+	}
+
+	return 0
+}
+
+func NewOrderProcessor(poolSize int, orderRepo repository.OrderRepository, ledgerRepo repository.LedgerRepository, outboxRepo repository.OutboxRepository, webhookRepo repository.WebhookRepository, notifier order.StatusNotifier, transactor repository.Transactor, logger logger.Logger, client AccrualClient, leaseTTL time.Duration, retryPolicy dtos.RetryPolicy, pushTimeout time.Duration) *OrderProcessor {
 	return &OrderProcessor{
-		poolSize:   poolSize,
-		orderRepo:  orderRepo,
-		orderQueue: make(chan string, poolSize),
-		wg:         sync.WaitGroup{},
-		logger:     logger,
-		client:     client,
+		poolSize:    poolSize,
+		orderRepo:   orderRepo,
+		ledgerRepo:  ledgerRepo,
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		notifier:    notifier,
+		transactor:  transactor,
+		orderQueue:  make(chan string, poolSize),
+		wg:          sync.WaitGroup{},
+		logger:      logger,
+		client:      client,
+		owner:       repository.NewLeaseOwner(),
+		leaseTTL:    leaseTTL,
+		retryPolicy: retryPolicy,
+		pushTimeout: pushTimeout,
 	}
 }
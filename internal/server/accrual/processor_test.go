@@ -0,0 +1,195 @@
+package accrual_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderProcessor_run(t *testing.T) {
+	orderID := "2377225624"
+	accrualValue := points.FromMinor(50000)
+	retryPolicy := dtos.RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, MaxAge: time.Hour}
+
+	tests := []struct {
+		name      string
+		setupMock func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient)
+	}{
+		{
+			name: "should process claimed order and release lease",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessed, Accrual: &accrualValue}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusNew}, nil)
+				orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), orderID, repository.OrderStatusProcessed, &accrualValue).Return(nil)
+				orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), dtos.OrderStatusChange{OrderID: orderID, FromStatus: repository.OrderStatusNew, Status: repository.OrderStatusProcessed, Accrual: &accrualValue, Source: repository.OrderStatusSourceAccrual}).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusProcessed, Accrual: &accrualValue}).Return(nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventOrderProcessed, orderID, gomock.Any()).DoAndReturn(func(ctx context.Context, eventType string, aggregateID string, payload any) error {
+					event := payload.(dtos.OrderProcessedEvent)
+					require.Equal(t, orderID, event.OrderID)
+					require.Equal(t, 1, event.UserID)
+					require.Equal(t, &accrualValue, event.Accrual)
+					return nil
+				})
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), repository.NewAccrualPosting(1, orderID, accrualValue)).Return(int64(1), nil)
+				orderRepoMock.EXPECT().ReleaseOrder(gomock.Any(), orderID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "should schedule retry if accrual system failed",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, errors.New("connection refused"))
+				orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, "connection refused").Return(false, nil)
+			},
+		},
+		{
+			name: "should schedule retry if order is still processing",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessing}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusNew}, nil)
+				orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), orderID, repository.OrderStatusProcessing, nil).Return(nil)
+				orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), gomock.Any()).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, gomock.Any()).Return(nil)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, "order is PROCESSING in accrual system").Return(true, nil)
+			},
+		},
+		{
+			name: "should map registered order to new without transition",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: "REGISTERED"}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusNew}, nil)
+				orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, "order is REGISTERED in accrual system").Return(false, nil)
+			},
+		},
+		{
+			name: "should not move order back",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: "REGISTERED"}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusProcessing}, nil)
+				orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "should schedule retry if status is unknown",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: "DONE"}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "should schedule retry if order is not registered",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, accrual.ErrOrderNotFound)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), orderID, gomock.Any(), retryPolicy, gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "should release lease without retry if circuit is open",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, accrual.ErrCircuitOpen)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ReleaseOrder(gomock.Any(), orderID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "should release lease without retry if rate limited",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, ledgerRepoMock *repository.MockLedgerRepository, outboxRepoMock *repository.MockOutboxRepository, webhookRepoMock *repository.MockWebhookRepository, clientMock *accrual.MockAccrualClient) {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, accrual.ErrRateLimit)
+				orderRepoMock.EXPECT().ScheduleRetry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				orderRepoMock.EXPECT().ReleaseOrder(gomock.Any(), orderID, gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+			outboxRepoMock := repository.NewMockOutboxRepository(ctrl)
+			webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+			transactorMock := repository.NewMockTransactor(ctrl)
+			clientMock := accrual.NewMockAccrualClient(ctrl)
+
+			transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).AnyTimes()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var owner string
+
+			gomock.InOrder(
+				orderRepoMock.EXPECT().ClaimOrdersForProcessing(gomock.Any(), gomock.Any(), time.Minute, time.Duration(0), int64(2)).DoAndReturn(func(ctx context.Context, leaseOwner string, leaseTTL time.Duration, pushTimeout time.Duration, limit int64) ([]string, error) {
+					owner = leaseOwner
+					return []string{orderID}, nil
+				}),
+				orderRepoMock.EXPECT().ClaimOrdersForProcessing(gomock.Any(), gomock.Any(), time.Minute, time.Duration(0), int64(2)).DoAndReturn(func(ctx context.Context, leaseOwner string, leaseTTL time.Duration, pushTimeout time.Duration, limit int64) ([]string, error) {
+					cancel()
+					return nil, nil
+				}),
+			)
+
+			tc.setupMock(orderRepoMock, ledgerRepoMock, outboxRepoMock, webhookRepoMock, clientMock)
+
+			p := accrual.NewOrderProcessor(2, orderRepoMock, ledgerRepoMock, outboxRepoMock, webhookRepoMock, order.NewStatusBroker(), transactorMock, logger.New("info"), clientMock, time.Minute, retryPolicy, 0)
+
+			err := p.Run(ctx)
+
+			require.ErrorIs(t, err, context.Canceled)
+			require.NotEmpty(t, owner)
+		})
+	}
+}
+
+func TestOrderProcessor_applyPushedOrderInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepoMock := repository.NewMockOrderRepository(ctrl)
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	broker := order.NewStatusBroker()
+	notifications, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	p := accrual.NewOrderProcessor(1, orderRepoMock, repository.NewMockLedgerRepository(ctrl), repository.NewMockOutboxRepository(ctrl), webhookRepoMock, broker, transactorMock, logger.New("info"), accrual.NewMockAccrualClient(ctrl), time.Minute, dtos.RetryPolicy{}, time.Minute)
+
+	orderRepoMock.EXPECT().MarkOrderPushed(gomock.Any(), "2377225624").Return(nil)
+	orderRepoMock.EXPECT().LockOrder(gomock.Any(), "2377225624").Return(dtos.Order{ID: "2377225624", UserID: 1, Status: repository.OrderStatusNew}, nil)
+	orderRepoMock.EXPECT().UpdateOrder(gomock.Any(), "2377225624", repository.OrderStatusProcessing, nil).Return(nil)
+	orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), dtos.OrderStatusChange{OrderID: "2377225624", FromStatus: repository.OrderStatusNew, Status: repository.OrderStatusProcessing, Source: repository.OrderStatusSourceAccrual}).Return(nil)
+	webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, gomock.Any()).Return(nil)
+
+	err := p.ApplyPushedOrderInfo(context.Background(), accrual.OrderInfoDTO{OrderID: "2377225624", Status: repository.OrderStatusProcessing})
+
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+
+	orderRepoMock.EXPECT().MarkOrderPushed(gomock.Any(), "12345678903").Return(repository.ErrOrderNotFound)
+
+	err = p.ApplyPushedOrderInfo(context.Background(), accrual.OrderInfoDTO{OrderID: "12345678903", Status: repository.OrderStatusProcessing})
+
+	require.ErrorIs(t, err, repository.ErrOrderNotFound)
+}
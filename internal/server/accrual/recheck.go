@@ -0,0 +1,251 @@
+package accrual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const recheckBatchSize = 100
+
+// Rechecker periodically re-queries processed orders and compensates changed or revoked accruals:
+// the change is recorded as an order adjustment, posted to the ledger and emitted as OutboxEventOrderAccrualAdjusted.
+// A reversal may leave the user balance negative, further withdrawals are then rejected until it is covered.
+type Rechecker struct {
+	orderRepo   repository.OrderRepository
+	ledgerRepo  repository.LedgerRepository
+	outboxRepo  repository.OutboxRepository
+	webhookRepo repository.WebhookRepository
+	notifier    order.StatusNotifier
+	transactor  repository.Transactor
+	client      AccrualClient
+	logger      logger.Logger
+	interval    time.Duration
+	window      time.Duration
+}
+
+func (r *Rechecker) Run(ctx context.Context) error {
+	if r.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		r.recheckAll(ctx)
+	}
+}
+
+func (r *Rechecker) recheckAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		orderList, err := r.orderRepo.GetOrdersForRecheck(ctx, r.window, r.interval, recheckBatchSize)
+
+		if err != nil {
+			r.logger.Errorw("failed to get orders for recheck", "err", err)
+			return
+		}
+
+		if len(orderList) == 0 {
+			return
+		}
+
+		for _, orderID := range orderList {
+			if err := r.Recheck(ctx, orderID); err != nil {
+				r.logger.Errorw("failed to recheck order", "orderID", orderID, "err", err)
+				return
+			}
+		}
+	}
+}
+
+// Recheck re-queries a processed order and applies the change of its accrual, if any.
+func (r *Rechecker) Recheck(ctx context.Context, orderID string) error {
+	op := "rechecker.recheck"
+
+	info, err := r.client.GetOrderInfo(ctx, orderID)
+
+	if errors.Is(err, ErrOrderNotFound) {
+		return r.orderRepo.MarkAccrualChecked(ctx, orderID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	changedFor, err := r.applyRecheck(ctx, info)
+
+	if err != nil {
+		return err
+	}
+
+	if changedFor != 0 && r.notifier != nil {
+		r.notifier.Notify(changedFor)
+	}
+
+	return nil
+}
+
+// applyRecheck applies the rechecked order info in a transaction. It returns the owner of the order if its
+// status or accrual changed, zero otherwise. The owner streams are notified by the caller once the transaction commits.
+func (r *Rechecker) applyRecheck(ctx context.Context, info OrderInfoDTO) (int, error) {
+	op := "rechecker.applyRecheck"
+
+	var changedFor int
+
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := r.orderRepo.LockOrder(ctx, info.OrderID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if locked.Status != repository.OrderStatusProcessed {
+			return nil
+		}
+
+		var previous, current points.Points
+
+		if locked.Accrual != nil {
+			previous = *locked.Accrual
+		}
+
+		status := repository.OrderStatusProcessed
+
+		switch info.Status {
+		case repository.OrderStatusProcessed:
+			if info.Accrual != nil {
+				current = *info.Accrual
+			}
+		case repository.OrderStatusInvalid:
+			status = repository.OrderStatusInvalid
+		default:
+			// the accrual system is processing the order again, the result is checked next time
+			return r.orderRepo.MarkAccrualChecked(ctx, locked.ID)
+		}
+
+		if current == previous && status == locked.Status {
+			return r.orderRepo.MarkAccrualChecked(ctx, locked.ID)
+		}
+
+		reason := repository.OrderAdjustmentReasonChanged
+
+		if status == repository.OrderStatusInvalid || current == 0 {
+			reason = repository.OrderAdjustmentReasonRevoked
+		}
+
+		var accrual *points.Points
+
+		if status == repository.OrderStatusProcessed {
+			accrual = &current
+		}
+
+		if err := order.ValidateTransition(locked.Status, status); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := r.orderRepo.SetAccrual(ctx, locked.ID, status, accrual); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = r.orderRepo.CreateStatusChange(ctx, dtos.OrderStatusChange{
+			OrderID:    locked.ID,
+			FromStatus: locked.Status,
+			Status:     status,
+			Accrual:    accrual,
+			Source:     repository.OrderStatusSourceRecheck,
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		updated := locked
+		updated.Status = status
+		updated.Accrual = accrual
+
+		if err := r.webhookRepo.EnqueueDeliveries(ctx, locked.UserID, repository.WebhookEventOrderStatusChanged, updated); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		delta := current - previous
+
+		err = r.outboxRepo.Add(ctx, repository.OutboxEventOrderAccrualAdjusted, locked.ID, dtos.OrderAccrualAdjustedEvent{
+			OrderID:         locked.ID,
+			UserID:          locked.UserID,
+			Status:          status,
+			PreviousAccrual: previous,
+			Accrual:         current,
+			Amount:          delta,
+			Reason:          reason,
+			AdjustedAt:      time.Now(),
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		changedFor = locked.UserID
+
+		if delta == 0 {
+			return nil
+		}
+
+		adjustmentID, err := r.orderRepo.CreateAdjustment(ctx, dtos.OrderAdjustment{
+			OrderID:         locked.ID,
+			UserID:          locked.UserID,
+			PreviousAccrual: previous,
+			Accrual:         current,
+			Amount:          delta,
+			Reason:          reason,
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = r.ledgerRepo.Post(ctx, repository.NewAccrualAdjustmentPosting(locked.UserID, locked.ID, adjustmentID, delta))
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.logger.Infow("order accrual adjusted", "orderID", locked.ID, "userID", locked.UserID, "previous", previous, "accrual", current, "reason", reason)
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return changedFor, nil
+}
+
+func NewRechecker(orderRepo repository.OrderRepository, ledgerRepo repository.LedgerRepository, outboxRepo repository.OutboxRepository, webhookRepo repository.WebhookRepository, notifier order.StatusNotifier, transactor repository.Transactor, client AccrualClient, logger logger.Logger, interval time.Duration, window time.Duration) *Rechecker {
+	return &Rechecker{
+		orderRepo:   orderRepo,
+		ledgerRepo:  ledgerRepo,
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		notifier:    notifier,
+		transactor:  transactor,
+		client:      client,
+		logger:      logger,
+		interval:    interval,
+		window:      window,
+	}
+}
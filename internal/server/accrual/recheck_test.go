@@ -0,0 +1,179 @@
+package accrual_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRechecker_recheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepoMock := repository.NewMockOrderRepository(ctrl)
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+	outboxRepoMock := repository.NewMockOutboxRepository(ctrl)
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+	clientMock := accrual.NewMockAccrualClient(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	broker := order.NewStatusBroker()
+	notifications, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	r := accrual.NewRechecker(orderRepoMock, ledgerRepoMock, outboxRepoMock, webhookRepoMock, broker, transactorMock, clientMock, logger.New("info"), time.Hour, time.Hour)
+
+	orderID := "2377225624"
+	previous := points.FromMinor(500)
+	processedOrder := dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusProcessed, Accrual: &previous}
+
+	accrualOf := func(minor int64) *points.Points {
+		value := points.FromMinor(minor)
+		return &value
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		notified  bool
+	}{
+		{
+			name: "should mark checked if accrual not changed",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessed, Accrual: accrualOf(500)}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(processedOrder, nil)
+				orderRepoMock.EXPECT().MarkAccrualChecked(gomock.Any(), orderID).Return(nil)
+			},
+		},
+		{
+			name: "should mark checked if order unknown to accrual system",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{}, fmt.Errorf("%w: %s", accrual.ErrOrderNotFound, orderID))
+				orderRepoMock.EXPECT().MarkAccrualChecked(gomock.Any(), orderID).Return(nil)
+			},
+		},
+		{
+			name: "should post reversal if accrual decreased",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessed, Accrual: accrualOf(300)}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(processedOrder, nil)
+				orderRepoMock.EXPECT().SetAccrual(gomock.Any(), orderID, repository.OrderStatusProcessed, accrualOf(300)).Return(nil)
+				orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), dtos.OrderStatusChange{OrderID: orderID, FromStatus: repository.OrderStatusProcessed, Status: repository.OrderStatusProcessed, Accrual: accrualOf(300), Source: repository.OrderStatusSourceRecheck}).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, gomock.Any()).Return(nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventOrderAccrualAdjusted, orderID, gomock.Any()).DoAndReturn(func(ctx context.Context, eventType string, aggregateID string, payload any) error {
+					event := payload.(dtos.OrderAccrualAdjustedEvent)
+					event.AdjustedAt = time.Time{}
+
+					require.Equal(t, dtos.OrderAccrualAdjustedEvent{
+						OrderID:         orderID,
+						UserID:          1,
+						Status:          repository.OrderStatusProcessed,
+						PreviousAccrual: points.FromMinor(500),
+						Accrual:         points.FromMinor(300),
+						Amount:          points.FromMinor(-200),
+						Reason:          repository.OrderAdjustmentReasonChanged,
+					}, event)
+
+					return nil
+				})
+				orderRepoMock.EXPECT().CreateAdjustment(gomock.Any(), dtos.OrderAdjustment{
+					OrderID:         orderID,
+					UserID:          1,
+					PreviousAccrual: points.FromMinor(500),
+					Accrual:         points.FromMinor(300),
+					Amount:          points.FromMinor(-200),
+					Reason:          repository.OrderAdjustmentReasonChanged,
+				}).Return(int64(7), nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), dtos.LedgerPosting{
+					Type:           repository.LedgerTypeReversal,
+					Reference:      orderID + ":7",
+					UserID:         1,
+					Amount:         points.FromMinor(200),
+					UserDirection:  repository.LedgerDirectionDebit,
+					CounterAccount: repository.LedgerAccountAccruals,
+				}).Return(int64(1), nil)
+			},
+			notified: true,
+		},
+		{
+			name: "should post adjustment if accrual increased",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessed, Accrual: accrualOf(650)}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(processedOrder, nil)
+				orderRepoMock.EXPECT().SetAccrual(gomock.Any(), orderID, repository.OrderStatusProcessed, accrualOf(650)).Return(nil)
+				orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), gomock.Any()).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, gomock.Any()).Return(nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventOrderAccrualAdjusted, orderID, gomock.Any()).Return(nil)
+				orderRepoMock.EXPECT().CreateAdjustment(gomock.Any(), gomock.Any()).Return(int64(8), nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), dtos.LedgerPosting{
+					Type:           repository.LedgerTypeAdjustment,
+					Reference:      orderID + ":8",
+					UserID:         1,
+					Amount:         points.FromMinor(150),
+					UserDirection:  repository.LedgerDirectionCredit,
+					CounterAccount: repository.LedgerAccountAccruals,
+				}).Return(int64(1), nil)
+			},
+			notified: true,
+		},
+		{
+			name: "should revoke accrual if order became invalid",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusInvalid}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(processedOrder, nil)
+				orderRepoMock.EXPECT().SetAccrual(gomock.Any(), orderID, repository.OrderStatusInvalid, nil).Return(nil)
+				orderRepoMock.EXPECT().CreateStatusChange(gomock.Any(), dtos.OrderStatusChange{OrderID: orderID, FromStatus: repository.OrderStatusProcessed, Status: repository.OrderStatusInvalid, Source: repository.OrderStatusSourceRecheck}).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventOrderStatusChanged, gomock.Any()).Return(nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventOrderAccrualAdjusted, orderID, gomock.Any()).Return(nil)
+				orderRepoMock.EXPECT().CreateAdjustment(gomock.Any(), dtos.OrderAdjustment{
+					OrderID:         orderID,
+					UserID:          1,
+					PreviousAccrual: points.FromMinor(500),
+					Accrual:         points.FromMinor(0),
+					Amount:          points.FromMinor(-500),
+					Reason:          repository.OrderAdjustmentReasonRevoked,
+				}).Return(int64(9), nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), repository.NewAccrualAdjustmentPosting(1, orderID, 9, points.FromMinor(-500))).Return(int64(1), nil)
+			},
+			notified: true,
+		},
+		{
+			name: "should skip order which is not processed",
+			setupMock: func() {
+				clientMock.EXPECT().GetOrderInfo(gomock.Any(), orderID).Return(accrual.OrderInfoDTO{OrderID: orderID, Status: repository.OrderStatusProcessed, Accrual: accrualOf(300)}, nil)
+				orderRepoMock.EXPECT().LockOrder(gomock.Any(), orderID).Return(dtos.Order{ID: orderID, UserID: 1, Status: repository.OrderStatusProcessing}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := r.Recheck(context.Background(), orderID)
+
+			require.NoError(t, err)
+
+			if tc.notified {
+				require.Len(t, notifications, 1)
+				<-notifications
+			} else {
+				require.Empty(t, notifications)
+			}
+		})
+	}
+}
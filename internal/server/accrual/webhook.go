@@ -0,0 +1,161 @@
+package accrual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/signature"
+)
+
+const (
+	SignatureHeader = "X-Accrual-Signature"
+	// TimestampHeader carries the unix time in seconds the body was signed at.
+	TimestampHeader = "X-Accrual-Timestamp"
+)
+
+// signatureTolerance is how far the signing time may be from now, a captured request can not be replayed later.
+const signatureTolerance = 5 * time.Minute
+
+// maxWebhookBodySize limits the pushed body, it holds a single order.
+const maxWebhookBodySize = 64 << 10
+
+// OrderInfoApplier applies order info pushed by the accrual system, it is implemented by OrderProcessor.
+type OrderInfoApplier interface {
+	ApplyPushedOrderInfo(ctx context.Context, info OrderInfoDTO) error
+}
+
+// WebhookController receives order info pushed by the accrual system or a bridge. The unix time in seconds
+// is sent in X-Accrual-Timestamp and "<timestamp>.<body>" is signed with HMAC-SHA256 of the shared secret,
+// the hex digest is sent in X-Accrual-Signature as "sha256=<digest>". Requests signed more than
+// signatureTolerance away from now are rejected. With an empty secret the webhook is disabled and answers 404.
+type WebhookController struct {
+	logger  logger.Logger
+	secret  string
+	applier OrderInfoApplier
+}
+
+func (c *WebhookController) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.With(middleware.AllowContentType("application/json")).Post("/", c.handlePush)
+
+	return r
+}
+
+// handlePush godoc
+//
+//	@Summary		push order info from accrual system
+//	@Tags			accrual
+//
+//	@Param			body				body	OrderInfoDTO	true	"order info"
+//	@Param			X-Accrual-Timestamp	header	integer			true	"unix time in seconds the body was signed at"
+//	@Param			X-Accrual-Signature	header	string			true	"sha256=<hex HMAC-SHA256 of <timestamp>.<body>>"
+//	@Accept			json
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404	"order not found or webhook is disabled"
+//	@Failure		409	"order can not move to the pushed status"
+//	@Failure		500
+//	@Router			/api/accrual/webhook [post]
+func (c *WebhookController) handlePush(w http.ResponseWriter, r *http.Request) {
+	op := "webhookController.handlePush"
+
+	logger := c.logger.With("op", op)
+
+	if c.secret == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get(TimestampHeader)
+
+	if !validTimestamp(timestamp, time.Now()) {
+		http.Error(w, "Invalid signature timestamp", http.StatusUnauthorized)
+		return
+	}
+
+	if !signature.Valid(c.secret, SignedPayload(timestamp, body), r.Header.Get(SignatureHeader)) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var info OrderInfoDTO
+
+	if err := json.Unmarshal(body, &info); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := order.MapAccrualStatus(info.Status); err != nil || info.OrderID == "" || (info.Accrual != nil && *info.Accrual < 0) {
+		http.Error(w, "Invalid order info", http.StatusBadRequest)
+		return
+	}
+
+	err = c.applier.ApplyPushedOrderInfo(r.Context(), info)
+
+	if errors.Is(err, repository.ErrOrderNotFound) {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	// retrying a push moving the order backwards can not succeed, it is not a failure of gophermart
+	if errors.Is(err, order.ErrIllegalTransition) {
+		logger.Warnw("rejected pushed order info", "orderID", info.OrderID, "status", info.Status, "err", err)
+		http.Error(w, "Illegal order status transition", http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("failed to apply pushed order info", "orderID", info.OrderID, "err", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Debugw("applied pushed order info", "orderID", info.OrderID, "status", info.Status)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// validTimestamp reports whether the timestamp is unix seconds within signatureTolerance from now.
+func validTimestamp(timestamp string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return false
+	}
+
+	diff := now.Sub(time.Unix(seconds, 0))
+
+	return diff <= signatureTolerance && diff >= -signatureTolerance
+}
+
+// SignedPayload returns what is signed for the body sent at timestamp: "<timestamp>.<body>".
+func SignedPayload(timestamp string, body []byte) []byte {
+	return append([]byte(timestamp+"."), body...)
+}
+
+func NewWebhookController(logger logger.Logger, secret string, applier OrderInfoApplier) *WebhookController {
+	return &WebhookController{
+		logger,
+		secret,
+		applier,
+	}
+}
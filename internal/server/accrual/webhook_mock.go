@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/accrual/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/accrual/webhook.go -destination=./internal/server/accrual/webhook_mock.go -package=accrual
+//
+
+// Package accrual is a generated GoMock package.
+package accrual
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderInfoApplier is a mock of OrderInfoApplier interface.
+type MockOrderInfoApplier struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderInfoApplierMockRecorder
+}
+
+// MockOrderInfoApplierMockRecorder is the mock recorder for MockOrderInfoApplier.
+type MockOrderInfoApplierMockRecorder struct {
+	mock *MockOrderInfoApplier
+}
+
+// NewMockOrderInfoApplier creates a new mock instance.
+func NewMockOrderInfoApplier(ctrl *gomock.Controller) *MockOrderInfoApplier {
+	mock := &MockOrderInfoApplier{ctrl: ctrl}
+	mock.recorder = &MockOrderInfoApplierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderInfoApplier) EXPECT() *MockOrderInfoApplierMockRecorder {
+	return m.recorder
+}
+
+// ApplyPushedOrderInfo mocks base method.
+func (m *MockOrderInfoApplier) ApplyPushedOrderInfo(ctx context.Context, info OrderInfoDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPushedOrderInfo", ctx, info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPushedOrderInfo indicates an expected call of ApplyPushedOrderInfo.
+func (mr *MockOrderInfoApplierMockRecorder) ApplyPushedOrderInfo(ctx, info any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPushedOrderInfo", reflect.TypeOf((*MockOrderInfoApplier)(nil).ApplyPushedOrderInfo), ctx, info)
+}
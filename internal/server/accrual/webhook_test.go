@@ -0,0 +1,185 @@
+package accrual_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/sodiqit/gophermart/pkg/signature"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookController_handlePush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := "secret"
+	applierMock := accrual.NewMockOrderInfoApplier(ctrl)
+
+	r := chi.NewRouter()
+	r.Mount("/webhook", accrual.NewWebhookController(logger.New("info"), secret, applierMock).Route())
+	r.Mount("/disabled", accrual.NewWebhookController(logger.New("info"), "", applierMock).Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	signAt := func(timestamp string, body string) string {
+		return signature.Sign(secret, accrual.SignedPayload(timestamp, []byte(body)))
+	}
+
+	sign := func(body string) string {
+		return signAt(now, body)
+	}
+
+	accrualValue := points.FromMinor(50000)
+	processed := `{"order":"2377225624","status":"PROCESSED","accrual":500}`
+
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		timestamp      string
+		signature      string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:           "should return 404 if webhook is disabled",
+			url:            "/disabled",
+			body:           processed,
+			signature:      sign(processed),
+			setupMock:      func() {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 401 if signature is invalid",
+			url:            "/webhook",
+			body:           processed,
+			signature:      sign(`{"order":"2377225624","status":"INVALID"}`),
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 401 if signed too long ago",
+			url:            "/webhook",
+			body:           processed,
+			timestamp:      stale,
+			signature:      signAt(stale, processed),
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 401 if timestamp differs from the signed one",
+			url:            "/webhook",
+			body:           processed,
+			timestamp:      strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+			signature:      sign(processed),
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 401 if timestamp is not unix time",
+			url:            "/webhook",
+			body:           processed,
+			timestamp:      time.Now().Format(time.RFC3339),
+			signature:      sign(processed),
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 401 without signature",
+			url:            "/webhook",
+			body:           processed,
+			signature:      "",
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 400 if status is unknown",
+			url:            "/webhook",
+			body:           `{"order":"2377225624","status":"DONE"}`,
+			signature:      sign(`{"order":"2377225624","status":"DONE"}`),
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "should apply pushed order info",
+			url:       "/webhook",
+			body:      processed,
+			signature: sign(processed),
+			setupMock: func() {
+				applierMock.EXPECT().ApplyPushedOrderInfo(gomock.Any(), accrual.OrderInfoDTO{OrderID: "2377225624", Status: repository.OrderStatusProcessed, Accrual: &accrualValue}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "should return 404 if order not found",
+			url:       "/webhook",
+			body:      processed,
+			signature: sign(processed),
+			setupMock: func() {
+				applierMock.EXPECT().ApplyPushedOrderInfo(gomock.Any(), gomock.Any()).Return(fmt.Errorf("orderRepo.markOrderPushed: %w", repository.ErrOrderNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "should return 409 if order can not move to the pushed status",
+			url:       "/webhook",
+			body:      `{"order":"2377225624","status":"REGISTERED"}`,
+			signature: sign(`{"order":"2377225624","status":"REGISTERED"}`),
+			setupMock: func() {
+				applierMock.EXPECT().ApplyPushedOrderInfo(gomock.Any(), gomock.Any()).Return(fmt.Errorf("orderProcessor.applyOrderInfo: %w: PROCESSING -> NEW", order.ErrIllegalTransition))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:      "should return 500 if apply failed",
+			url:       "/webhook",
+			body:      processed,
+			signature: sign(processed),
+			setupMock: func() {
+				applierMock.EXPECT().ApplyPushedOrderInfo(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			timestamp := tc.timestamp
+
+			if timestamp == "" {
+				timestamp = now
+			}
+
+			res, err := client.R().
+				SetHeader(accrual.TimestampHeader, timestamp).
+				SetHeader("Content-Type", "application/json").
+				SetHeader(accrual.SignatureHeader, tc.signature).
+				SetBody(tc.body).
+				Post(tc.url)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+		})
+	}
+}
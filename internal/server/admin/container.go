@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+type AdminContainer struct {
+	Controller *AdminController
+}
+
+func NewContainer(config *config.Config, logger logger.Logger, orderRepo repository.OrderRepository, accrualRateLimiter AccrualRateLimiter) *AdminContainer {
+	adminService := NewSimpleAdminService(orderRepo, accrualRateLimiter)
+	adminController := NewController(logger, config.AdminAPIKey, adminService)
+
+	return &AdminContainer{
+		Controller: adminController,
+	}
+}
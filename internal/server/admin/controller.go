@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sodiqit/gophermart/internal/logger"
+)
+
+type AdminController struct {
+	logger       logger.Logger
+	apiKey       string
+	adminService AdminService
+}
+
+func (c *AdminController) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Use(APIKeyAuth(c.apiKey))
+
+	r.Get("/orders/dead-letter", c.handleGetDeadLetterOrders)
+	r.Post("/orders/{number}/requeue", c.handleRequeueOrder)
+	r.Get("/accrual/rate-limit", c.handleGetAccrualRateLimit)
+
+	return r
+}
+
+// handleGetDeadLetterOrders godoc
+//
+//	@Summary		get list of dead-lettered orders
+//	@Tags			admin
+//
+//	@Param			limit		query	int		false	"page size, 50 by default and 100 at most"
+//	@Param			offset		query	int		false	"number of orders to skip"
+//	@Param			X-Admin-Key	header	string	true	"admin API key"
+//	@Produce		json
+//	@Success		200	{array}	dtos.DeadLetterOrder
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404	"admin API is disabled"
+//	@Failure		500
+//	@Router			/api/admin/orders/dead-letter [get]
+func (c *AdminController) handleGetDeadLetterOrders(w http.ResponseWriter, r *http.Request) {
+	op := "adminController.handleGetDeadLetterOrders"
+
+	logger := c.logger.With("op", op)
+
+	limit, err := parseInt(r.URL.Query().Get("limit"))
+
+	if err != nil {
+		http.Error(w, "Invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	offset, err := parseInt(r.URL.Query().Get("offset"))
+
+	if err != nil {
+		http.Error(w, "Invalid offset", http.StatusBadRequest)
+		return
+	}
+
+	orders, err := c.adminService.GetDeadLetterOrders(r.Context(), limit, offset)
+
+	if err != nil {
+		logger.Errorw("error while get dead-lettered orders", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := json.Marshal(orders)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Write(result)
+}
+
+// handleRequeueOrder godoc
+//
+//	@Summary		requeue dead-lettered order
+//	@Tags			admin
+//
+//	@Param			number		path	string	true	"order number"
+//	@Param			X-Admin-Key	header	string	true	"admin API key"
+//	@Success		202
+//	@Failure		401
+//	@Failure		404	"order is not dead-lettered or admin API is disabled"
+//	@Failure		500
+//	@Router			/api/admin/orders/{number}/requeue [post]
+func (c *AdminController) handleRequeueOrder(w http.ResponseWriter, r *http.Request) {
+	op := "adminController.handleRequeueOrder"
+
+	logger := c.logger.With("op", op)
+
+	orderNumber := chi.URLParam(r, "number")
+
+	err := c.adminService.RequeueOrder(r.Context(), orderNumber)
+
+	if errors.Is(err, ErrOrderNotDeadLettered) {
+		http.Error(w, "Order is not dead-lettered", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while requeue order", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infow("order requeued", "orderID", orderNumber)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleGetAccrualRateLimit godoc
+//
+//	@Summary		get rate limit of accrual system
+//	@Description	limit is in requests per minute, paused_until is set while requests wait for Retry-After
+//	@Tags			admin
+//
+//	@Param			X-Admin-Key	header	string	true	"admin API key"
+//	@Produce		json
+//	@Success		200	{object}	accrual.RateLimitState
+//	@Failure		401
+//	@Failure		404	"admin API is disabled"
+//	@Failure		500
+//	@Router			/api/admin/accrual/rate-limit [get]
+func (c *AdminController) handleGetAccrualRateLimit(w http.ResponseWriter, r *http.Request) {
+	op := "adminController.handleGetAccrualRateLimit"
+
+	logger := c.logger.With("op", op)
+
+	result, err := json.Marshal(c.adminService.GetAccrualRateLimit(r.Context()))
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Write(result)
+}
+
+func parseInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func NewController(logger logger.Logger, apiKey string, adminService AdminService) *AdminController {
+	return &AdminController{
+		logger,
+		apiKey,
+		adminService,
+	}
+}
@@ -0,0 +1,136 @@
+package admin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/admin"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	adminServiceMock := admin.NewMockAdminService(ctrl)
+
+	r.Mount("/admin", admin.NewController(logger.New("info"), "secret", adminServiceMock).Route())
+	r.Mount("/disabled", admin.NewController(logger.New("info"), "", adminServiceMock).Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		apiKey         string
+		setupMock      func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "should return 404 if admin API is disabled",
+			method:         http.MethodGet,
+			url:            "/disabled/orders/dead-letter",
+			apiKey:         "",
+			setupMock:      func() {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 401 if API key is wrong",
+			method:         http.MethodGet,
+			url:            "/admin/orders/dead-letter",
+			apiKey:         "wrong",
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should return 400 if limit is invalid",
+			method:         http.MethodGet,
+			url:            "/admin/orders/dead-letter?limit=abc",
+			apiKey:         "secret",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "should list dead-lettered orders",
+			method: http.MethodGet,
+			url:    "/admin/orders/dead-letter?limit=10&offset=20",
+			apiKey: "secret",
+			setupMock: func() {
+				adminServiceMock.EXPECT().GetDeadLetterOrders(gomock.Any(), int64(10), int64(20)).Return([]dtos.DeadLetterOrder{{ID: "2377225624", UserID: 1, Status: "NEW", Attempts: 3, LastError: "connection refused"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"number":"2377225624","user_id":1,"status":"NEW","attempts":3,"last_error":"connection refused","uploaded_at":"0001-01-01T00:00:00Z","queued_at":"0001-01-01T00:00:00Z","dead_lettered_at":"0001-01-01T00:00:00Z"}]`,
+		},
+		{
+			name:   "should return accrual rate limit",
+			method: http.MethodGet,
+			url:    "/admin/accrual/rate-limit",
+			apiKey: "secret",
+			setupMock: func() {
+				adminServiceMock.EXPECT().GetAccrualRateLimit(gomock.Any()).Return(accrual.RateLimitState{Limit: 10})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"limit":10}`,
+		},
+		{
+			name:   "should requeue order",
+			method: http.MethodPost,
+			url:    "/admin/orders/2377225624/requeue",
+			apiKey: "secret",
+			setupMock: func() {
+				adminServiceMock.EXPECT().RequeueOrder(gomock.Any(), "2377225624").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:   "should return 404 if order is not dead-lettered",
+			method: http.MethodPost,
+			url:    "/admin/orders/2377225624/requeue",
+			apiKey: "secret",
+			setupMock: func() {
+				adminServiceMock.EXPECT().RequeueOrder(gomock.Any(), "2377225624").Return(admin.ErrOrderNotDeadLettered)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "should return 500 if requeue failed",
+			method: http.MethodPost,
+			url:    "/admin/orders/2377225624/requeue",
+			apiKey: "secret",
+			setupMock: func() {
+				adminServiceMock.EXPECT().RequeueOrder(gomock.Any(), "2377225624").Return(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			res, err := client.R().SetHeader(admin.APIKeyHeader, tc.apiKey).Execute(tc.method, tc.url)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, res.String())
+			}
+		})
+	}
+}
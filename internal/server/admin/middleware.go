@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+const APIKeyHeader = "X-Admin-Key"
+
+// APIKeyAuth lets through requests with the admin API key in X-Admin-Key header.
+// With an empty key the admin API is disabled and every request is answered with 404.
+func APIKeyAuth(apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey == "" {
+				http.NotFound(w, r)
+				return
+			}
+
+			key := r.Header.Get(APIKeyHeader)
+
+			if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+const (
+	DefaultDeadLetterLimit = 50
+	MaxDeadLetterLimit     = 100
+)
+
+var ErrOrderNotDeadLettered = errors.New("order is not dead-lettered")
+
+type AdminService interface {
+	GetDeadLetterOrders(ctx context.Context, limit int64, offset int64) ([]dtos.DeadLetterOrder, error)
+	RequeueOrder(ctx context.Context, orderNumber string) error
+	GetAccrualRateLimit(ctx context.Context) accrual.RateLimitState
+}
+
+// AccrualRateLimiter reports the rate limit state of the accrual system client.
+type AccrualRateLimiter interface {
+	RateLimitState() accrual.RateLimitState
+}
+
+type SimpleAdminService struct {
+	orderRepo          repository.OrderRepository
+	accrualRateLimiter AccrualRateLimiter
+}
+
+// GetDeadLetterOrders returns a page of dead-lettered orders, limit is clamped to MaxDeadLetterLimit.
+func (s *SimpleAdminService) GetDeadLetterOrders(ctx context.Context, limit int64, offset int64) ([]dtos.DeadLetterOrder, error) {
+	op := "adminService.getDeadLetterOrders"
+
+	if limit <= 0 {
+		limit = DefaultDeadLetterLimit
+	}
+
+	if limit > MaxDeadLetterLimit {
+		limit = MaxDeadLetterLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, err := s.orderRepo.GetDeadLetterOrders(ctx, limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+// RequeueOrder puts the dead-lettered order back into processing, it is claimed by the next processor poll.
+func (s *SimpleAdminService) RequeueOrder(ctx context.Context, orderNumber string) error {
+	op := "adminService.requeueOrder"
+
+	err := s.orderRepo.RequeueOrder(ctx, orderNumber)
+
+	if errors.Is(err, repository.ErrOrderNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrOrderNotDeadLettered)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *SimpleAdminService) GetAccrualRateLimit(ctx context.Context) accrual.RateLimitState {
+	return s.accrualRateLimiter.RateLimitState()
+}
+
+func NewSimpleAdminService(orderRepo repository.OrderRepository, accrualRateLimiter AccrualRateLimiter) *SimpleAdminService {
+	return &SimpleAdminService{
+		orderRepo:          orderRepo,
+		accrualRateLimiter: accrualRateLimiter,
+	}
+}
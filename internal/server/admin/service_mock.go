@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/admin/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/admin/service.go -destination=./internal/server/admin/service_mock.go -package=admin
+//
+
+// Package admin is a generated GoMock package.
+package admin
+
+import (
+	context "context"
+	reflect "reflect"
+
+	accrual "github.com/sodiqit/gophermart/internal/server/accrual"
+	dtos "github.com/sodiqit/gophermart/internal/server/dtos"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminService is a mock of AdminService interface.
+type MockAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceMockRecorder
+}
+
+// MockAdminServiceMockRecorder is the mock recorder for MockAdminService.
+type MockAdminServiceMockRecorder struct {
+	mock *MockAdminService
+}
+
+// NewMockAdminService creates a new mock instance.
+func NewMockAdminService(ctrl *gomock.Controller) *MockAdminService {
+	mock := &MockAdminService{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminService) EXPECT() *MockAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAccrualRateLimit mocks base method.
+func (m *MockAdminService) GetAccrualRateLimit(ctx context.Context) accrual.RateLimitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccrualRateLimit", ctx)
+	ret0, _ := ret[0].(accrual.RateLimitState)
+	return ret0
+}
+
+// GetAccrualRateLimit indicates an expected call of GetAccrualRateLimit.
+func (mr *MockAdminServiceMockRecorder) GetAccrualRateLimit(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccrualRateLimit", reflect.TypeOf((*MockAdminService)(nil).GetAccrualRateLimit), ctx)
+}
+
+// GetDeadLetterOrders mocks base method.
+func (m *MockAdminService) GetDeadLetterOrders(ctx context.Context, limit, offset int64) ([]dtos.DeadLetterOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterOrders", ctx, limit, offset)
+	ret0, _ := ret[0].([]dtos.DeadLetterOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterOrders indicates an expected call of GetDeadLetterOrders.
+func (mr *MockAdminServiceMockRecorder) GetDeadLetterOrders(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterOrders", reflect.TypeOf((*MockAdminService)(nil).GetDeadLetterOrders), ctx, limit, offset)
+}
+
+// RequeueOrder mocks base method.
+func (m *MockAdminService) RequeueOrder(ctx context.Context, orderNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOrder", ctx, orderNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueOrder indicates an expected call of RequeueOrder.
+func (mr *MockAdminServiceMockRecorder) RequeueOrder(ctx, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOrder", reflect.TypeOf((*MockAdminService)(nil).RequeueOrder), ctx, orderNumber)
+}
+
+// MockAccrualRateLimiter is a mock of AccrualRateLimiter interface.
+type MockAccrualRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccrualRateLimiterMockRecorder
+}
+
+// MockAccrualRateLimiterMockRecorder is the mock recorder for MockAccrualRateLimiter.
+type MockAccrualRateLimiterMockRecorder struct {
+	mock *MockAccrualRateLimiter
+}
+
+// NewMockAccrualRateLimiter creates a new mock instance.
+func NewMockAccrualRateLimiter(ctrl *gomock.Controller) *MockAccrualRateLimiter {
+	mock := &MockAccrualRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockAccrualRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccrualRateLimiter) EXPECT() *MockAccrualRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// RateLimitState mocks base method.
+func (m *MockAccrualRateLimiter) RateLimitState() accrual.RateLimitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitState")
+	ret0, _ := ret[0].(accrual.RateLimitState)
+	return ret0
+}
+
+// RateLimitState indicates an expected call of RateLimitState.
+func (mr *MockAccrualRateLimiterMockRecorder) RateLimitState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitState", reflect.TypeOf((*MockAccrualRateLimiter)(nil).RateLimitState))
+}
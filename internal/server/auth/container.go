@@ -8,21 +8,56 @@ import (
 
 type AuthContainer struct {
 	TokenService      TokenService
+	RevocationStore   *CachedRevocationStore
 	SimpleAuthService AuthService
 	Controller        *AuthController
 	GRPCServer        *AuthServer
+	JWKSController    *JWKSController
 }
 
-func NewContainer(config *config.Config, logger logger.Logger, userRepo repository.UserRepository) *AuthContainer {
-	tokenService := NewJWTTokenService(config.JWTSecretKey, config.JWTTimeExp)
-	authService := NewSimpleAuthService(tokenService, userRepo)
-	authController := NewController(logger, authService)
+func NewContainer(config *config.Config, logger logger.Logger, userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, passwordResetRepo repository.PasswordResetRepository, revocationRepo repository.TokenRevocationRepository, transactor repository.Transactor) (*AuthContainer, error) {
+	var keys *KeySet
+	var jwtTokenService *JWTTokenService
+
+	if config.JWTSigningKeyFile != "" {
+		var err error
+
+		keys, err = LoadKeySet(config.JWTSigningKeyFile, SplitKeyFiles(config.JWTVerificationKeyFiles))
+
+		if err != nil {
+			return nil, err
+		}
+
+		jwtTokenService = NewKeySetJWTTokenService(keys, config.JWTTimeExp)
+	} else {
+		jwtTokenService = NewJWTTokenService(config.JWTSecretKey, config.JWTTimeExp)
+	}
+
+	var notifier PasswordResetNotifier
+
+	if config.PasswordResetNotifier != "" {
+		var err error
+
+		notifier, err = NewNotifier(config.PasswordResetNotifier, config.PasswordResetFile, logger)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	revocationStore := NewCachedRevocationStore(revocationRepo, logger, config.TokenRevocationCacheTTL)
+	tokenService := NewRevocableTokenService(jwtTokenService, revocationStore)
+	authService := NewSimpleAuthService(tokenService, userRepo, refreshTokenRepo, passwordResetRepo, revocationStore, notifier, transactor, config.RefreshTokenTTL, config.PasswordResetTokenTTL)
+	authController := NewController(logger, tokenService, authService)
 	authServer := NewAuthServer(logger, authService)
+	jwksController := NewJWKSController(logger, keys)
 
 	return &AuthContainer{
 		TokenService:      tokenService,
+		RevocationStore:   revocationStore,
 		SimpleAuthService: authService,
 		Controller:        authController,
 		GRPCServer:        authServer,
-	}
+		JWKSController:    jwksController,
+	}, nil
 }
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -8,12 +9,14 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
 	"github.com/sodiqit/gophermart/internal/utils"
 )
 
 type AuthController struct {
-	logger      logger.Logger
-	authService AuthService
+	logger       logger.Logger
+	tokenService TokenService
+	authService  AuthService
 }
 
 func (c *AuthController) Route() *chi.Mux {
@@ -23,6 +26,13 @@ func (c *AuthController) Route() *chi.Mux {
 
 	r.Post("/register", c.handleRegister)
 	r.Post("/login", c.handleLogin)
+	r.Post("/token/refresh", c.handleRefresh)
+	r.With(JWTAuth(c.tokenService)).Post("/logout", c.handleLogout)
+	r.With(JWTAuth(c.tokenService)).Post("/logout/all", c.handleLogoutAll)
+	r.With(JWTAuth(c.tokenService)).Post("/password", c.handleChangePassword)
+	r.Post("/password/reset/request", c.handleRequestPasswordReset)
+	r.Post("/password/reset", c.handleResetPassword)
+	r.With(JWTAuth(c.tokenService)).Delete("/account", c.handleDeleteAccount)
 
 	return r
 }
@@ -36,7 +46,8 @@ func (c *AuthController) Route() *chi.Mux {
 //	@Param			body body	RegisterRequestDTO	true	"Register body"
 //
 //	@Accept			json
-//	@Success		200
+//	@Produce		json
+//	@Success		200	{object}	dtos.TokenPair
 //	@Failure		409
 //	@Failure		500
 //	@Header			200	{string}	Authorization	"Bearer token"
@@ -55,15 +66,14 @@ func (c *AuthController) handleRegister(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	token, err := c.authService.Register(r.Context(), dto.Username, dto.Password)
+	tokens, err := c.authService.Register(r.Context(), dto.Username, dto.Password)
 
 	if err != nil {
 		mapRegisterErrorToHTTPError(w, err, logger, dto)
 		return
 	}
 
-	w.Header().Add("Authorization", fmt.Sprintf("Bearer %s", token))
-	w.WriteHeader(200)
+	writeTokens(w, tokens, logger)
 }
 
 // handleLogin godoc
@@ -75,7 +85,8 @@ func (c *AuthController) handleRegister(w http.ResponseWriter, r *http.Request)
 //	@Param			body body	LoginRequestDTO	true	"Login body"
 //
 //	@Accept			json
-//	@Success		200
+//	@Produce		json
+//	@Success		200	{object}	dtos.TokenPair
 //	@Failure		401
 //	@Failure		500
 //	@Header			200	{string}	Authorization	"Bearer token"
@@ -94,20 +105,337 @@ func (c *AuthController) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := c.authService.Login(r.Context(), dto.Username, dto.Password)
+	tokens, err := c.authService.Login(r.Context(), dto.Username, dto.Password)
 
 	if err != nil {
 		mapLoginErrorToHTTPError(w, err, logger, dto)
 		return
 	}
 
-	w.Header().Add("Authorization", fmt.Sprintf("Bearer %s", token))
-	w.WriteHeader(200)
+	writeTokens(w, tokens, logger)
 }
 
-func NewController(logger logger.Logger, authService AuthService) *AuthController {
+// handleRefresh godoc
+//
+//	@Summary		refresh access token
+//	@Description	exchange the refresh token for a new access token and a new refresh token. The refresh token can be exchanged once, presenting it again revokes all tokens refreshed from the same login
+//	@Tags			auth
+//
+//	@Param			body body	RefreshRequestDTO	true	"Refresh body"
+//
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	dtos.TokenPair
+//	@Failure		400
+//	@Failure		401
+//	@Failure		500
+//	@Header			200	{string}	Authorization	"Bearer token"
+//	@Router			/api/user/token/refresh [post]
+func (c *AuthController) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleRefresh"
+
+	logger := c.logger.With("op", op)
+
+	var dto RefreshRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := c.authService.Refresh(r.Context(), dto.RefreshToken)
+
+	if errors.Is(err, ErrRefreshTokenReused) {
+		logger.Warnw("refresh token reused, its family is revoked")
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
+
+	if errors.Is(err, ErrInvalidRefreshToken) {
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	writeTokens(w, tokens, logger)
+}
+
+// handleLogout godoc
+//
+//	@Summary		logout
+//	@Description	revoke the access token of the request. The refresh token of the session is revoked as well when passed in the body, otherwise it can still be exchanged
+//	@Tags			auth
+//
+//	@Param			Authorization	header	string				true	"Bearer token"
+//	@Param			body			body	LogoutRequestDTO	false	"Logout body"
+//
+//	@Accept			json
+//	@Success		204
+//	@Failure		400
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/logout [post]
+func (c *AuthController) handleLogout(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleLogout"
+
+	logger := c.logger.With("op", op)
+
+	var dto LogoutRequestDTO
+
+	if r.ContentLength != 0 {
+		err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	claims := ExtractClaimsFromContext(r.Context())
+
+	err := c.authService.Logout(r.Context(), claims, dto.RefreshToken)
+
+	if err != nil {
+		logger.Errorw("error while logout", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleLogoutAll godoc
+//
+//	@Summary		logout all sessions
+//	@Description	revoke every access and refresh token issued to the user, including the token of the request
+//	@Tags			auth
+//
+//	@Param			Authorization	header	string	true	"Bearer token"
+//
+//	@Success		204
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/logout/all [post]
+func (c *AuthController) handleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleLogoutAll"
+
+	logger := c.logger.With("op", op)
+
+	claims := ExtractClaimsFromContext(r.Context())
+
+	err := c.authService.LogoutAll(r.Context(), claims)
+
+	if err != nil {
+		logger.Errorw("error while logout all sessions", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infow("all sessions logged out", "userID", claims.TokenUser.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleChangePassword godoc
+//
+//	@Summary		change password
+//	@Description	set a new password once the current one is confirmed. Every session of the user is logged out, the returned tokens start a new one
+//	@Tags			auth
+//
+//	@Param			Authorization	header	string						true	"Bearer token"
+//	@Param			body			body	ChangePasswordRequestDTO	true	"Change password body"
+//
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	dtos.TokenPair
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Header			200	{string}	Authorization	"Bearer token"
+//	@Router			/api/user/password [post]
+func (c *AuthController) handleChangePassword(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleChangePassword"
+
+	logger := c.logger.With("op", op)
+
+	var dto ChangePasswordRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := c.authService.ChangePassword(r.Context(), ExtractClaimsFromContext(r.Context()), dto.CurrentPassword, dto.NewPassword)
+
+	if err != nil {
+		mapPasswordConfirmErrorToHTTPError(w, err, logger)
+		return
+	}
+
+	writeTokens(w, tokens, logger)
+}
+
+// handleRequestPasswordReset godoc
+//
+//	@Summary		request password reset
+//	@Description	send a single-use password reset token to the user. The request is accepted for unknown logins as well
+//	@Tags			auth
+//
+//	@Param			body	body	PasswordResetRequestDTO	true	"Password reset request body"
+//
+//	@Accept			json
+//	@Success		202
+//	@Failure		400
+//	@Failure		404	"password reset is disabled"
+//	@Failure		500
+//	@Router			/api/user/password/reset/request [post]
+func (c *AuthController) handleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleRequestPasswordReset"
+
+	logger := c.logger.With("op", op)
+
+	var dto PasswordResetRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.authService.RequestPasswordReset(r.Context(), dto.Username)
+
+	if errors.Is(err, ErrPasswordResetDisabled) {
+		http.Error(w, "", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while request password reset", "err", err.Error(), "username", dto.Username)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleResetPassword godoc
+//
+//	@Summary		reset password
+//	@Description	set a new password with the reset token. Every session of the user is logged out
+//	@Tags			auth
+//
+//	@Param			body	body	ResetPasswordRequestDTO	true	"Reset password body"
+//
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	"body or token is invalid"
+//	@Failure		500
+//	@Router			/api/user/password/reset [post]
+func (c *AuthController) handleResetPassword(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleResetPassword"
+
+	logger := c.logger.With("op", op)
+
+	var dto ResetPasswordRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.authService.ResetPassword(r.Context(), dto.Token, dto.NewPassword)
+
+	if errors.Is(err, ErrInvalidResetToken) {
+		http.Error(w, ErrInvalidResetToken.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while reset password", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDeleteAccount godoc
+//
+//	@Summary		delete account
+//	@Description	delete the user once the password is confirmed, along with orders, balance and everything else the user owns. Transfers stay in the history of the other users
+//	@Tags			auth
+//
+//	@Param			Authorization	header	string					true	"Bearer token"
+//	@Param			body			body	DeleteAccountRequestDTO	true	"Delete account body"
+//
+//	@Accept			json
+//	@Success		204
+//	@Failure		400
+//	@Failure		401
+//	@Failure		403
+//	@Failure		500
+//	@Router			/api/user/account [delete]
+func (c *AuthController) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	op := "authController.handleDeleteAccount"
+
+	logger := c.logger.With("op", op)
+
+	var dto DeleteAccountRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims := ExtractClaimsFromContext(r.Context())
+
+	err = c.authService.DeleteAccount(r.Context(), claims, dto.Password)
+
+	if err != nil {
+		mapPasswordConfirmErrorToHTTPError(w, err, logger)
+		return
+	}
+
+	logger.Infow("account deleted", "userID", claims.TokenUser.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTokens answers with the tokens, the access token is also set to Authorization header as before refresh tokens.
+func writeTokens(w http.ResponseWriter, tokens dtos.TokenPair, logger logger.Logger) {
+	result, err := json.Marshal(tokens)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Authorization", fmt.Sprintf("Bearer %s", tokens.AccessToken))
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(result)
+}
+
+func NewController(logger logger.Logger, tokenService TokenService, authService AuthService) *AuthController {
 	return &AuthController{
 		logger,
+		tokenService,
 		authService,
 	}
 }
@@ -133,3 +461,19 @@ func mapLoginErrorToHTTPError(w http.ResponseWriter, err error, logger logger.Lo
 	logger.Errorw("", "err", err.Error(), "username", dto.Username)
 	http.Error(w, "", http.StatusInternalServerError)
 }
+
+// mapPasswordConfirmErrorToHTTPError maps errors of the operations confirmed with the password of the user.
+func mapPasswordConfirmErrorToHTTPError(w http.ResponseWriter, err error, logger logger.Logger) {
+	if errors.Is(err, ErrIncorrectPassword) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if errors.Is(err, ErrUserNotFound) {
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
+
+	logger.Errorw("", "err", err.Error())
+	http.Error(w, "", http.StatusInternalServerError)
+}
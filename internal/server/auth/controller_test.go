@@ -10,6 +10,7 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -24,7 +25,7 @@ func TestAuthController_handleRegister(t *testing.T) {
 	authServiceMock := auth.NewMockAuthService(ctrl)
 	logger := logger.New("info")
 
-	c := auth.NewController(logger, authServiceMock)
+	c := auth.NewController(logger, auth.NewMockTokenService(ctrl), authServiceMock)
 
 	r.Mount("/", c.Route())
 
@@ -67,7 +68,7 @@ func TestAuthController_handleRegister(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusOK,
 			setupMock: func() {
-				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return("test_token", nil)
+				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return(dtos.TokenPair{AccessToken: "test_token", RefreshToken: "test_refresh_token", ExpiresIn: 600}, nil)
 			},
 		},
 		{
@@ -78,7 +79,7 @@ func TestAuthController_handleRegister(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusConflict,
 			setupMock: func() {
-				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return("", auth.ErrUserAlreadyExist)
+				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return(dtos.TokenPair{}, auth.ErrUserAlreadyExist)
 			},
 		},
 		{
@@ -89,7 +90,7 @@ func TestAuthController_handleRegister(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func() {
-				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return("", errors.New("unexpected error"))
+				authServiceMock.EXPECT().Register(gomock.Any(), "test", "test").Return(dtos.TokenPair{}, errors.New("unexpected error"))
 			},
 		},
 	}
@@ -112,6 +113,7 @@ func TestAuthController_handleRegister(t *testing.T) {
 			require.Equal(t, tc.expectedStatus, resp.StatusCode())
 			if tc.expectedStatus == http.StatusOK {
 				assert.Equal(t, "Bearer test_token", resp.Header().Get("Authorization"))
+				assert.JSONEq(t, `{"access_token":"test_token","refresh_token":"test_refresh_token","expires_in":600}`, string(resp.Body()))
 			}
 		})
 	}
@@ -126,7 +128,7 @@ func TestAuthController_handleLogin(t *testing.T) {
 	authServiceMock := auth.NewMockAuthService(ctrl)
 	logger := logger.New("info")
 
-	c := auth.NewController(logger, authServiceMock)
+	c := auth.NewController(logger, auth.NewMockTokenService(ctrl), authServiceMock)
 
 	r.Mount("/", c.Route())
 
@@ -169,7 +171,7 @@ func TestAuthController_handleLogin(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusOK,
 			setupMock: func() {
-				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return("test_token", nil)
+				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return(dtos.TokenPair{AccessToken: "test_token", RefreshToken: "test_refresh_token", ExpiresIn: 600}, nil)
 			},
 		},
 		{
@@ -180,7 +182,7 @@ func TestAuthController_handleLogin(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusUnauthorized,
 			setupMock: func() {
-				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return("", auth.ErrUserNotFound)
+				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return(dtos.TokenPair{}, auth.ErrUserNotFound)
 			},
 		},
 		{
@@ -191,7 +193,7 @@ func TestAuthController_handleLogin(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusUnauthorized,
 			setupMock: func() {
-				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return("", auth.ErrIncorrectPassword)
+				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return(dtos.TokenPair{}, auth.ErrIncorrectPassword)
 			},
 		},
 		{
@@ -202,7 +204,7 @@ func TestAuthController_handleLogin(t *testing.T) {
 			contentType:    "application/json",
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func() {
-				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return("", errors.New("unexpected error"))
+				authServiceMock.EXPECT().Login(gomock.Any(), "test", "test").Return(dtos.TokenPair{}, errors.New("unexpected error"))
 			},
 		},
 	}
@@ -225,7 +227,329 @@ func TestAuthController_handleLogin(t *testing.T) {
 			require.Equal(t, tc.expectedStatus, resp.StatusCode())
 			if tc.expectedStatus == http.StatusOK {
 				assert.Equal(t, "Bearer test_token", resp.Header().Get("Authorization"))
+				assert.JSONEq(t, `{"access_token":"test_token","refresh_token":"test_refresh_token","expires_in":600}`, string(resp.Body()))
 			}
 		})
 	}
 }
+
+func TestAuthController_handleRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	authServiceMock := auth.NewMockAuthService(ctrl)
+	logger := logger.New("info")
+
+	c := auth.NewController(logger, auth.NewMockTokenService(ctrl), authServiceMock)
+
+	r.Mount("/", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:           "should return 400 if refresh token missing",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func() {},
+		},
+		{
+			name:           "should return new tokens",
+			body:           `{"refresh_token": "refresh"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func() {
+				authServiceMock.EXPECT().Refresh(gomock.Any(), "refresh").Return(dtos.TokenPair{AccessToken: "test_token", RefreshToken: "test_refresh_token", ExpiresIn: 600}, nil)
+			},
+		},
+		{
+			name:           "should return 401 if refresh token invalid",
+			body:           `{"refresh_token": "refresh"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func() {
+				authServiceMock.EXPECT().Refresh(gomock.Any(), "refresh").Return(dtos.TokenPair{}, auth.ErrInvalidRefreshToken)
+			},
+		},
+		{
+			name:           "should return 401 if refresh token reused",
+			body:           `{"refresh_token": "refresh"}`,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func() {
+				authServiceMock.EXPECT().Refresh(gomock.Any(), "refresh").Return(dtos.TokenPair{}, auth.ErrRefreshTokenReused)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			resp, err := client.R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(tc.body).
+				Post("/token/refresh")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "Bearer test_token", resp.Header().Get("Authorization"))
+				assert.JSONEq(t, `{"access_token":"test_token","refresh_token":"test_refresh_token","expires_in":600}`, string(resp.Body()))
+			}
+		})
+	}
+}
+
+func TestAuthController_handleLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	authServiceMock := auth.NewMockAuthService(ctrl)
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	logger := logger.New("info")
+
+	c := auth.NewController(logger, tokenServiceMock, authServiceMock)
+
+	r.Mount("/", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	claims := &auth.Claims{TokenUser: auth.TokenUser{ID: 1}}
+
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:           "should return 401 if token invalid",
+			url:            "/logout",
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{}, auth.ErrTokenRevoked)
+				authServiceMock.EXPECT().Logout(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should logout without refresh token",
+			url:            "/logout",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().Logout(gomock.Any(), claims, "").Return(nil)
+			},
+		},
+		{
+			name:           "should logout with refresh token",
+			url:            "/logout",
+			body:           `{"refresh_token": "refresh"}`,
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().Logout(gomock.Any(), claims, "refresh").Return(nil)
+			},
+		},
+		{
+			name:           "should return 400 if body invalid",
+			url:            "/logout",
+			body:           `refresh`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().Logout(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should logout all sessions",
+			url:            "/logout/all",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().LogoutAll(gomock.Any(), claims).Return(nil)
+			},
+		},
+		{
+			name:           "should return 500 if logout all sessions failed",
+			url:            "/logout/all",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().LogoutAll(gomock.Any(), claims).Return(errors.New("db error"))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			req := client.R().SetHeader("Authorization", "Bearer test")
+
+			if tc.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tc.body)
+			}
+
+			resp, err := req.Post(tc.url)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+		})
+	}
+}
+
+func TestAuthController_handlePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	authServiceMock := auth.NewMockAuthService(ctrl)
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	logger := logger.New("info")
+
+	c := auth.NewController(logger, tokenServiceMock, authServiceMock)
+
+	r.Mount("/", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	claims := &auth.Claims{TokenUser: auth.TokenUser{ID: 1}}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:           "should change password",
+			method:         http.MethodPost,
+			url:            "/password",
+			body:           `{"current_password": "current", "new_password": "new_password"}`,
+			expectedStatus: http.StatusOK,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().ChangePassword(gomock.Any(), claims, "current", "new_password").Return(dtos.TokenPair{AccessToken: "token", RefreshToken: "refresh"}, nil)
+			},
+		},
+		{
+			name:           "should return 403 if current password incorrect",
+			method:         http.MethodPost,
+			url:            "/password",
+			body:           `{"current_password": "incorrect", "new_password": "new_password"}`,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().ChangePassword(gomock.Any(), claims, "incorrect", "new_password").Return(dtos.TokenPair{}, auth.ErrIncorrectPassword)
+			},
+		},
+		{
+			name:           "should return 400 if new password too short",
+			method:         http.MethodPost,
+			url:            "/password",
+			body:           `{"current_password": "current", "new_password": "new"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().ChangePassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should accept password reset request",
+			method:         http.MethodPost,
+			url:            "/password/reset/request",
+			body:           `{"login": "test"}`,
+			expectedStatus: http.StatusAccepted,
+			setupMock: func() {
+				authServiceMock.EXPECT().RequestPasswordReset(gomock.Any(), "test").Return(nil)
+			},
+		},
+		{
+			name:           "should return 404 if password reset disabled",
+			method:         http.MethodPost,
+			url:            "/password/reset/request",
+			body:           `{"login": "test"}`,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func() {
+				authServiceMock.EXPECT().RequestPasswordReset(gomock.Any(), "test").Return(auth.ErrPasswordResetDisabled)
+			},
+		},
+		{
+			name:           "should reset password",
+			method:         http.MethodPost,
+			url:            "/password/reset",
+			body:           `{"token": "reset", "new_password": "new_password"}`,
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				authServiceMock.EXPECT().ResetPassword(gomock.Any(), "reset", "new_password").Return(nil)
+			},
+		},
+		{
+			name:           "should return 400 if reset token invalid",
+			method:         http.MethodPost,
+			url:            "/password/reset",
+			body:           `{"token": "reset", "new_password": "new_password"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				authServiceMock.EXPECT().ResetPassword(gomock.Any(), "reset", "new_password").Return(auth.ErrInvalidResetToken)
+			},
+		},
+		{
+			name:           "should delete account",
+			method:         http.MethodDelete,
+			url:            "/account",
+			body:           `{"password": "password"}`,
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().DeleteAccount(gomock.Any(), claims, "password").Return(nil)
+			},
+		},
+		{
+			name:           "should return 403 if password incorrect on delete account",
+			method:         http.MethodDelete,
+			url:            "/account",
+			body:           `{"password": "incorrect"}`,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(claims, nil)
+				authServiceMock.EXPECT().DeleteAccount(gomock.Any(), claims, "incorrect").Return(auth.ErrIncorrectPassword)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			resp, err := client.R().
+				SetHeader("Authorization", "Bearer test").
+				SetHeader("Content-Type", "application/json").
+				SetBody(tc.body).
+				Execute(tc.method, tc.url)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+		})
+	}
+}
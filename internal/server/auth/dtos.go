@@ -9,3 +9,29 @@ type LoginRequestDTO struct {
 	Username string `json:"login" validate:"required"`
 	Password string `json:"password" validate:"required,min=4,max=32"`
 }
+
+type RefreshRequestDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequestDTO struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ChangePasswordRequestDTO struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=4,max=32"`
+}
+
+type PasswordResetRequestDTO struct {
+	Username string `json:"login" validate:"required"`
+}
+
+type ResetPasswordRequestDTO struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=4,max=32"`
+}
+
+type DeleteAccountRequestDTO struct {
+	Password string `json:"password" validate:"required"`
+}
@@ -35,7 +35,9 @@ func (s *AuthServer) Login(ctx context.Context, in *proto.LoginRequest) (*proto.
 		return nil, mapLoginServiceError(err, logger)
 	}
 
-	response.Token = result
+	response.Token = result.AccessToken
+	response.RefreshToken = result.RefreshToken
+	response.ExpiresIn = result.ExpiresIn
 
 	return &response, nil
 }
@@ -57,11 +59,157 @@ func (s *AuthServer) Register(ctx context.Context, in *proto.RegisterRequest) (*
 		return nil, mapRegisterServiceError(err, logger)
 	}
 
-	response.Token = result
+	response.Token = result.AccessToken
+	response.RefreshToken = result.RefreshToken
+	response.ExpiresIn = result.ExpiresIn
 
 	return &response, nil
 }
 
+func (s *AuthServer) Refresh(ctx context.Context, in *proto.RefreshRequest) (*proto.RefreshResponse, error) {
+	var response proto.RefreshResponse
+
+	logger := s.logger.With("op", proto.AuthService_Refresh_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.authService.Refresh(ctx, in.RefreshToken)
+
+	if err != nil {
+		return nil, mapRefreshServiceError(err, logger)
+	}
+
+	response.Token = result.AccessToken
+	response.RefreshToken = result.RefreshToken
+	response.ExpiresIn = result.ExpiresIn
+
+	return &response, nil
+}
+
+func (s *AuthServer) Logout(ctx context.Context, in *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+	logger := s.logger.With("op", proto.AuthService_Logout_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.authService.Logout(ctx, ExtractClaimsFromContext(ctx), in.GetRefreshToken())
+
+	if err != nil {
+		logger.Errorw("failed to logout", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.LogoutResponse{}, nil
+}
+
+func (s *AuthServer) LogoutAll(ctx context.Context, in *proto.LogoutAllRequest) (*proto.LogoutAllResponse, error) {
+	logger := s.logger.With("op", proto.AuthService_LogoutAll_FullMethodName)
+
+	err := s.authService.LogoutAll(ctx, ExtractClaimsFromContext(ctx))
+
+	if err != nil {
+		logger.Errorw("failed to logout all sessions", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.LogoutAllResponse{}, nil
+}
+
+func (s *AuthServer) ChangePassword(ctx context.Context, in *proto.ChangePasswordRequest) (*proto.ChangePasswordResponse, error) {
+	var response proto.ChangePasswordResponse
+
+	logger := s.logger.With("op", proto.AuthService_ChangePassword_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.authService.ChangePassword(ctx, ExtractClaimsFromContext(ctx), in.CurrentPassword, in.NewPassword)
+
+	if err != nil {
+		return nil, mapPasswordConfirmServiceError(err, logger)
+	}
+
+	response.Token = result.AccessToken
+	response.RefreshToken = result.RefreshToken
+	response.ExpiresIn = result.ExpiresIn
+
+	return &response, nil
+}
+
+func (s *AuthServer) RequestPasswordReset(ctx context.Context, in *proto.RequestPasswordResetRequest) (*proto.RequestPasswordResetResponse, error) {
+	logger := s.logger.With("op", proto.AuthService_RequestPasswordReset_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.authService.RequestPasswordReset(ctx, in.Nickname)
+
+	if errors.Is(err, ErrPasswordResetDisabled) {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	}
+
+	if err != nil {
+		logger.Errorw("failed to request password reset", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.RequestPasswordResetResponse{}, nil
+}
+
+func (s *AuthServer) ResetPassword(ctx context.Context, in *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error) {
+	logger := s.logger.With("op", proto.AuthService_ResetPassword_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.authService.ResetPassword(ctx, in.Token, in.NewPassword)
+
+	if errors.Is(err, ErrInvalidResetToken) {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidResetToken.Error())
+	}
+
+	if err != nil {
+		logger.Errorw("failed to reset password", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.ResetPasswordResponse{}, nil
+}
+
+func (s *AuthServer) DeleteAccount(ctx context.Context, in *proto.DeleteAccountRequest) (*proto.DeleteAccountResponse, error) {
+	logger := s.logger.With("op", proto.AuthService_DeleteAccount_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.authService.DeleteAccount(ctx, ExtractClaimsFromContext(ctx), in.Password)
+
+	if err != nil {
+		return nil, mapPasswordConfirmServiceError(err, logger)
+	}
+
+	return &proto.DeleteAccountResponse{}, nil
+}
+
 func mapLoginServiceError(err error, logger logger.Logger) error {
 	code := codes.Internal
 	msg := "Internal server error"
@@ -86,6 +234,21 @@ func mapRegisterServiceError(err error, logger logger.Logger) error {
 	return status.Error(code, msg)
 }
 
+func mapRefreshServiceError(err error, logger logger.Logger) error {
+	if errors.Is(err, ErrRefreshTokenReused) {
+		logger.Warnw("refresh token reused, its family is revoked")
+		return status.Error(codes.Unauthenticated, ErrInvalidRefreshToken.Error())
+	}
+
+	if errors.Is(err, ErrInvalidRefreshToken) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	logger.Errorw("failed to refresh token", "err", err)
+
+	return status.Error(codes.Internal, "Internal server error")
+}
+
 func NewAuthServer(logger logger.Logger, authService AuthService) *AuthServer {
 	v, err := protovalidate.New()
 	if err != nil {
@@ -97,3 +260,17 @@ func NewAuthServer(logger logger.Logger, authService AuthService) *AuthServer {
 		validator:   v,
 	}
 }
+
+func mapPasswordConfirmServiceError(err error, logger logger.Logger) error {
+	if errors.Is(err, ErrIncorrectPassword) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if errors.Is(err, ErrUserNotFound) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	logger.Errorw("failed to confirm password", "err", err)
+
+	return status.Error(codes.Internal, "Internal server error")
+}
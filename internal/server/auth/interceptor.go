@@ -22,26 +22,66 @@ func UnaryAuthInterceptor(tokenService TokenService, protectedMethods []string)
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "Metadata not provided")
+		ctx, err := authenticate(ctx, tokenService)
+		if err != nil {
+			return nil, err
 		}
 
-		values := md.Get("token")
-		if len(values) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "No token provided")
-		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor authenticates streaming calls of the protected methods the same way as UnaryAuthInterceptor.
+func StreamAuthInterceptor(tokenService TokenService, protectedMethods []string) grpc.StreamServerInterceptor {
+	protectedMethodsMap := make(map[string]bool)
+	for _, method := range protectedMethods {
+		protectedMethodsMap[method] = true
+	}
 
-		token := values[0]
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 
-		claims, err := tokenService.Validate(token)
+		_, ok := protectedMethodsMap[info.FullMethod]
+		if !ok {
+			return handler(srv, ss)
+		}
 
+		ctx, err := authenticate(ss.Context(), tokenService)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "Invalid token")
+			return err
 		}
 
-		ctx = context.WithValue(ctx, ClaimsContextKey, claims)
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		return handler(ctx, req)
+// authenticate validates the token from the metadata and returns ctx carrying its claims.
+func authenticate(ctx context.Context, tokenService TokenService) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Metadata not provided")
+	}
+
+	values := md.Get("token")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "No token provided")
+	}
+
+	token := values[0]
+
+	claims, err := tokenService.Validate(token)
+
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid token")
 	}
+
+	return context.WithValue(ctx, ClaimsContextKey, claims), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+// JWKSController publishes the public keys tokens are verified with, so other services verify tokens
+// without the signing key. Tokens signed with HS256 can not be verified publicly, the set is empty then.
+type JWKSController struct {
+	logger logger.Logger
+	keys   *KeySet
+}
+
+func (c *JWKSController) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get("/jwks.json", c.handleJWKS)
+
+	return r
+}
+
+// handleJWKS godoc
+//
+//	@Summary		token verification keys
+//	@Description	public keys access tokens are verified with, the key is selected by kid header of the token. Keys retired by a rotation are listed while tokens signed with them can be valid
+//	@Tags			auth
+//
+//	@Produce		json
+//	@Success		200	{object}	dtos.JSONWebKeySet
+//	@Failure		500
+//	@Router			/.well-known/jwks.json [get]
+func (c *JWKSController) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	op := "jwksController.handleJWKS"
+
+	logger := c.logger.With("op", op)
+
+	set := dtos.JSONWebKeySet{Keys: []dtos.JSONWebKey{}}
+
+	if c.keys != nil {
+		set = c.keys.JWKS()
+	}
+
+	result, err := json.Marshal(set)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Cache-Control", "public, max-age=300")
+	w.Write(result)
+}
+
+func NewJWKSController(logger logger.Logger, keys *KeySet) *JWKSController {
+	return &JWKSController{
+		logger: logger,
+		keys:   keys,
+	}
+}
@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported key, RSA and Ed25519 keys are supported")
+
+// Key is a key tokens are signed or verified with. ID is the RFC 7638 thumbprint of the public key,
+// it is sent as kid header of the tokens signed with the key.
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PublicKey  crypto.PublicKey
+	PrivateKey crypto.Signer
+}
+
+// KeySet holds the key signing new tokens and retired keys which still verify tokens signed before a rotation.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	ordered []*Key
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (s *KeySet) SigningKey() *Key {
+	return s.signing
+}
+
+// Key returns the key with the kid.
+func (s *KeySet) Key(kid string) (*Key, bool) {
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
+
+// JWKS returns the public keys of the set, the signing key first.
+func (s *KeySet) JWKS() dtos.JSONWebKeySet {
+	set := dtos.JSONWebKeySet{Keys: make([]dtos.JSONWebKey, 0, len(s.ordered))}
+
+	for _, key := range s.ordered {
+		set.Keys = append(set.Keys, publicJWK(key))
+	}
+
+	return set
+}
+
+// LoadKeySet reads the private signing key and the verification keys from PEM files. A verification key file
+// may hold a public or a private key, keys equal to the signing key are skipped.
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	signing, err := loadKey(signingKeyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if signing.PrivateKey == nil {
+		return nil, fmt.Errorf("%s: signing key must be a private key", signingKeyFile)
+	}
+
+	return NewKeySet(signing, verificationKeyFiles)
+}
+
+// NewKeySet returns a set signing with the key, verificationKeyFiles are loaded as in LoadKeySet.
+func NewKeySet(signing *Key, verificationKeyFiles []string) (*KeySet, error) {
+	set := &KeySet{
+		signing: signing,
+		keys:    map[string]*Key{signing.ID: signing},
+		ordered: []*Key{signing},
+	}
+
+	for _, file := range verificationKeyFiles {
+		key, err := loadKey(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := set.keys[key.ID]; ok {
+			continue
+		}
+
+		set.keys[key.ID] = key
+		set.ordered = append(set.ordered, key)
+	}
+
+	return set, nil
+}
+
+// NewKey returns the key of a private or public RSA or Ed25519 key.
+func NewKey(k any) (*Key, error) {
+	key := &Key{}
+
+	switch k := k.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = &k.PublicKey
+		key.PrivateKey = k
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = k
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PublicKey = k.Public()
+		key.PrivateKey = k
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PublicKey = k
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	id, err := thumbprint(publicJWK(key))
+
+	if err != nil {
+		return nil, err
+	}
+
+	key.ID = id
+
+	return key, nil
+}
+
+// SplitKeyFiles splits the comma separated list of key files from config.
+func SplitKeyFiles(files string) []string {
+	var result []string
+
+	for _, file := range strings.Split(files, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			result = append(result, file)
+		}
+	}
+
+	return result
+}
+
+func loadKey(file string) (*Key, error) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", file)
+	}
+
+	var parsed any
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		err = fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	key, err := NewKey(parsed)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return key, nil
+}
+
+func publicJWK(key *Key) dtos.JSONWebKey {
+	jwk := dtos.JSONWebKey{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Method.Alg(),
+	}
+
+	switch k := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	}
+
+	return jwk
+}
+
+// thumbprint returns the RFC 7638 thumbprint of the key: SHA-256 of its required members in lexicographic order.
+func thumbprint(jwk dtos.JSONWebKey) (string, error) {
+	var members any
+
+	switch jwk.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		return "", ErrUnsupportedKey
+	}
+
+	data, err := json.Marshal(members)
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
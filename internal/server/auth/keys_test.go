@@ -0,0 +1,179 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, name string, blockType string, der []byte) string {
+	file := filepath.Join(t.TempDir(), name)
+
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+
+	return file
+}
+
+func TestJWTTokenService_keySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	rsaFile := writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	rsaPublicFile := writePEM(t, "rsa.pub.pem", "PUBLIC KEY", rsaPublicDER)
+	edFile := writePEM(t, "ed25519.pem", "PRIVATE KEY", edDER)
+
+	oldKeys, err := auth.LoadKeySet(rsaFile, nil)
+	require.NoError(t, err)
+
+	rotatedKeys, err := auth.LoadKeySet(edFile, []string{rsaPublicFile})
+	require.NoError(t, err)
+
+	require.Equal(t, jwt.SigningMethodRS256, oldKeys.SigningKey().Method)
+	require.Equal(t, jwt.SigningMethodEdDSA, rotatedKeys.SigningKey().Method)
+
+	oldService := auth.NewKeySetJWTTokenService(oldKeys, time.Hour)
+	rotatedService := auth.NewKeySetJWTTokenService(rotatedKeys, time.Hour)
+
+	t.Run("should verify token signed before rotation", func(t *testing.T) {
+		token, err := oldService.Build(1)
+		require.NoError(t, err)
+
+		claims, err := rotatedService.Validate(token)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, claims.TokenUser.ID)
+	})
+
+	t.Run("should sign with kid of signing key", func(t *testing.T) {
+		token, err := rotatedService.Build(1)
+		require.NoError(t, err)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &auth.Claims{})
+
+		require.NoError(t, err)
+		require.Equal(t, "EdDSA", parsed.Header["alg"])
+		require.Equal(t, rotatedKeys.SigningKey().ID, parsed.Header["kid"])
+
+		_, err = rotatedService.Validate(token)
+		require.NoError(t, err)
+	})
+
+	t.Run("should not verify token signed with unknown key", func(t *testing.T) {
+		token, err := rotatedService.Build(1)
+		require.NoError(t, err)
+
+		_, err = oldService.Validate(token)
+
+		require.Error(t, err)
+	})
+
+	t.Run("should not verify token signed with other algorithm", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+			TokenUser:        auth.TokenUser{ID: 1},
+		})
+		token.Header["kid"] = oldKeys.SigningKey().ID
+
+		signed, err := token.SignedString(rsaPublicDER)
+		require.NoError(t, err)
+
+		_, err = rotatedService.Validate(signed)
+
+		require.Error(t, err)
+	})
+
+	t.Run("should not sign with public key", func(t *testing.T) {
+		_, err := auth.LoadKeySet(rsaPublicFile, nil)
+
+		require.Error(t, err)
+	})
+}
+
+func TestJWKSController(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	edPublicDER, err := x509.MarshalPKIXPublicKey(edPublic)
+	require.NoError(t, err)
+
+	signing, err := auth.NewKey(rsaKey)
+	require.NoError(t, err)
+
+	keys, err := auth.NewKeySet(signing, []string{writePEM(t, "ed25519.pub.pem", "PUBLIC KEY", edPublicDER)})
+	require.NoError(t, err)
+
+	retired, err := auth.NewKey(edKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		keys     *auth.KeySet
+		expected []dtos.JSONWebKey
+	}{
+		{
+			name: "should return empty set without keys",
+		},
+		{
+			name: "should return signing and retired keys",
+			keys: keys,
+			expected: []dtos.JSONWebKey{
+				{KeyType: "RSA", KeyID: signing.ID, Use: "sig", Algorithm: "RS256"},
+				{KeyType: "OKP", KeyID: retired.ID, Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.Mount("/.well-known", auth.NewJWKSController(logger.New("info"), tc.keys).Route())
+
+			ts := httptest.NewServer(r)
+			defer ts.Close()
+
+			var set dtos.JSONWebKeySet
+
+			resp, err := resty.New().SetBaseURL(ts.URL).R().SetResult(&set).Get("/.well-known/jwks.json")
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode())
+			require.Len(t, set.Keys, len(tc.expected))
+
+			for i, expected := range tc.expected {
+				require.Equal(t, expected.KeyType, set.Keys[i].KeyType)
+				require.Equal(t, expected.KeyID, set.Keys[i].KeyID)
+				require.Equal(t, expected.Use, set.Keys[i].Use)
+				require.Equal(t, expected.Algorithm, set.Keys[i].Algorithm)
+				require.Equal(t, expected.Curve, set.Keys[i].Curve)
+			}
+		})
+	}
+}
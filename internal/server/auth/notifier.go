@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+const (
+	NotifierLog  = "log"
+	NotifierFile = "file"
+)
+
+var ErrUnknownNotifier = errors.New("unknown password reset notifier")
+
+// PasswordResetNotifier delivers password reset tokens to users. Anyone reading the delivered token can
+// reset the password, the log and file notifiers are meant for local runs only.
+type PasswordResetNotifier interface {
+	NotifyPasswordReset(ctx context.Context, reset dtos.PasswordReset) error
+}
+
+// LogNotifier writes password resets to the log.
+type LogNotifier struct {
+	logger logger.Logger
+}
+
+func (n *LogNotifier) NotifyPasswordReset(ctx context.Context, reset dtos.PasswordReset) error {
+	n.logger.Infow("password reset requested", "login", reset.Login, "token", reset.Token, "expires_at", reset.ExpiresAt)
+
+	return nil
+}
+
+// WriterNotifier writes every password reset as a line of JSON, e.g. to a file.
+type WriterNotifier struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (n *WriterNotifier) NotifyPasswordReset(ctx context.Context, reset dtos.PasswordReset) error {
+	line, err := json.Marshal(reset)
+
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err = n.writer.Write(append(line, '\n'))
+
+	return err
+}
+
+// NewNotifier returns the notifier of the kind, file is the path password resets are appended to by the file notifier.
+func NewNotifier(kind string, file string, logger logger.Logger) (PasswordResetNotifier, error) {
+	switch kind {
+	case NotifierLog:
+		return NewLogNotifier(logger), nil
+	case NotifierFile:
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return NewWriterNotifier(f), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownNotifier, kind)
+}
+
+func NewLogNotifier(logger logger.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func NewWriterNotifier(writer io.Writer) *WriterNotifier {
+	return &WriterNotifier{writer: writer}
+}
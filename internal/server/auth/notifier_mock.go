@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/auth/notifier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/auth/notifier.go -destination=./internal/server/auth/notifier_mock.go -package=auth
+//
+
+// Package auth is a generated GoMock package.
+package auth
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dtos "github.com/sodiqit/gophermart/internal/server/dtos"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordResetNotifier is a mock of PasswordResetNotifier interface.
+type MockPasswordResetNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetNotifierMockRecorder
+}
+
+// MockPasswordResetNotifierMockRecorder is the mock recorder for MockPasswordResetNotifier.
+type MockPasswordResetNotifierMockRecorder struct {
+	mock *MockPasswordResetNotifier
+}
+
+// NewMockPasswordResetNotifier creates a new mock instance.
+func NewMockPasswordResetNotifier(ctrl *gomock.Controller) *MockPasswordResetNotifier {
+	mock := &MockPasswordResetNotifier{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetNotifier) EXPECT() *MockPasswordResetNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPasswordReset mocks base method.
+func (m *MockPasswordResetNotifier) NotifyPasswordReset(ctx context.Context, reset dtos.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPasswordReset", ctx, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPasswordReset indicates an expected call of NotifyPasswordReset.
+func (mr *MockPasswordResetNotifierMockRecorder) NotifyPasswordReset(ctx, reset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPasswordReset", reflect.TypeOf((*MockPasswordResetNotifier)(nil).NotifyPasswordReset), ctx, reset)
+}
@@ -0,0 +1,39 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterNotifier_notifyPasswordReset(t *testing.T) {
+	var buf bytes.Buffer
+
+	n := auth.NewWriterNotifier(&buf)
+
+	reset := dtos.PasswordReset{UserID: 1, Login: "test", Token: "reset", ExpiresAt: time.Now().UTC().Truncate(time.Second)}
+
+	require.NoError(t, n.NotifyPasswordReset(context.Background(), reset))
+	require.NoError(t, n.NotifyPasswordReset(context.Background(), reset))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var written dtos.PasswordReset
+
+	require.NoError(t, json.Unmarshal(lines[0], &written))
+	require.Equal(t, reset, written)
+}
+
+func TestNewNotifier(t *testing.T) {
+	_, err := auth.NewNotifier("smtp", "", logger.New("info"))
+
+	require.ErrorIs(t, err, auth.ErrUnknownNotifier)
+}
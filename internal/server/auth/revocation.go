@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+var ErrTokenRevoked = errors.New("token revoked")
+
+const (
+	revocationPurgeInterval = 10 * time.Minute
+	revocationCheckTimeout  = 5 * time.Second
+)
+
+type RevocationStore interface {
+	// IsRevoked reports whether the token was revoked by itself or by logging out all sessions of its user.
+	IsRevoked(ctx context.Context, claims *Claims) (bool, error)
+	// Revoke revokes the token until it expires.
+	Revoke(ctx context.Context, claims *Claims) error
+	// RevokeAll revokes all tokens issued to the user so far.
+	RevokeAll(ctx context.Context, userID int) error
+	// RevokeDeleted revokes all tokens of the deleted user on this instance at once. Other instances
+	// revoke them once they look the user up again.
+	RevokeDeleted(userID int)
+}
+
+type cachedToken struct {
+	revoked bool
+	until   time.Time
+}
+
+type cachedUser struct {
+	revokedBefore *time.Time
+	deleted       bool
+	until         time.Time
+}
+
+// CachedRevocationStore keeps revocations in the database and caches lookups, so authenticated requests do not
+// query the database each time. A revoked token stays cached until it expires, everything else for cacheTTL:
+// revocations made by this instance take effect at once, those made by other replicas within cacheTTL.
+type CachedRevocationStore struct {
+	revocationRepo repository.TokenRevocationRepository
+	logger         logger.Logger
+	cacheTTL       time.Duration
+	now            func() time.Time
+
+	mu        sync.Mutex
+	tokens    map[string]cachedToken
+	users     map[int]cachedUser
+	nextPrune time.Time
+}
+
+func (s *CachedRevocationStore) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	user, err := s.user(ctx, claims.TokenUser.ID)
+
+	if err != nil {
+		return false, err
+	}
+
+	if user.deleted {
+		return true, nil
+	}
+
+	revokedBefore := user.revokedBefore
+
+	// tokens carry the issue time with revocationPrecision, revokedBefore is truncated to it as well
+	if revokedBefore != nil && (claims.IssuedAt == nil || claims.IssuedAt.Time.Before(*revokedBefore)) {
+		return true, nil
+	}
+
+	// tokens issued before jti was introduced can only be revoked with all sessions
+	if claims.RegisteredClaims.ID == "" {
+		return false, nil
+	}
+
+	return s.tokenRevoked(ctx, claims)
+}
+
+func (s *CachedRevocationStore) Revoke(ctx context.Context, claims *Claims) error {
+	// tokens issued before jti was introduced can only be revoked with all sessions
+	if claims.RegisteredClaims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	expiresAt := claims.ExpiresAt.Time
+
+	err := s.revocationRepo.RevokeToken(ctx, claims.RegisteredClaims.ID, claims.TokenUser.ID, expiresAt.UTC())
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[claims.RegisteredClaims.ID] = cachedToken{revoked: true, until: expiresAt}
+
+	return nil
+}
+
+// RevokeAll revokes tokens issued before now. Tokens issued earlier within the same revocationPrecision
+// stay valid, so callers holding the token of the request revoke it explicitly.
+func (s *CachedRevocationStore) RevokeAll(ctx context.Context, userID int) error {
+	before := s.now().UTC().Truncate(revocationPrecision)
+
+	err := s.revocationRepo.RevokeUserTokens(ctx, userID, before)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[userID] = cachedUser{revokedBefore: &before, until: s.now().Add(s.cacheTTL)}
+
+	return nil
+}
+
+func (s *CachedRevocationStore) RevokeDeleted(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[userID] = cachedUser{deleted: true, until: s.now().Add(s.cacheTTL)}
+}
+
+// Run periodically deletes revocations of expired tokens, expired tokens are rejected anyway.
+func (s *CachedRevocationStore) Run(ctx context.Context) error {
+	ticker := time.NewTicker(revocationPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		deleted, err := s.revocationRepo.DeleteExpiredRevokedTokens(ctx, s.now().UTC())
+
+		if err != nil {
+			s.logger.Errorw("failed to purge revoked tokens", "err", err)
+			continue
+		}
+
+		if deleted > 0 {
+			s.logger.Infow("purged revoked tokens", "count", deleted)
+		}
+	}
+}
+
+func (s *CachedRevocationStore) user(ctx context.Context, userID int) (cachedUser, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	cached, ok := s.users[userID]
+	s.mu.Unlock()
+
+	if ok && now.Before(cached.until) {
+		return cached, nil
+	}
+
+	revokedBefore, err := s.revocationRepo.GetUserTokensRevokedBefore(ctx, userID)
+	deleted := errors.Is(err, repository.ErrUserNotFound)
+
+	if err != nil && !deleted {
+		return cachedUser{}, err
+	}
+
+	user := cachedUser{revokedBefore: revokedBefore, deleted: deleted, until: now.Add(s.cacheTTL)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	s.users[userID] = user
+
+	return user, nil
+}
+
+func (s *CachedRevocationStore) tokenRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	now := s.now()
+	jti := claims.RegisteredClaims.ID
+
+	s.mu.Lock()
+	cached, ok := s.tokens[jti]
+	s.mu.Unlock()
+
+	if ok && now.Before(cached.until) {
+		return cached.revoked, nil
+	}
+
+	revoked, err := s.revocationRepo.IsTokenRevoked(ctx, jti)
+
+	if err != nil {
+		return false, err
+	}
+
+	until := now.Add(s.cacheTTL)
+
+	if revoked && claims.ExpiresAt != nil {
+		until = claims.ExpiresAt.Time
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(now)
+	s.tokens[jti] = cachedToken{revoked: revoked, until: until}
+
+	return revoked, nil
+}
+
+// prune drops outdated cache entries, at most once per cacheTTL. It must be called with mu held.
+func (s *CachedRevocationStore) prune(now time.Time) {
+	if now.Before(s.nextPrune) {
+		return
+	}
+
+	s.nextPrune = now.Add(s.cacheTTL)
+
+	for jti, cached := range s.tokens {
+		if !now.Before(cached.until) {
+			delete(s.tokens, jti)
+		}
+	}
+
+	for userID, cached := range s.users {
+		if !now.Before(cached.until) {
+			delete(s.users, userID)
+		}
+	}
+}
+
+var _ RevocationStore = (*CachedRevocationStore)(nil)
+
+func NewCachedRevocationStore(revocationRepo repository.TokenRevocationRepository, logger logger.Logger, cacheTTL time.Duration) *CachedRevocationStore {
+	return &CachedRevocationStore{
+		revocationRepo: revocationRepo,
+		logger:         logger,
+		cacheTTL:       cacheTTL,
+		now:            time.Now,
+		tokens:         make(map[string]cachedToken),
+		users:          make(map[int]cachedUser),
+	}
+}
+
+// RevocableTokenService rejects revoked tokens on validation, so JWTAuth and the gRPC auth interceptors
+// consult the revocation store for every authenticated request.
+type RevocableTokenService struct {
+	TokenService
+	store RevocationStore
+}
+
+func (s *RevocableTokenService) Validate(token string) (*Claims, error) {
+	claims, err := s.TokenService.Validate(token)
+
+	if err != nil {
+		return claims, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), revocationCheckTimeout)
+	defer cancel()
+
+	revoked, err := s.store.IsRevoked(ctx, claims)
+
+	if err != nil {
+		return claims, err
+	}
+
+	if revoked {
+		return claims, ErrTokenRevoked
+	}
+
+	return claims, nil
+}
+
+var _ TokenService = (*RevocableTokenService)(nil)
+
+func NewRevocableTokenService(tokenService TokenService, store RevocationStore) *RevocableTokenService {
+	return &RevocableTokenService{
+		TokenService: tokenService,
+		store:        store,
+	}
+}
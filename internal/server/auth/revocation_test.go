@@ -0,0 +1,253 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedRevocationStore_isRevoked(t *testing.T) {
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	expiresAt := issuedAt.Add(time.Hour)
+
+	newClaims := func(jti string, userID int) *auth.Claims {
+		return &auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        jti,
+				IssuedAt:  jwt.NewNumericDate(issuedAt),
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+			TokenUser: auth.TokenUser{ID: userID},
+		}
+	}
+
+	revokedBefore := issuedAt.Add(time.Second)
+
+	tests := []struct {
+		name      string
+		claims    *auth.Claims
+		setupMock func(revocationRepoMock *repository.MockTokenRevocationRepository)
+		expected  bool
+	}{
+		{
+			name:   "should not revoke token",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(nil, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), "jti").Return(false, nil)
+			},
+		},
+		{
+			name:   "should revoke token revoked by itself",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(nil, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), "jti").Return(true, nil)
+			},
+			expected: true,
+		},
+		{
+			name:   "should revoke token issued before all sessions were logged out",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(&revokedBefore, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: true,
+		},
+		{
+			name:   "should revoke token issued earlier within the second all sessions were logged out",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revokedBefore := issuedAt.Add(500 * time.Millisecond)
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(&revokedBefore, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: true,
+		},
+		{
+			name:   "should not revoke token issued when all sessions were logged out",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(&issuedAt, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), "jti").Return(false, nil)
+			},
+		},
+		{
+			name:   "should revoke token of deleted user",
+			claims: newClaims("jti", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(nil, repository.ErrUserNotFound)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: true,
+		},
+		{
+			name:   "should not look up token without jti",
+			claims: newClaims("", 1),
+			setupMock: func(revocationRepoMock *repository.MockTokenRevocationRepository) {
+				revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(nil, nil)
+				revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			revocationRepoMock := repository.NewMockTokenRevocationRepository(ctrl)
+
+			tc.setupMock(revocationRepoMock)
+
+			s := auth.NewCachedRevocationStore(revocationRepoMock, logger.New("info"), time.Minute)
+
+			revoked, err := s.IsRevoked(context.Background(), tc.claims)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, revoked)
+
+			// the second lookup is served from the cache
+			revoked, err = s.IsRevoked(context.Background(), tc.claims)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, revoked)
+		})
+	}
+}
+
+func TestCachedRevocationStore_revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revocationRepoMock := repository.NewMockTokenRevocationRepository(ctrl)
+
+	s := auth.NewCachedRevocationStore(revocationRepoMock, logger.New("info"), time.Minute)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	expiresAt := issuedAt.Add(time.Hour)
+
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenUser: auth.TokenUser{ID: 1},
+	}
+
+	revocationRepoMock.EXPECT().GetUserTokensRevokedBefore(gomock.Any(), 1).Return(nil, nil)
+	revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), "jti").Return(false, nil)
+
+	revoked, err := s.IsRevoked(context.Background(), claims)
+
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	revocationRepoMock.EXPECT().RevokeToken(gomock.Any(), "jti", 1, claims.ExpiresAt.Time.UTC()).Return(nil)
+
+	require.NoError(t, s.Revoke(context.Background(), claims))
+
+	// the revocation replaces the cached lookup without querying the database again
+	revoked, err = s.IsRevoked(context.Background(), claims)
+
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revocationRepoMock.EXPECT().RevokeUserTokens(gomock.Any(), 1, gomock.Any()).Return(nil)
+
+	require.NoError(t, s.RevokeAll(context.Background(), 1))
+
+	other := *claims
+	other.RegisteredClaims.ID = "other"
+
+	revoked, err = s.IsRevoked(context.Background(), &other)
+
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestCachedRevocationStore_revokeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revocationRepoMock := repository.NewMockTokenRevocationRepository(ctrl)
+	tokenService := auth.NewJWTTokenService("secret", time.Hour)
+
+	s := auth.NewCachedRevocationStore(revocationRepoMock, logger.New("info"), time.Minute)
+
+	validate := func() *auth.Claims {
+		token, err := tokenService.Build(1)
+		require.NoError(t, err)
+
+		claims, err := tokenService.Validate(token)
+		require.NoError(t, err)
+
+		return claims
+	}
+
+	before := validate()
+
+	time.Sleep(2 * time.Millisecond)
+
+	revocationRepoMock.EXPECT().RevokeUserTokens(gomock.Any(), 1, gomock.Any()).Return(nil)
+
+	require.NoError(t, s.RevokeAll(context.Background(), 1))
+
+	time.Sleep(2 * time.Millisecond)
+
+	after := validate()
+
+	// both tokens are usually issued within the same second, only the earlier one is revoked
+	revoked, err := s.IsRevoked(context.Background(), before)
+
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revocationRepoMock.EXPECT().IsTokenRevoked(gomock.Any(), after.RegisteredClaims.ID).Return(false, nil)
+
+	revoked, err = s.IsRevoked(context.Background(), after)
+
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestRevocableTokenService_validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+
+	s := auth.NewRevocableTokenService(auth.NewJWTTokenService("secret", time.Hour), revocationStoreMock)
+
+	token, err := s.Build(1)
+
+	require.NoError(t, err)
+
+	revocationStoreMock.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, claims *auth.Claims) (bool, error) {
+		require.NotEmpty(t, claims.RegisteredClaims.ID)
+		require.NotNil(t, claims.IssuedAt)
+		require.Equal(t, 1, claims.TokenUser.ID)
+
+		return false, nil
+	})
+
+	claims, err := s.Validate(token)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, claims.TokenUser.ID)
+
+	revocationStoreMock.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(true, nil)
+
+	_, err = s.Validate(token)
+
+	require.ErrorIs(t, err, auth.ErrTokenRevoked)
+}
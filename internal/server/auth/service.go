@@ -2,8 +2,13 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-jet/jet/v2/qrm"
 	"github.com/sodiqit/gophermart/internal/server/dtos"
@@ -12,72 +17,441 @@ import (
 )
 
 type AuthService interface {
-	Register(ctx context.Context, username string, password string) (string, error)
-	Login(ctx context.Context, username string, password string) (string, error)
+	Register(ctx context.Context, username string, password string) (dtos.TokenPair, error)
+	Login(ctx context.Context, username string, password string) (dtos.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (dtos.TokenPair, error)
+	Logout(ctx context.Context, claims *Claims, refreshToken string) error
+	LogoutAll(ctx context.Context, claims *Claims) error
+	ChangePassword(ctx context.Context, claims *Claims, currentPassword string, newPassword string) (dtos.TokenPair, error)
+	RequestPasswordReset(ctx context.Context, username string) error
+	ResetPassword(ctx context.Context, resetToken string, newPassword string) error
+	DeleteAccount(ctx context.Context, claims *Claims, password string) error
 }
 
 var ErrUserAlreadyExist = errors.New("user already exist")
 var ErrUserNotFound = errors.New("user not found")
 var ErrIncorrectPassword = errors.New("incorrect password")
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+var ErrInvalidResetToken = errors.New("invalid password reset token")
+var ErrPasswordResetDisabled = errors.New("password reset disabled")
 
 type SimpleAuthService struct {
-	tokenService TokenService
-	userRepo     repository.UserRepository
+	tokenService      TokenService
+	userRepo          repository.UserRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	passwordResetRepo repository.PasswordResetRepository
+	revocationStore   RevocationStore
+	notifier          PasswordResetNotifier
+	transactor        repository.Transactor
+	refreshTokenTTL   time.Duration
+	resetTokenTTL     time.Duration
 }
 
-func (s *SimpleAuthService) Register(ctx context.Context, username string, password string) (string, error) {
+func (s *SimpleAuthService) Register(ctx context.Context, username string, password string) (dtos.TokenPair, error) {
 	op := "authService.register"
 
 	exist, err := s.userRepo.Exist(ctx, username)
 
 	if err != nil {
-		return "", err
+		return dtos.TokenPair{}, err
 	}
 
 	if exist {
-		return "", fmt.Errorf("%s: %w", op, ErrUserAlreadyExist)
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, ErrUserAlreadyExist)
 	}
 
 	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 
 	if err != nil {
-		return "", err
+		return dtos.TokenPair{}, err
 	}
 
 	userID, err := s.userRepo.Create(ctx, dtos.User{Login: username, PasswordHash: string(passHash)})
 
 	if err != nil {
-		return "", err
+		return dtos.TokenPair{}, err
 	}
 
-	return s.tokenService.Build(userID)
+	return s.issueTokens(ctx, userID, "")
 }
 
-func (s *SimpleAuthService) Login(ctx context.Context, username string, password string) (string, error) {
+func (s *SimpleAuthService) Login(ctx context.Context, username string, password string) (dtos.TokenPair, error) {
 	op := "authService.login"
 
 	user, err := s.userRepo.FindByLogin(ctx, username)
 
 	if errors.Is(err, qrm.ErrNoRows) {
-		return "", fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, ErrUserNotFound)
 	}
 
 	if err != nil {
-		return "", err
+		return dtos.TokenPair{}, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, ErrIncorrectPassword)
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, ErrIncorrectPassword)
+	}
+
+	return s.issueTokens(ctx, user.ID, "")
+}
+
+// Refresh exchanges the refresh token for a new access token and a new refresh token, the exchanged token
+// can not be used again. A used token presented again was stolen or replayed: every token of its family
+// is revoked, so both the thief and the user have to log in again, and ErrRefreshTokenReused is returned.
+func (s *SimpleAuthService) Refresh(ctx context.Context, refreshToken string) (dtos.TokenPair, error) {
+	op := "authService.refresh"
+
+	var pair dtos.TokenPair
+	var reused bool
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.refreshTokenRepo.LockRefreshToken(ctx, hashToken(refreshToken))
+
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return ErrInvalidRefreshToken
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if token.RevokedAt != nil || token.Expired {
+			return ErrInvalidRefreshToken
+		}
+
+		// the family is revoked in this transaction, the error is returned once it commits
+		if token.UsedAt != nil {
+			reused = true
+			return s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+		}
+
+		if err := s.refreshTokenRepo.MarkRefreshTokenUsed(ctx, token.ID); err != nil {
+			return err
+		}
+
+		pair, err = s.issueTokens(ctx, token.UserID, token.FamilyID)
+
+		return err
+	})
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reused {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, ErrRefreshTokenReused)
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the access token of claims and, if given, the family of the refresh token, so the session
+// can not be refreshed either. Refresh tokens which are unknown or belong to another user are ignored.
+func (s *SimpleAuthService) Logout(ctx context.Context, claims *Claims, refreshToken string) error {
+	op := "authService.logout"
+
+	if refreshToken != "" {
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			token, err := s.refreshTokenRepo.LockRefreshToken(ctx, hashToken(refreshToken))
+
+			if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if token.UserID != claims.TokenUser.ID {
+				return nil
+			}
+
+			return s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, token.FamilyID)
+		})
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := s.revocationStore.Revoke(ctx, claims); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LogoutAll revokes every access and refresh token issued to the user of claims, including the token of claims.
+func (s *SimpleAuthService) LogoutAll(ctx context.Context, claims *Claims) error {
+	op := "authService.logoutAll"
+
+	userID := claims.TokenUser.ID
+
+	if err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.revocationStore.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.revocationStore.Revoke(ctx, claims); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangePassword sets the new password once the current one is confirmed. Every session of the user is logged out,
+// the returned tokens start a new one.
+func (s *SimpleAuthService) ChangePassword(ctx context.Context, claims *Claims, currentPassword string, newPassword string) (dtos.TokenPair, error) {
+	op := "authService.changePassword"
+
+	userID := claims.TokenUser.ID
+
+	if err := s.confirmPassword(ctx, userID, currentPassword); err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.storePassword(ctx, userID, string(passHash))
+	})
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.revocationStore.RevokeAll(ctx, userID); err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.revocationStore.Revoke(ctx, claims); err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.issueTokens(ctx, userID, "")
+}
+
+// RequestPasswordReset sends a single-use reset token to the user through the notifier. Unknown logins are
+// not reported, so the method can not be used to find out which logins are registered.
+func (s *SimpleAuthService) RequestPasswordReset(ctx context.Context, username string) error {
+	op := "authService.requestPasswordReset"
+
+	if s.notifier == nil {
+		return fmt.Errorf("%s: %w", op, ErrPasswordResetDisabled)
+	}
+
+	user, err := s.userRepo.FindByLogin(ctx, username)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	resetToken, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	expiresAt := time.Now().Add(s.resetTokenTTL)
+
+	err = s.passwordResetRepo.CreatePasswordResetToken(ctx, user.ID, hashToken(resetToken), s.resetTokenTTL)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.notifier.NotifyPasswordReset(ctx, dtos.PasswordReset{UserID: user.ID, Login: user.Login, Token: resetToken, ExpiresAt: expiresAt})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets the new password of the user the reset token was sent to. The token and any other
+// outstanding reset token of the user are used up, every session of the user is logged out.
+func (s *SimpleAuthService) ResetPassword(ctx context.Context, resetToken string, newPassword string) error {
+	op := "authService.resetPassword"
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userID int
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.passwordResetRepo.LockPasswordResetToken(ctx, hashToken(resetToken))
+
+		if errors.Is(err, repository.ErrPasswordResetTokenNotFound) {
+			return ErrInvalidResetToken
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if token.UsedAt != nil || token.Expired {
+			return ErrInvalidResetToken
+		}
+
+		userID = token.UserID
+
+		return s.storePassword(ctx, token.UserID, string(passHash))
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.revocationStore.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteAccount deletes the user once the password is confirmed. Orders, balance, tokens and everything else
+// the user owns are deleted along by the foreign keys. Transfers with other users are kept for them
+// with the user unset.
+func (s *SimpleAuthService) DeleteAccount(ctx context.Context, claims *Claims, password string) error {
+	op := "authService.deleteAccount"
+
+	userID := claims.TokenUser.ID
+
+	if err := s.confirmPassword(ctx, userID, password); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err := s.userRepo.Delete(ctx, userID)
+
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.revocationStore.RevokeDeleted(userID)
+
+	return nil
+}
+
+// confirmPassword returns ErrIncorrectPassword unless the password is the password of the user.
+func (s *SimpleAuthService) confirmPassword(ctx context.Context, userID int, password string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return ErrUserNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrIncorrectPassword
+	}
+
+	return nil
+}
+
+// storePassword sets the password hash of the user and revokes the refresh and reset tokens issued so far.
+// It must be called within Transactor.WithinTransaction, access tokens are revoked by the caller once it commits.
+func (s *SimpleAuthService) storePassword(ctx context.Context, userID int, passHash string) error {
+	if err := s.userRepo.Update(ctx, userID, dtos.UserUpdate{PasswordHash: &passHash}); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.passwordResetRepo.UsePasswordResetTokens(ctx, userID)
+}
+
+// issueTokens builds the access token and stores a new refresh token of the family, empty familyID starts a new family.
+func (s *SimpleAuthService) issueTokens(ctx context.Context, userID int, familyID string) (dtos.TokenPair, error) {
+	op := "authService.issueTokens"
+
+	accessToken, err := s.tokenService.Build(userID)
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if familyID == "" {
+		familyID, err = randomString(16, hex.EncodeToString)
+
+		if err != nil {
+			return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	refreshToken, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.refreshTokenRepo.CreateRefreshToken(ctx, userID, familyID, hashToken(refreshToken), s.refreshTokenTTL)
+
+	if err != nil {
+		return dtos.TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dtos.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.tokenService.TTL().Seconds()),
+	}, nil
+}
+
+// hashToken returns the hash refresh and password reset tokens are stored and looked up by.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(size int, encode func([]byte) string) (string, error) {
+	b := make([]byte, size)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
 
-	return s.tokenService.Build(user.ID)
+	return encode(b), nil
 }
 
-func NewSimpleAuthService(tokenService TokenService, userRepo repository.UserRepository) *SimpleAuthService {
+// NewSimpleAuthService returns the service, a nil notifier disables password reset.
+func NewSimpleAuthService(tokenService TokenService, userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, passwordResetRepo repository.PasswordResetRepository, revocationStore RevocationStore, notifier PasswordResetNotifier, transactor repository.Transactor, refreshTokenTTL time.Duration, resetTokenTTL time.Duration) *SimpleAuthService {
 	return &SimpleAuthService{
-		tokenService: tokenService,
-		userRepo:     userRepo,
+		tokenService:      tokenService,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		passwordResetRepo: passwordResetRepo,
+		revocationStore:   revocationStore,
+		notifier:          notifier,
+		transactor:        transactor,
+		refreshTokenTTL:   refreshTokenTTL,
+		resetTokenTTL:     resetTokenTTL,
 	}
 }
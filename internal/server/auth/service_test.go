@@ -2,10 +2,14 @@ package auth_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-jet/jet/v2/qrm"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/sodiqit/gophermart/internal/server/auth"
 	"github.com/sodiqit/gophermart/internal/server/dtos"
 	"github.com/sodiqit/gophermart/internal/server/repository"
@@ -20,8 +24,9 @@ func TestAuthService_register(t *testing.T) {
 
 	tokenServiceMock := auth.NewMockTokenService(ctrl)
 	userRepoMock := repository.NewMockUserRepository(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
 
-	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock)
+	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock, refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), auth.NewMockRevocationStore(ctrl), nil, repository.NewMockTransactor(ctrl), time.Hour, time.Hour)
 
 	tests := []struct {
 		name           string
@@ -53,6 +58,8 @@ func TestAuthService_register(t *testing.T) {
 				userRepoMock.EXPECT().Exist(gomock.Any(), "test").Return(false, nil)
 				userRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
 				tokenServiceMock.EXPECT().Build(1).Return("test_token", nil)
+				tokenServiceMock.EXPECT().TTL().Return(10 * time.Minute)
+				refreshTokenRepoMock.EXPECT().CreateRefreshToken(gomock.Any(), 1, gomock.Any(), gomock.Any(), time.Hour).Return(nil)
 			},
 			wantErr:        false,
 			expectedResult: "test_token",
@@ -75,7 +82,9 @@ func TestAuthService_register(t *testing.T) {
 
 			if !tc.wantErr {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedResult, token)
+				require.Equal(t, tc.expectedResult, token.AccessToken)
+				require.NotEmpty(t, token.RefreshToken)
+				require.Equal(t, int64(600), token.ExpiresIn)
 			}
 		})
 	}
@@ -87,8 +96,9 @@ func TestAuthService_login(t *testing.T) {
 
 	tokenServiceMock := auth.NewMockTokenService(ctrl)
 	userRepoMock := repository.NewMockUserRepository(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
 
-	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock)
+	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock, refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), auth.NewMockRevocationStore(ctrl), nil, repository.NewMockTransactor(ctrl), time.Hour, time.Hour)
 
 	tests := []struct {
 		name           string
@@ -121,6 +131,8 @@ func TestAuthService_login(t *testing.T) {
 				passHash, _ := bcrypt.GenerateFromPassword([]byte("test"), bcrypt.DefaultCost)
 				userRepoMock.EXPECT().FindByLogin(gomock.Any(), "test").Return(dtos.User{ID: 1, Login: "test", PasswordHash: string(passHash)}, nil)
 				tokenServiceMock.EXPECT().Build(1).Return("test_token", nil)
+				tokenServiceMock.EXPECT().TTL().Return(10 * time.Minute)
+				refreshTokenRepoMock.EXPECT().CreateRefreshToken(gomock.Any(), 1, gomock.Any(), gomock.Any(), time.Hour).Return(nil)
 			},
 			wantErr:        false,
 			expectedResult: "test_token",
@@ -143,8 +155,458 @@ func TestAuthService_login(t *testing.T) {
 
 			if !tc.wantErr {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedResult, token)
+				require.Equal(t, tc.expectedResult, token.AccessToken)
+				require.NotEmpty(t, token.RefreshToken)
+				require.Equal(t, int64(600), token.ExpiresIn)
 			}
 		})
 	}
 }
+
+func TestAuthService_refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := auth.NewSimpleAuthService(tokenServiceMock, repository.NewMockUserRepository(ctrl), refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), auth.NewMockRevocationStore(ctrl), nil, transactorMock, time.Hour, time.Hour)
+
+	sum := sha256.Sum256([]byte("refresh"))
+	tokenHash := hex.EncodeToString(sum[:])
+	usedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError error
+	}{
+		{
+			name: "should rotate refresh token",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), tokenHash).Return(dtos.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", TokenHash: tokenHash}, nil)
+				refreshTokenRepoMock.EXPECT().MarkRefreshTokenUsed(gomock.Any(), int64(1)).Return(nil)
+				tokenServiceMock.EXPECT().Build(1).Return("test_token", nil)
+				tokenServiceMock.EXPECT().TTL().Return(10 * time.Minute)
+				refreshTokenRepoMock.EXPECT().CreateRefreshToken(gomock.Any(), 1, "family", gomock.Any(), time.Hour).Return(nil)
+			},
+		},
+		{
+			name: "should return error if refresh token unknown",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{}, repository.ErrRefreshTokenNotFound)
+			},
+			expectedError: auth.ErrInvalidRefreshToken,
+		},
+		{
+			name: "should return error if refresh token expired",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", Expired: true}, nil)
+				refreshTokenRepoMock.EXPECT().MarkRefreshTokenUsed(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrInvalidRefreshToken,
+		},
+		{
+			name: "should revoke family if refresh token reused",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", UsedAt: &usedAt}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+				tokenServiceMock.EXPECT().Build(gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			tokens, err := s.Refresh(context.Background(), "refresh")
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "test_token", tokens.AccessToken)
+			require.NotEmpty(t, tokens.RefreshToken)
+			require.NotEqual(t, "refresh", tokens.RefreshToken)
+		})
+	}
+}
+
+func TestAuthService_logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), repository.NewMockUserRepository(ctrl), refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), revocationStoreMock, nil, transactorMock, time.Hour, time.Hour)
+
+	claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{ID: "jti"}, TokenUser: auth.TokenUser{ID: 1}}
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		setupMock    func()
+	}{
+		{
+			name: "should revoke access token",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Times(0)
+				revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil)
+			},
+		},
+		{
+			name:         "should revoke refresh token family",
+			refreshToken: "refresh",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+				revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil)
+			},
+		},
+		{
+			name:         "should not revoke refresh token of another user",
+			refreshToken: "refresh",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{ID: 1, UserID: 2, FamilyID: "family"}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), gomock.Any()).Times(0)
+				revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil)
+			},
+		},
+		{
+			name:         "should ignore unknown refresh token",
+			refreshToken: "unknown",
+			setupMock: func() {
+				refreshTokenRepoMock.EXPECT().LockRefreshToken(gomock.Any(), gomock.Any()).Return(dtos.RefreshToken{}, repository.ErrRefreshTokenNotFound)
+				revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := s.Logout(context.Background(), claims, tc.refreshToken)
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthService_logoutAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+
+	s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), repository.NewMockUserRepository(ctrl), refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), revocationStoreMock, nil, repository.NewMockTransactor(ctrl), time.Hour, time.Hour)
+
+	claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{ID: "jti"}, TokenUser: auth.TokenUser{ID: 1}}
+
+	gomock.InOrder(
+		refreshTokenRepoMock.EXPECT().RevokeUserRefreshTokens(gomock.Any(), 1).Return(nil),
+		revocationStoreMock.EXPECT().RevokeAll(gomock.Any(), 1).Return(nil),
+		revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil),
+	)
+
+	err := s.LogoutAll(context.Background(), claims)
+
+	require.NoError(t, err)
+}
+
+func TestAuthService_changePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	userRepoMock := repository.NewMockUserRepository(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
+	passwordResetRepoMock := repository.NewMockPasswordResetRepository(ctrl)
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock, refreshTokenRepoMock, passwordResetRepoMock, revocationStoreMock, nil, transactorMock, time.Hour, time.Hour)
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte("current"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	claims := &auth.Claims{TokenUser: auth.TokenUser{ID: 1}}
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		setupMock       func()
+		expectedError   error
+	}{
+		{
+			name:            "should change password and start new session",
+			currentPassword: "current",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByID(gomock.Any(), 1).Return(dtos.User{ID: 1, PasswordHash: string(passHash)}, nil)
+				userRepoMock.EXPECT().Update(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(ctx context.Context, userID int, update dtos.UserUpdate) error {
+					require.Nil(t, update.Login)
+					require.NoError(t, bcrypt.CompareHashAndPassword([]byte(*update.PasswordHash), []byte("new_password")))
+					return nil
+				})
+				refreshTokenRepoMock.EXPECT().RevokeUserRefreshTokens(gomock.Any(), 1).Return(nil)
+				passwordResetRepoMock.EXPECT().UsePasswordResetTokens(gomock.Any(), 1).Return(nil)
+				gomock.InOrder(
+					revocationStoreMock.EXPECT().RevokeAll(gomock.Any(), 1).Return(nil),
+					revocationStoreMock.EXPECT().Revoke(gomock.Any(), claims).Return(nil),
+					tokenServiceMock.EXPECT().Build(1).Return("test_token", nil),
+				)
+				tokenServiceMock.EXPECT().TTL().Return(10 * time.Minute)
+				refreshTokenRepoMock.EXPECT().CreateRefreshToken(gomock.Any(), 1, gomock.Any(), gomock.Any(), time.Hour).Return(nil)
+			},
+		},
+		{
+			name:            "should return error if current password incorrect",
+			currentPassword: "incorrect",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByID(gomock.Any(), 1).Return(dtos.User{ID: 1, PasswordHash: string(passHash)}, nil)
+				userRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				revocationStoreMock.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrIncorrectPassword,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			tokens, err := s.ChangePassword(context.Background(), claims, tc.currentPassword, "new_password")
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "test_token", tokens.AccessToken)
+		})
+	}
+}
+
+func TestAuthService_requestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := repository.NewMockUserRepository(ctrl)
+	passwordResetRepoMock := repository.NewMockPasswordResetRepository(ctrl)
+	notifierMock := auth.NewMockPasswordResetNotifier(ctrl)
+
+	s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), userRepoMock, repository.NewMockRefreshTokenRepository(ctrl), passwordResetRepoMock, auth.NewMockRevocationStore(ctrl), notifierMock, repository.NewMockTransactor(ctrl), time.Hour, 30*time.Minute)
+
+	tests := []struct {
+		name      string
+		setupMock func()
+	}{
+		{
+			name: "should send reset token",
+			setupMock: func() {
+				var tokenHash string
+
+				userRepoMock.EXPECT().FindByLogin(gomock.Any(), "test").Return(dtos.User{ID: 1, Login: "test"}, nil)
+				passwordResetRepoMock.EXPECT().CreatePasswordResetToken(gomock.Any(), 1, gomock.Any(), 30*time.Minute).DoAndReturn(func(ctx context.Context, userID int, hash string, ttl time.Duration) error {
+					tokenHash = hash
+					return nil
+				})
+				notifierMock.EXPECT().NotifyPasswordReset(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, reset dtos.PasswordReset) error {
+					sum := sha256.Sum256([]byte(reset.Token))
+
+					require.Equal(t, hex.EncodeToString(sum[:]), tokenHash)
+					require.Equal(t, 1, reset.UserID)
+					require.Equal(t, "test", reset.Login)
+					return nil
+				})
+			},
+		},
+		{
+			name: "should not report unknown login",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByLogin(gomock.Any(), "test").Return(dtos.User{}, qrm.ErrNoRows)
+				passwordResetRepoMock.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				notifierMock.EXPECT().NotifyPasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := s.RequestPasswordReset(context.Background(), "test")
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("should return error if password reset disabled", func(t *testing.T) {
+		s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), userRepoMock, repository.NewMockRefreshTokenRepository(ctrl), passwordResetRepoMock, auth.NewMockRevocationStore(ctrl), nil, repository.NewMockTransactor(ctrl), time.Hour, 30*time.Minute)
+
+		err := s.RequestPasswordReset(context.Background(), "test")
+
+		require.ErrorIs(t, err, auth.ErrPasswordResetDisabled)
+	})
+}
+
+func TestAuthService_resetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := repository.NewMockUserRepository(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
+	passwordResetRepoMock := repository.NewMockPasswordResetRepository(ctrl)
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), userRepoMock, refreshTokenRepoMock, passwordResetRepoMock, revocationStoreMock, auth.NewMockPasswordResetNotifier(ctrl), transactorMock, time.Hour, time.Hour)
+
+	sum := sha256.Sum256([]byte("reset"))
+	tokenHash := hex.EncodeToString(sum[:])
+	usedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError error
+	}{
+		{
+			name: "should reset password",
+			setupMock: func() {
+				passwordResetRepoMock.EXPECT().LockPasswordResetToken(gomock.Any(), tokenHash).Return(dtos.PasswordResetToken{ID: 1, UserID: 1, TokenHash: tokenHash}, nil)
+				userRepoMock.EXPECT().Update(gomock.Any(), 1, gomock.Any()).Return(nil)
+				refreshTokenRepoMock.EXPECT().RevokeUserRefreshTokens(gomock.Any(), 1).Return(nil)
+				passwordResetRepoMock.EXPECT().UsePasswordResetTokens(gomock.Any(), 1).Return(nil)
+				revocationStoreMock.EXPECT().RevokeAll(gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "should return error if token unknown",
+			setupMock: func() {
+				passwordResetRepoMock.EXPECT().LockPasswordResetToken(gomock.Any(), tokenHash).Return(dtos.PasswordResetToken{}, repository.ErrPasswordResetTokenNotFound)
+			},
+			expectedError: auth.ErrInvalidResetToken,
+		},
+		{
+			name: "should return error if token used",
+			setupMock: func() {
+				passwordResetRepoMock.EXPECT().LockPasswordResetToken(gomock.Any(), tokenHash).Return(dtos.PasswordResetToken{ID: 1, UserID: 1, UsedAt: &usedAt}, nil)
+				userRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrInvalidResetToken,
+		},
+		{
+			name: "should return error if token expired",
+			setupMock: func() {
+				passwordResetRepoMock.EXPECT().LockPasswordResetToken(gomock.Any(), tokenHash).Return(dtos.PasswordResetToken{ID: 1, UserID: 1, Expired: true}, nil)
+				userRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := s.ResetPassword(context.Background(), "reset", "new_password")
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthService_deleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := repository.NewMockUserRepository(ctrl)
+	revocationStoreMock := auth.NewMockRevocationStore(ctrl)
+
+	s := auth.NewSimpleAuthService(auth.NewMockTokenService(ctrl), userRepoMock, repository.NewMockRefreshTokenRepository(ctrl), repository.NewMockPasswordResetRepository(ctrl), revocationStoreMock, nil, repository.NewMockTransactor(ctrl), time.Hour, time.Hour)
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	claims := &auth.Claims{TokenUser: auth.TokenUser{ID: 1}}
+
+	tests := []struct {
+		name          string
+		password      string
+		setupMock     func()
+		expectedError error
+	}{
+		{
+			name:     "should delete account",
+			password: "password",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByID(gomock.Any(), 1).Return(dtos.User{ID: 1, PasswordHash: string(passHash)}, nil)
+				userRepoMock.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+				revocationStoreMock.EXPECT().RevokeDeleted(1)
+			},
+		},
+		{
+			name:     "should return error if password incorrect",
+			password: "incorrect",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByID(gomock.Any(), 1).Return(dtos.User{ID: 1, PasswordHash: string(passHash)}, nil)
+				userRepoMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: auth.ErrIncorrectPassword,
+		},
+		{
+			name:     "should return error if user already deleted",
+			password: "password",
+			setupMock: func() {
+				userRepoMock.EXPECT().FindByID(gomock.Any(), 1).Return(dtos.User{}, qrm.ErrNoRows)
+			},
+			expectedError: auth.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := s.DeleteAccount(context.Background(), claims, tc.password)
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
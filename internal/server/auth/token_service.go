@@ -2,15 +2,25 @@ package auth
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// revocationPrecision is the precision token issue times and revocation cutoffs are kept with. Seconds are not
+// enough: tokens issued earlier within the second all sessions are logged out in would stay valid.
+const revocationPrecision = time.Millisecond
+
+func init() {
+	jwt.TimePrecision = revocationPrecision
+}
+
 type TokenService interface {
 	Build(userID int) (string, error)
 	Validate(token string) (*Claims, error)
+	TTL() time.Duration
 }
 
 type contextKey string
@@ -26,20 +36,44 @@ type Claims struct {
 	TokenUser
 }
 
+// JWTTokenService signs tokens with HS256 and the secret key, or with the signing key of keys when they are set.
+// Tokens signed with a key carry its kid header, any key of the set verifies them, so the signing key can be
+// rotated while tokens signed with the previous one are still valid.
 type JWTTokenService struct {
 	secretKey string
+	keys      *KeySet
 	tokenExp  time.Duration
 }
 
+// Build returns a token of the user with a unique ID (jti claim), the ID is what logout revokes.
 func (j *JWTTokenService) Build(userID int) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+	jti, err := randomString(16, hex.EncodeToString)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenExp)),
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.tokenExp)),
 		},
 		TokenUser: TokenUser{ID: userID},
-	})
+	}
+
+	var tokenString string
+
+	if j.keys != nil {
+		key := j.keys.SigningKey()
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+		tokenString, err = token.SignedString(key.PrivateKey)
+	} else {
+		tokenString, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.secretKey))
+	}
 
-	tokenString, err := token.SignedString([]byte(j.secretKey))
 	if err != nil {
 		return "", err
 	}
@@ -47,15 +81,14 @@ func (j *JWTTokenService) Build(userID int) (string, error) {
 	return tokenString, nil
 }
 
+// TTL returns the lifetime of built tokens.
+func (j *JWTTokenService) TTL() time.Duration {
+	return j.tokenExp
+}
+
 func (j *JWTTokenService) Validate(tokenString string) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims,
-		func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			return []byte(j.secretKey), nil
-		})
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.verificationKey)
 	if err != nil {
 		return claims, err
 	}
@@ -67,6 +100,29 @@ func (j *JWTTokenService) Validate(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// verificationKey returns the key the token must be signed with, the algorithm of the token must match the key.
+func (j *JWTTokenService) verificationKey(t *jwt.Token) (interface{}, error) {
+	if j.keys == nil {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return []byte(j.secretKey), nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+
+	key, ok := j.keys.Key(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key: %q", kid)
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
 func ExtractUserFromContext(ctx context.Context) TokenUser {
 	claims, ok := ctx.Value(ClaimsContextKey).(*Claims)
 
@@ -77,9 +133,28 @@ func ExtractUserFromContext(ctx context.Context) TokenUser {
 	return claims.TokenUser
 }
 
+// ExtractClaimsFromContext returns the claims of the token the request is authenticated with.
+func ExtractClaimsFromContext(ctx context.Context) *Claims {
+	claims, ok := ctx.Value(ClaimsContextKey).(*Claims)
+
+	if !ok {
+		panic("no claims in context")
+	}
+
+	return claims
+}
+
 func NewJWTTokenService(secretKey string, tokenExp time.Duration) *JWTTokenService {
 	return &JWTTokenService{
 		secretKey: secretKey,
 		tokenExp:  tokenExp,
 	}
 }
+
+// NewKeySetJWTTokenService returns the service signing tokens with the signing key of keys.
+func NewKeySetJWTTokenService(keys *KeySet, tokenExp time.Duration) *JWTTokenService {
+	return &JWTTokenService{
+		keys:     keys,
+		tokenExp: tokenExp,
+	}
+}
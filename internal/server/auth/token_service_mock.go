@@ -11,6 +11,7 @@ package auth
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,18 +40,32 @@ func (m *MockTokenService) EXPECT() *MockTokenServiceMockRecorder {
 }
 
 // Build mocks base method.
-func (m *MockTokenService) Build(userId int) (string, error) {
+func (m *MockTokenService) Build(userID int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Build", userId)
+	ret := m.ctrl.Call(m, "Build", userID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Build indicates an expected call of Build.
-func (mr *MockTokenServiceMockRecorder) Build(userId any) *gomock.Call {
+func (mr *MockTokenServiceMockRecorder) Build(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockTokenService)(nil).Build), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockTokenService)(nil).Build), userID)
+}
+
+// TTL mocks base method.
+func (m *MockTokenService) TTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// TTL indicates an expected call of TTL.
+func (mr *MockTokenServiceMockRecorder) TTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockTokenService)(nil).TTL))
 }
 
 // Validate mocks base method.
@@ -4,23 +4,29 @@ import (
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
 	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
 	"github.com/sodiqit/gophermart/internal/server/repository"
 )
 
 type BalanceContainer struct {
-	Controller *BalanceController
-	Service    BalanceService
-	GRPCServer *BalanceServer
+	Controller  *BalanceController
+	Service     BalanceService
+	GRPCServer  *BalanceServer
+	HoldSweeper *HoldSweeper
 }
 
-func NewContainer(config *config.Config, logger logger.Logger, tokenService auth.TokenService, balanceRepo repository.BalanceRepository) *BalanceContainer {
-	service := NewService(balanceRepo)
-	controller := NewController(logger, tokenService, service)
+func NewContainer(config *config.Config, logger logger.Logger, tokenService auth.TokenService, balanceRepo repository.BalanceRepository, orderRepo repository.OrderRepository, ledgerRepo repository.LedgerRepository, outboxRepo repository.OutboxRepository, webhookRepo repository.WebhookRepository, transactor repository.Transactor, idempotencyService idempotency.IdempotencyService) *BalanceContainer {
+	policy := ledger.ExpiryPolicy{LifetimeMonths: config.PointsLifetimeMonths, ExpiringSoon: config.PointsExpiringSoon}
+	service := NewService(balanceRepo, orderRepo, ledgerRepo, outboxRepo, webhookRepo, transactor, policy, config.BalanceHoldTTL, config.BalanceTransferDailyLimit)
+	controller := NewController(logger, tokenService, service, idempotencyService)
 	server := NewBalanceServer(logger, service)
+	sweeper := NewHoldSweeper(balanceRepo, logger, config.BalanceHoldSweepInterval)
 
 	return &BalanceContainer{
-		Controller: controller,
-		Service:    service,
-		GRPCServer: server,
+		Controller:  controller,
+		Service:     service,
+		GRPCServer:  server,
+		HoldSweeper: sweeper,
 	}
 }
@@ -1,23 +1,31 @@
 package balance
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
 	"github.com/sodiqit/gophermart/internal/utils"
 	"github.com/sodiqit/gophermart/pkg/luhn"
 )
 
 type BalanceController struct {
-	logger         logger.Logger
-	tokenService   auth.TokenService
-	balanceService BalanceService
+	logger             logger.Logger
+	tokenService       auth.TokenService
+	balanceService     BalanceService
+	idempotencyService idempotency.IdempotencyService
 }
 
 func (c *BalanceController) Connect(r *chi.Mux, basePath string) {
@@ -26,7 +34,17 @@ func (c *BalanceController) Connect(r *chi.Mux, basePath string) {
 
 		r.Get(fmt.Sprintf("%suser/balance", basePath), c.handleGetUserBalance)
 		r.Get(fmt.Sprintf("%suser/withdrawals", basePath), c.handleGetUserWithdrawals)
-		r.With(middleware.AllowContentType("application/json")).Post(fmt.Sprintf("%suser/balance/withdraw", basePath), c.handleWithdraw)
+		r.Get(fmt.Sprintf("%suser/transactions", basePath), c.handleListTransactions)
+		r.Get(fmt.Sprintf("%suser/statement", basePath), c.handleExportStatement)
+		r.With(middleware.AllowContentType("application/json"), idempotency.Middleware(c.idempotencyService, c.logger)).Post(fmt.Sprintf("%suser/balance/withdraw", basePath), c.handleWithdraw)
+
+		r.Get(fmt.Sprintf("%suser/balance/holds", basePath), c.handleGetHolds)
+		r.With(middleware.AllowContentType("application/json"), idempotency.Middleware(c.idempotencyService, c.logger)).Post(fmt.Sprintf("%suser/balance/holds", basePath), c.handleCreateHold)
+		r.Post(fmt.Sprintf("%suser/balance/holds/{holdID}/capture", basePath), c.handleCaptureHold)
+		r.Post(fmt.Sprintf("%suser/balance/holds/{holdID}/void", basePath), c.handleVoidHold)
+
+		r.Get(fmt.Sprintf("%suser/balance/transfers", basePath), c.handleGetTransfers)
+		r.With(middleware.AllowContentType("application/json"), idempotency.Middleware(c.idempotencyService, c.logger)).Post(fmt.Sprintf("%suser/balance/transfers", basePath), c.handleTransfer)
 	})
 }
 
@@ -113,13 +131,131 @@ func (c *BalanceController) handleGetUserWithdrawals(w http.ResponseWriter, r *h
 	w.Write(result)
 }
 
+// handleListTransactions godoc
+//
+//	@Summary		list transactions
+//	@Description	get accruals, withdrawals, adjustments, transfers and expirations of the user, newest first
+//	@Tags			balance
+//
+//	@Param			cursor	query	string		false	"next_cursor of the previous page"
+//	@Param			from	query	string		false	"start of the period, inclusive: RFC 3339 time or YYYY-MM-DD"
+//	@Param			to		query	string		false	"end of the period, exclusive: RFC 3339 time or YYYY-MM-DD"
+//	@Param			type	query	[]string	false	"transaction types, comma separated or repeated"
+//	@Param			limit	query	int			false	"page size, 50 by default, at most 100"
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{object}	dtos.TransactionPage
+//	@Failure		400
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/transactions [get]
+func (c *BalanceController) handleListTransactions(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleListTransactions"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter.UserID = user.ID
+
+	page, err := c.balanceService.ListTransactions(r.Context(), filter)
+
+	if err != nil && errors.Is(err, ErrInvalidTransactionType) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while list transactions", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page, logger)
+}
+
+// handleExportStatement godoc
+//
+//	@Summary		export statement
+//	@Description	Export order uploads, accruals, withdrawals and other transactions of the period with opening and closing balance
+//	@Tags			balance
+//
+//	@Param			month	query	string	false	"calendar month YYYY-MM, alternative to from and to"
+//	@Param			from	query	string	false	"start of the period, inclusive: RFC 3339 time or YYYY-MM-DD"
+//	@Param			to		query	string	false	"end of the period, exclusive: RFC 3339 time or YYYY-MM-DD"
+//	@Param			format	query	string	false	"csv (default) or jsonl"
+//	@Security		ApiKeyAuth
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Success		200
+//	@Failure		400
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/statement [get]
+func (c *BalanceController) handleExportStatement(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleExportStatement"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	from, to, err := parseStatementPeriod(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+
+	if format == "" {
+		format = StatementFormatCSV
+	}
+
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+	writer, err := NewStatementWriter(format, ww)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ww.Header().Set("Content-Type", StatementContentType(format))
+	ww.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"statement-%s-%s.%s\"", from.Format(time.DateOnly), to.Format(time.DateOnly), format))
+
+	err = c.balanceService.ExportStatement(r.Context(), user.ID, from, to, writer)
+
+	if err != nil && errors.Is(err, ErrInvalidPeriod) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while export statement", "err", err)
+
+		// the statement is streamed, once a part of it is sent the status can not be changed
+		if ww.BytesWritten() == 0 {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}
+}
+
 // handleWithdraw godoc
 //
 //	@Summary		create withdraw
 //	@Description	Process new withdraw request
 //	@Tags			balance
 //
-//	@Param			body	body	WithdrawRequestDTO	true	"Withdraw body"
+//	@Param			body			body	WithdrawRequestDTO	true	"Withdraw body"
+//	@Param			Idempotency-Key	header	string				false	"replay the first response for retries with the same key"
 //	@Security		ApiKeyAuth
 //	@Accept			json
 //	@Produce		json
@@ -127,6 +263,7 @@ func (c *BalanceController) handleGetUserWithdrawals(w http.ResponseWriter, r *h
 //	@Failure		400
 //	@Failure		401
 //	@Failure		402	string	true	"Not enough balance"
+//	@Failure		409	string	true	"Order reserved by an active hold"
 //	@Failure		422	string	true	"Not correct order number"
 //	@Failure		500
 //	@Router			/api/user/balance/withdraw [post]
@@ -160,6 +297,11 @@ func (c *BalanceController) handleWithdraw(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if err != nil && errors.Is(err, ErrOrderAlreadyUsed) {
+		http.Error(w, "Order already used", http.StatusConflict)
+		return
+	}
+
 	if err != nil {
 		logger.Errorw("error while register withdraw", "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -169,10 +311,367 @@ func (c *BalanceController) handleWithdraw(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 }
 
-func NewController(logger logger.Logger, tokenService auth.TokenService, balanceService BalanceService) *BalanceController {
+// handleGetHolds godoc
+//
+//	@Summary		get holds
+//	@Description	get user balance holds
+//	@Tags			balance
+//
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}	dtos.Hold
+//	@Success		204
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/balance/holds [get]
+func (c *BalanceController) handleGetHolds(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleGetHolds"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	holds, err := c.balanceService.GetHolds(r.Context(), user.ID)
+
+	if err != nil {
+		logger.Errorw("", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(holds) == 0 {
+		http.Error(w, "", http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, holds, logger)
+}
+
+// handleCreateHold godoc
+//
+//	@Summary		create hold
+//	@Description	Reserve points for an order until the hold is captured, voided or expires
+//	@Tags			balance
+//
+//	@Param			body			body	CreateHoldRequestDTO	true	"Hold body"
+//	@Param			Idempotency-Key	header	string					false	"replay the first response for retries with the same key"
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	dtos.Hold
+//	@Failure		400
+//	@Failure		401
+//	@Failure		402	string	true	"Not enough balance"
+//	@Failure		409	string	true	"Order already used"
+//	@Failure		422	string	true	"Not correct order number"
+//	@Failure		500
+//	@Router			/api/user/balance/holds [post]
+func (c *BalanceController) handleCreateHold(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleCreateHold"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	var dto CreateHoldRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !luhn.ValidateString(dto.OrderID) {
+		http.Error(w, "Invalid order number", http.StatusUnprocessableEntity)
+		return
+	}
+
+	hold, err := c.balanceService.CreateHold(r.Context(), user.ID, dto.OrderID, dto.Sum)
+
+	if err != nil {
+		mapHoldErrorToHTTPAnswer(w, err, logger)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, hold, logger)
+}
+
+// handleCaptureHold godoc
+//
+//	@Summary		capture hold
+//	@Description	Withdraw the held points for the hold order
+//	@Tags			balance
+//
+//	@Param			holdID	path	int	true	"Hold ID"
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{object}	dtos.Hold
+//	@Failure		401
+//	@Failure		402	string	true	"Not enough balance"
+//	@Failure		404
+//	@Failure		409	string	true	"Hold is not active"
+//	@Failure		500
+//	@Router			/api/user/balance/holds/{holdID}/capture [post]
+func (c *BalanceController) handleCaptureHold(w http.ResponseWriter, r *http.Request) {
+	c.handleHoldAction(w, r, "balanceController.handleCaptureHold", c.balanceService.CaptureHold)
+}
+
+// handleVoidHold godoc
+//
+//	@Summary		void hold
+//	@Description	Release the held points without withdrawing them
+//	@Tags			balance
+//
+//	@Param			holdID	path	int	true	"Hold ID"
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{object}	dtos.Hold
+//	@Failure		401
+//	@Failure		404
+//	@Failure		409	string	true	"Hold is not active"
+//	@Failure		500
+//	@Router			/api/user/balance/holds/{holdID}/void [post]
+func (c *BalanceController) handleVoidHold(w http.ResponseWriter, r *http.Request) {
+	c.handleHoldAction(w, r, "balanceController.handleVoidHold", c.balanceService.VoidHold)
+}
+
+func (c *BalanceController) handleHoldAction(w http.ResponseWriter, r *http.Request, op string, action func(ctx context.Context, userID int, holdID int64) (dtos.Hold, error)) {
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	holdID, err := strconv.ParseInt(chi.URLParam(r, "holdID"), 10, 64)
+
+	if err != nil {
+		http.Error(w, "Invalid hold id", http.StatusBadRequest)
+		return
+	}
+
+	hold, err := action(r.Context(), user.ID, holdID)
+
+	if err != nil {
+		mapHoldErrorToHTTPAnswer(w, err, logger)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hold, logger)
+}
+
+func mapHoldErrorToHTTPAnswer(w http.ResponseWriter, err error, logger logger.Logger) {
+	switch {
+	case errors.Is(err, ErrInsufficientFunds):
+		http.Error(w, "Not have enough funds", http.StatusPaymentRequired)
+	case errors.Is(err, ErrHoldNotFound):
+		http.Error(w, "Hold not found", http.StatusNotFound)
+	case errors.Is(err, ErrHoldNotActive):
+		http.Error(w, "Hold is already captured, voided or expired", http.StatusConflict)
+	case errors.Is(err, ErrOrderAlreadyUsed):
+		http.Error(w, "Order already used", http.StatusConflict)
+	default:
+		logger.Errorw("error while process hold", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// handleGetTransfers godoc
+//
+//	@Summary		get transfers
+//	@Description	get points transfers sent and received by the user
+//	@Tags			balance
+//
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}	dtos.Transfer
+//	@Success		204
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/balance/transfers [get]
+func (c *BalanceController) handleGetTransfers(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleGetTransfers"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	transfers, err := c.balanceService.GetTransfers(r.Context(), user.ID)
+
+	if err != nil {
+		logger.Errorw("", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(transfers) == 0 {
+		http.Error(w, "", http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transfers, logger)
+}
+
+// handleTransfer godoc
+//
+//	@Summary		transfer points
+//	@Description	Transfer points to another user by login
+//	@Tags			balance
+//
+//	@Param			body			body	TransferRequestDTO	true	"Transfer body"
+//	@Param			Idempotency-Key	header	string				false	"replay the first response for retries with the same key"
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	dtos.Transfer
+//	@Failure		400	string	true	"Transfer to yourself"
+//	@Failure		401
+//	@Failure		402	string	true	"Not enough balance"
+//	@Failure		404	string	true	"Recipient not found"
+//	@Failure		422	string	true	"Daily transfer limit exceeded"
+//	@Failure		500
+//	@Router			/api/user/balance/transfers [post]
+func (c *BalanceController) handleTransfer(w http.ResponseWriter, r *http.Request) {
+	op := "balanceController.handleTransfer"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	var dto TransferRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := c.balanceService.Transfer(r.Context(), user.ID, dto.Recipient, dto.Sum)
+
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusCreated, transfer, logger)
+	case errors.Is(err, ErrSelfTransfer):
+		http.Error(w, "Cannot transfer points to yourself", http.StatusBadRequest)
+	case errors.Is(err, ErrInsufficientFunds):
+		http.Error(w, "Not have enough funds", http.StatusPaymentRequired)
+	case errors.Is(err, ErrRecipientNotFound):
+		http.Error(w, "Recipient not found", http.StatusNotFound)
+	case errors.Is(err, ErrTransferLimitExceeded):
+		http.Error(w, "Daily transfer limit exceeded", http.StatusUnprocessableEntity)
+	default:
+		logger.Errorw("error while transfer points", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func parseTransactionFilter(query url.Values) (dtos.TransactionFilter, error) {
+	var filter dtos.TransactionFilter
+
+	cursor, err := DecodeCursor(query.Get("cursor"))
+
+	if err != nil {
+		return filter, err
+	}
+
+	filter.After = cursor
+
+	for _, param := range []struct {
+		name string
+		dest **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := query.Get(param.name)
+
+		if value == "" {
+			continue
+		}
+
+		t, err := parseTime(value)
+
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", param.name, err)
+		}
+
+		*param.dest = &t
+	}
+
+	for _, value := range query["type"] {
+		for _, transactionType := range strings.Split(value, ",") {
+			if transactionType != "" {
+				filter.Types = append(filter.Types, strings.ToUpper(transactionType))
+			}
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+
+		if err != nil || limit <= 0 || limit > MaxTransactionsLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", MaxTransactionsLimit)
+		}
+
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func parseStatementPeriod(query url.Values) (time.Time, time.Time, error) {
+	if month := query.Get("month"); month != "" {
+		from, err := time.Parse("2006-01", month)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid month: %w", err)
+		}
+
+		return from, from.AddDate(0, 1, 0), nil
+	}
+
+	if query.Get("from") == "" || query.Get("to") == "" {
+		return time.Time{}, time.Time{}, errors.New("month or from and to are required")
+	}
+
+	from, err := parseTime(query.Get("from"))
+
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+	}
+
+	to, err := parseTime(query.Get("to"))
+
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return from, to, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any, logger logger.Logger) {
+	result, err := json.Marshal(value)
+
+	if err != nil {
+		logger.Errorw("error while serialize response", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(result)
+}
+
+func NewController(logger logger.Logger, tokenService auth.TokenService, balanceService BalanceService, idempotencyService idempotency.IdempotencyService) *BalanceController {
 	return &BalanceController{
 		logger,
 		tokenService,
 		balanceService,
+		idempotencyService,
 	}
 }
@@ -0,0 +1,41 @@
+package balance
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor returns an opaque cursor pointing after the transaction.
+func encodeCursor(transaction dtos.Transaction) string {
+	cursor := dtos.TransactionCursor{OccurredAt: transaction.OccurredAt, Type: transaction.Type, Reference: transaction.Reference}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned with a previous page, an empty cursor means the first page.
+func DecodeCursor(value string) (*dtos.TransactionCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor dtos.TransactionCursor
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Type == "" || cursor.OccurredAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
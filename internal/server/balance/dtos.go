@@ -1,6 +1,18 @@
 package balance
 
+import "github.com/sodiqit/gophermart/pkg/points"
+
 type WithdrawRequestDTO struct {
-	Sum     float64 `json:"sum" validate:"required,gt=0"`
-	OrderID string  `json:"order" validate:"required"`
+	Sum     points.Points `json:"sum" validate:"required,gt=0" swaggertype:"number"`
+	OrderID string        `json:"order" validate:"required"`
+}
+
+type CreateHoldRequestDTO struct {
+	Sum     points.Points `json:"sum" validate:"required,gt=0" swaggertype:"number"`
+	OrderID string        `json:"order" validate:"required"`
+}
+
+type TransferRequestDTO struct {
+	Sum       points.Points `json:"sum" validate:"required,gt=0" swaggertype:"number"`
+	Recipient string        `json:"recipient" validate:"required"`
 }
@@ -1,6 +1,7 @@
 package balance
 
 import (
+	"bufio"
 	"context"
 	"errors"
 
@@ -8,7 +9,10 @@ import (
 	proto "github.com/sodiqit/gophermart/gen/proto/balance/v1"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
 	"github.com/sodiqit/gophermart/pkg/luhn"
+	"github.com/sodiqit/gophermart/pkg/points"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -36,8 +40,18 @@ func (s *BalanceServer) GetBalance(ctx context.Context, in *proto.GetBalanceRequ
 	}
 
 	response.Balance = &proto.Balance{
-		Current:   balance.Current,
-		Withdrawn: balance.Withdrawn,
+		Current:        balance.Current.Float64(),
+		Withdrawn:      balance.Withdrawn.Float64(),
+		CurrentMinor:   balance.Current.Minor(),
+		WithdrawnMinor: balance.Withdrawn.Minor(),
+		HeldMinor:      balance.Held.Minor(),
+	}
+
+	for _, expiring := range balance.ExpiringSoon {
+		response.Balance.ExpiringSoon = append(response.Balance.ExpiringSoon, &proto.ExpiringPoints{
+			AmountMinor: expiring.Amount.Minor(),
+			ExpiresAt:   timestamppb.New(expiring.ExpiresAt),
+		})
 	}
 
 	return &response, nil
@@ -61,9 +75,12 @@ func (s *BalanceServer) GetWithdrawals(ctx context.Context, in *proto.GetWithdra
 
 	for _, withdraw := range withdrawals {
 		result = append(result, &proto.Withdrawal{
-			OrderId:     withdraw.OrderID,
-			ProcessedAt: timestamppb.New(withdraw.ProcessedAt),
-			Amount:      withdraw.Amount,
+			OrderId:      withdraw.OrderID,
+			ProcessedAt:  timestamppb.New(withdraw.ProcessedAt),
+			Amount:       withdraw.Amount.Float64(),
+			AmountMinor:  withdraw.Amount.Minor(),
+			Type:         mapWithdrawTypeToProto(withdraw.Type),
+			Counterparty: withdraw.Counterparty,
 		})
 	}
 
@@ -85,12 +102,26 @@ func (s *BalanceServer) Withdraw(ctx context.Context, in *proto.WithdrawRequest)
 		return nil, status.Error(codes.InvalidArgument, "Invalid order id")
 	}
 
-	err := s.balanceService.Withdraw(ctx, user.ID, in.OrderId, in.Sum)
+	sum := points.FromMinor(in.SumMinor)
+
+	if in.SumMinor == 0 {
+		sum = points.FromFloat(in.Sum)
+	}
+
+	if sum <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Sum must be positive")
+	}
+
+	err := s.balanceService.Withdraw(ctx, user.ID, in.OrderId, sum)
 
 	if err != nil && errors.Is(err, ErrInsufficientFunds) {
 		return nil, status.Error(codes.InvalidArgument, "Not enough funds")
 	}
 
+	if err != nil && errors.Is(err, ErrOrderAlreadyUsed) {
+		return nil, status.Error(codes.AlreadyExists, "Order already used")
+	}
+
 	if err != nil {
 		logger.Errorw("failed to withdraw", "error", err)
 		return nil, status.Error(codes.Internal, "Internal server error")
@@ -99,6 +130,326 @@ func (s *BalanceServer) Withdraw(ctx context.Context, in *proto.WithdrawRequest)
 	return &response, nil
 }
 
+func (s *BalanceServer) CreateHold(ctx context.Context, in *proto.CreateHoldRequest) (*proto.CreateHoldResponse, error) {
+	logger := s.logger.With("op", proto.BalanceService_CreateHold_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	if !luhn.ValidateString(in.OrderId) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid order id")
+	}
+
+	hold, err := s.balanceService.CreateHold(ctx, user.ID, in.OrderId, points.FromMinor(in.SumMinor))
+
+	if err != nil {
+		return nil, mapHoldErrorToGRPCStatus(err, logger)
+	}
+
+	return &proto.CreateHoldResponse{Hold: mapHoldToProto(hold)}, nil
+}
+
+func (s *BalanceServer) CaptureHold(ctx context.Context, in *proto.CaptureHoldRequest) (*proto.CaptureHoldResponse, error) {
+	logger := s.logger.With("op", proto.BalanceService_CaptureHold_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	hold, err := s.balanceService.CaptureHold(ctx, user.ID, in.HoldId)
+
+	if err != nil {
+		return nil, mapHoldErrorToGRPCStatus(err, logger)
+	}
+
+	return &proto.CaptureHoldResponse{Hold: mapHoldToProto(hold)}, nil
+}
+
+func (s *BalanceServer) VoidHold(ctx context.Context, in *proto.VoidHoldRequest) (*proto.VoidHoldResponse, error) {
+	logger := s.logger.With("op", proto.BalanceService_VoidHold_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	hold, err := s.balanceService.VoidHold(ctx, user.ID, in.HoldId)
+
+	if err != nil {
+		return nil, mapHoldErrorToGRPCStatus(err, logger)
+	}
+
+	return &proto.VoidHoldResponse{Hold: mapHoldToProto(hold)}, nil
+}
+
+func (s *BalanceServer) GetHolds(ctx context.Context, in *proto.GetHoldsRequest) (*proto.GetHoldsResponse, error) {
+	var response proto.GetHoldsResponse
+
+	logger := s.logger.With("op", proto.BalanceService_GetHolds_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	holds, err := s.balanceService.GetHolds(ctx, user.ID)
+
+	if err != nil {
+		logger.Errorw("failed to get holds", "error", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	for _, hold := range holds {
+		response.Holds = append(response.Holds, mapHoldToProto(hold))
+	}
+
+	return &response, nil
+}
+
+func (s *BalanceServer) Transfer(ctx context.Context, in *proto.TransferRequest) (*proto.TransferResponse, error) {
+	logger := s.logger.With("op", proto.BalanceService_Transfer_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	transfer, err := s.balanceService.Transfer(ctx, user.ID, in.Recipient, points.FromMinor(in.SumMinor))
+
+	switch {
+	case err == nil:
+		return &proto.TransferResponse{Transfer: mapTransferToProto(transfer)}, nil
+	case errors.Is(err, ErrSelfTransfer):
+		return nil, status.Error(codes.InvalidArgument, "Cannot transfer points to yourself")
+	case errors.Is(err, ErrInsufficientFunds):
+		return nil, status.Error(codes.FailedPrecondition, "Not enough funds")
+	case errors.Is(err, ErrRecipientNotFound):
+		return nil, status.Error(codes.NotFound, "Recipient not found")
+	case errors.Is(err, ErrTransferLimitExceeded):
+		return nil, status.Error(codes.ResourceExhausted, "Daily transfer limit exceeded")
+	}
+
+	logger.Errorw("failed to transfer points", "error", err)
+
+	return nil, status.Error(codes.Internal, "Internal server error")
+}
+
+func (s *BalanceServer) GetTransfers(ctx context.Context, in *proto.GetTransfersRequest) (*proto.GetTransfersResponse, error) {
+	var response proto.GetTransfersResponse
+
+	logger := s.logger.With("op", proto.BalanceService_GetTransfers_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	transfers, err := s.balanceService.GetTransfers(ctx, user.ID)
+
+	if err != nil {
+		logger.Errorw("failed to get transfers", "error", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	for _, transfer := range transfers {
+		response.Transfers = append(response.Transfers, mapTransferToProto(transfer))
+	}
+
+	return &response, nil
+}
+
+func (s *BalanceServer) ListTransactions(ctx context.Context, in *proto.ListTransactionsRequest) (*proto.ListTransactionsResponse, error) {
+	logger := s.logger.With("op", proto.BalanceService_ListTransactions_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	cursor, err := DecodeCursor(in.Cursor)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := dtos.TransactionFilter{UserID: user.ID, After: cursor, Limit: in.Limit}
+
+	if in.From != nil {
+		from := in.From.AsTime()
+		filter.From = &from
+	}
+
+	if in.To != nil {
+		to := in.To.AsTime()
+		filter.To = &to
+	}
+
+	for _, transactionType := range in.Types {
+		filter.Types = append(filter.Types, transactionType.String())
+	}
+
+	page, err := s.balanceService.ListTransactions(ctx, filter)
+
+	if err != nil && errors.Is(err, ErrInvalidTransactionType) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		logger.Errorw("failed to list transactions", "error", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	response := proto.ListTransactionsResponse{NextCursor: page.NextCursor}
+
+	for _, transaction := range page.Transactions {
+		response.Transactions = append(response.Transactions, &proto.Transaction{
+			Type:         proto.Transaction_TransactionType(proto.Transaction_TransactionType_value[transaction.Type]),
+			OrderId:      transaction.OrderID,
+			Counterparty: transaction.Counterparty,
+			AmountMinor:  transaction.Amount.Minor(),
+			OccurredAt:   timestamppb.New(transaction.OccurredAt),
+		})
+	}
+
+	return &response, nil
+}
+
+func (s *BalanceServer) ExportStatement(in *proto.ExportStatementRequest, stream proto.BalanceService_ExportStatementServer) error {
+	logger := s.logger.With("op", proto.BalanceService_ExportStatement_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(stream.Context())
+
+	format := StatementFormatCSV
+
+	if in.Format == proto.ExportStatementRequest_JSONL {
+		format = StatementFormatJSONL
+	}
+
+	chunks := bufio.NewWriterSize(statementChunkWriter{stream}, statementChunkSize)
+
+	writer, err := NewStatementWriter(format, chunks)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.balanceService.ExportStatement(stream.Context(), user.ID, in.From.AsTime(), in.To.AsTime(), writer)
+
+	if err == nil {
+		err = chunks.Flush()
+	}
+
+	if err != nil && errors.Is(err, ErrInvalidPeriod) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		logger.Errorw("failed to export statement", "error", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	return nil
+}
+
+// statementChunkSize is the size of the statement chunks sent to the stream.
+const statementChunkSize = 32 * 1024
+
+type statementChunkWriter struct {
+	stream proto.BalanceService_ExportStatementServer
+}
+
+func (w statementChunkWriter) Write(p []byte) (int, error) {
+	// the buffer is reused by bufio.Writer after Write returns, the message must own its data
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	if err := w.stream.Send(&proto.ExportStatementResponse{Data: data}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func mapTransferToProto(transfer dtos.Transfer) *proto.Transfer {
+	direction := proto.Transfer_OUTGOING
+
+	if transfer.Direction == repository.TransferDirectionIncoming {
+		direction = proto.Transfer_INCOMING
+	}
+
+	return &proto.Transfer{
+		Id:           transfer.ID,
+		Direction:    direction,
+		Counterparty: transfer.Counterparty,
+		AmountMinor:  transfer.Amount.Minor(),
+		CreatedAt:    timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+func mapHoldToProto(hold dtos.Hold) *proto.Hold {
+	return &proto.Hold{
+		Id:          hold.ID,
+		OrderId:     hold.OrderID,
+		AmountMinor: hold.Amount.Minor(),
+		Status:      mapHoldStatusToProto(hold.Status),
+		ExpiresAt:   timestamppb.New(hold.ExpiresAt),
+		CreatedAt:   timestamppb.New(hold.CreatedAt),
+	}
+}
+
+func mapHoldStatusToProto(holdStatus string) proto.Hold_HoldStatus {
+	switch holdStatus {
+	case repository.HoldStatusHeld:
+		return proto.Hold_HELD
+	case repository.HoldStatusCaptured:
+		return proto.Hold_CAPTURED
+	case repository.HoldStatusVoided:
+		return proto.Hold_VOIDED
+	case repository.HoldStatusExpired:
+		return proto.Hold_EXPIRED
+	}
+
+	panic("invalid hold status")
+}
+
+func mapHoldErrorToGRPCStatus(err error, logger logger.Logger) error {
+	switch {
+	case errors.Is(err, ErrInsufficientFunds):
+		return status.Error(codes.FailedPrecondition, "Not enough funds")
+	case errors.Is(err, ErrHoldNotFound):
+		return status.Error(codes.NotFound, "Hold not found")
+	case errors.Is(err, ErrHoldNotActive):
+		return status.Error(codes.FailedPrecondition, "Hold is already captured, voided or expired")
+	case errors.Is(err, ErrOrderAlreadyUsed):
+		return status.Error(codes.AlreadyExists, "Order already used")
+	}
+
+	logger.Errorw("failed to process hold", "error", err)
+
+	return status.Error(codes.Internal, "Internal server error")
+}
+
+func mapWithdrawTypeToProto(withdrawType string) proto.Withdrawal_WithdrawalType {
+	switch withdrawType {
+	case repository.WithdrawTypeWithdrawal:
+		return proto.Withdrawal_WITHDRAWAL
+	case repository.WithdrawTypeReversal:
+		return proto.Withdrawal_REVERSAL
+	case repository.WithdrawTypeTransferOut:
+		return proto.Withdrawal_TRANSFER_OUT
+	case repository.WithdrawTypeTransferIn:
+		return proto.Withdrawal_TRANSFER_IN
+	}
+
+	panic("invalid withdraw type")
+}
+
 func NewBalanceServer(logger logger.Logger, balanceService BalanceService) *BalanceServer {
 	v, err := protovalidate.New()
 	if err != nil {
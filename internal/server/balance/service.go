@@ -4,51 +4,504 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
 	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
 )
 
 var ErrInsufficientFunds = errors.New("insufficient funds")
+var ErrHoldNotFound = errors.New("hold not found")
+var ErrHoldNotActive = errors.New("hold is already captured, voided or expired")
+var ErrOrderAlreadyUsed = errors.New("order already used by a withdrawal or a hold")
+var ErrRecipientNotFound = errors.New("recipient not found")
+var ErrSelfTransfer = errors.New("cannot transfer points to yourself")
+var ErrTransferLimitExceeded = errors.New("daily transfer limit exceeded")
+var ErrInvalidTransactionType = errors.New("invalid transaction type")
+
+const (
+	DefaultTransactionsLimit = 50
+	MaxTransactionsLimit     = 100
+)
+
+// orderTransactionTypes are listed by OrderRepository, the other types by BalanceRepository.
+var orderTransactionTypes = []string{repository.TransactionTypeAccrual, repository.TransactionTypeAdjustment, repository.TransactionTypeReversal}
+var balanceTransactionTypes = []string{repository.TransactionTypeWithdrawal, repository.TransactionTypeTransferOut, repository.TransactionTypeTransferIn, repository.TransactionTypeExpiration}
 
 type BalanceService interface {
 	GetTotalBalance(ctx context.Context, userID int) (dtos.Balance, error)
-	Withdraw(ctx context.Context, userID int, orderID string, sum float64) error
+	Withdraw(ctx context.Context, userID int, orderID string, sum points.Points) error
 	GetWithdrawals(ctx context.Context, userID int) ([]dtos.Withdraw, error)
+	CreateHold(ctx context.Context, userID int, orderID string, sum points.Points) (dtos.Hold, error)
+	CaptureHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error)
+	VoidHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error)
+	GetHolds(ctx context.Context, userID int) ([]dtos.Hold, error)
+	Transfer(ctx context.Context, senderID int, recipientLogin string, sum points.Points) (dtos.Transfer, error)
+	GetTransfers(ctx context.Context, userID int) ([]dtos.Transfer, error)
+	ListTransactions(ctx context.Context, filter dtos.TransactionFilter) (dtos.TransactionPage, error)
+	ExportStatement(ctx context.Context, userID int, from time.Time, to time.Time, writer StatementWriter) error
 }
 
 type SimpleBalanceService struct {
 	balanceRepo repository.BalanceRepository
+	orderRepo   repository.OrderRepository
+	ledgerRepo  repository.LedgerRepository
+	outboxRepo  repository.OutboxRepository
+	webhookRepo repository.WebhookRepository
+	transactor  repository.Transactor
+	policy      ledger.ExpiryPolicy
+	holdTTL     time.Duration
+
+	// transferDailyLimit caps the sum a user can send per day, 0 disables the limit
+	transferDailyLimit points.Points
 }
 
 func (s *SimpleBalanceService) GetTotalBalance(ctx context.Context, userID int) (dtos.Balance, error) {
-	return s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+	op := "balanceService.getTotalBalance"
+
+	balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+
+	if err != nil || !s.policy.Enabled() {
+		return balance, err
+	}
+
+	lots, err := s.ledgerRepo.GetActiveLots(ctx, userID)
+
+	if err != nil {
+		return dtos.Balance{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balance.ExpiringSoon = s.policy.ExpiringSoonAmounts(lots, balance.Current, time.Now())
+
+	return balance, nil
 }
 
-func (s *SimpleBalanceService) Withdraw(ctx context.Context, userID int, orderID string, sum float64) error {
+// Withdraw checks the balance, registers the withdraw, posts it to the ledger and notifies about it
+// in one transaction. The user balance is locked before the check, so concurrent withdrawals of the same user are applied one by one.
+func (s *SimpleBalanceService) Withdraw(ctx context.Context, userID int, orderID string, sum points.Points) error {
 	op := "balanceService.withdraw"
 
-	balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.balanceRepo.LockUserBalance(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if balance.Current-sum < 0 {
+			return ErrInsufficientFunds
+		}
+
+		// the order of an active hold is withdrawn by capturing the hold
+		held, err := s.balanceRepo.HasActiveHold(ctx, orderID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if held {
+			return ErrOrderAlreadyUsed
+		}
+
+		_, err = s.balanceRepo.CreateWithdraw(ctx, userID, orderID, sum)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = s.ledgerRepo.Post(ctx, repository.NewWithdrawalPosting(userID, orderID, sum))
+
+		if err != nil {
+			return err
+		}
+
+		return s.notifyWithdrawal(ctx, userID, orderID, sum)
+	})
+}
+
+func (s *SimpleBalanceService) GetWithdrawals(ctx context.Context, userID int) ([]dtos.Withdraw, error) {
+	return s.balanceRepo.GetWithdrawalsByUser(ctx, userID)
+}
+
+// CreateHold reserves sum of the available balance for the order. The hold ends when it is captured,
+// voided or expires after the hold TTL.
+func (s *SimpleBalanceService) CreateHold(ctx context.Context, userID int, orderID string, sum points.Points) (dtos.Hold, error) {
+	op := "balanceService.createHold"
+
+	var hold dtos.Hold
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.balanceRepo.LockUserBalance(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if balance.Current-sum < 0 {
+			return ErrInsufficientFunds
+		}
+
+		hold, err = s.balanceRepo.CreateHold(ctx, userID, orderID, sum, s.holdTTL)
+
+		if errors.Is(err, repository.ErrHoldOrderUsed) {
+			return ErrOrderAlreadyUsed
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+
+	return hold, err
+}
+
+// CaptureHold turns an active hold into a withdrawal of the held sum for the hold order.
+func (s *SimpleBalanceService) CaptureHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	op := "balanceService.captureHold"
+
+	var hold dtos.Hold
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.balanceRepo.LockUserBalance(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		hold, err = s.lockActiveHold(ctx, userID, holdID)
+
+		if err != nil {
+			return err
+		}
+
+		balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		// held points are excluded from Current, a reversal could still have taken them
+		if balance.Current+balance.Held-hold.Amount < 0 {
+			return ErrInsufficientFunds
+		}
+
+		_, err = s.balanceRepo.CreateWithdraw(ctx, userID, hold.OrderID, hold.Amount)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = s.ledgerRepo.Post(ctx, repository.NewWithdrawalPosting(userID, hold.OrderID, hold.Amount))
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := s.notifyWithdrawal(ctx, userID, hold.OrderID, hold.Amount); err != nil {
+			return err
+		}
+
+		hold.Status = repository.HoldStatusCaptured
+
+		return s.balanceRepo.SetHoldStatus(ctx, hold.ID, repository.HoldStatusCaptured)
+	})
+
+	return hold, err
+}
+
+// VoidHold releases an active hold without withdrawing anything.
+func (s *SimpleBalanceService) VoidHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	var hold dtos.Hold
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		hold, err = s.lockActiveHold(ctx, userID, holdID)
+
+		if err != nil {
+			return err
+		}
+
+		hold.Status = repository.HoldStatusVoided
+
+		return s.balanceRepo.SetHoldStatus(ctx, hold.ID, repository.HoldStatusVoided)
+	})
+
+	return hold, err
+}
+
+func (s *SimpleBalanceService) GetHolds(ctx context.Context, userID int) ([]dtos.Hold, error) {
+	return s.balanceRepo.GetHoldsByUser(ctx, userID)
+}
+
+// Transfer moves sum from the sender to the user with recipientLogin in one transaction. Both users are locked
+// in the order of their ids, so concurrent transfers between the same users can not deadlock.
+func (s *SimpleBalanceService) Transfer(ctx context.Context, senderID int, recipientLogin string, sum points.Points) (dtos.Transfer, error) {
+	op := "balanceService.transfer"
+
+	var transfer dtos.Transfer
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		recipientID, err := s.balanceRepo.GetUserIDByLogin(ctx, recipientLogin)
+
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrRecipientNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if recipientID == senderID {
+			return ErrSelfTransfer
+		}
+
+		userIDs := []int{senderID, recipientID}
+
+		if recipientID < senderID {
+			userIDs = []int{recipientID, senderID}
+		}
+
+		for _, userID := range userIDs {
+			if err := s.balanceRepo.LockUserBalance(ctx, userID); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		balance, err := s.balanceRepo.GetBalanceWithWithdrawals(ctx, senderID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if balance.Current-sum < 0 {
+			return ErrInsufficientFunds
+		}
+
+		if s.transferDailyLimit > 0 {
+			transferred, err := s.balanceRepo.GetTransferredToday(ctx, senderID)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			if transferred+sum > s.transferDailyLimit {
+				return ErrTransferLimitExceeded
+			}
+		}
+
+		transfer, err = s.balanceRepo.CreateTransfer(ctx, senderID, recipientID, sum)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		transfer.Counterparty = recipientLogin
+
+		out, in := repository.NewTransferPostings(senderID, recipientID, transfer.ID, sum)
+
+		if err := s.ledgerRepo.PostTransfer(ctx, out, in); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+
+	return transfer, err
+}
+
+func (s *SimpleBalanceService) GetTransfers(ctx context.Context, userID int) ([]dtos.Transfer, error) {
+	return s.balanceRepo.GetTransfersByUser(ctx, userID)
+}
+
+// ListTransactions returns a page of the user transactions newest first. Accruals and withdrawals are kept
+// in different repositories, a page is a merge of the next filter.Limit transactions of each of them.
+func (s *SimpleBalanceService) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) (dtos.TransactionPage, error) {
+	page := dtos.TransactionPage{Transactions: make([]dtos.Transaction, 0)}
+
+	for _, transactionType := range filter.Types {
+		if !contains(orderTransactionTypes, transactionType) && !contains(balanceTransactionTypes, transactionType) {
+			return page, ErrInvalidTransactionType
+		}
+	}
+
+	if len(filter.Types) == 0 {
+		filter.Types = append(append([]string{}, orderTransactionTypes...), balanceTransactionTypes...)
+	}
+
+	limit := filter.Limit
+
+	if limit <= 0 {
+		limit = DefaultTransactionsLimit
+	}
+
+	if limit > MaxTransactionsLimit {
+		limit = MaxTransactionsLimit
+	}
+
+	filter.Limit = limit + 1
+	filter.Ascending = false
+
+	transactions, err := s.mergeTransactions(ctx, filter, orderTransactionTypes)
 
 	if err != nil {
+		return page, err
+	}
+
+	if int64(len(transactions)) > limit {
+		transactions = transactions[:limit]
+		page.NextCursor = encodeCursor(transactions[limit-1])
+	}
+
+	page.Transactions = append(page.Transactions, transactions...)
+
+	return page, nil
+}
+
+// mergeTransactions queries the repositories listing the filter types and merges their results in the filter order.
+// orderTypes are the types listed by OrderRepository.
+func (s *SimpleBalanceService) mergeTransactions(ctx context.Context, filter dtos.TransactionFilter, orderTypes []string) ([]dtos.Transaction, error) {
+	op := "balanceService.mergeTransactions"
+
+	var transactions []dtos.Transaction
+
+	if selectsAny(filter.Types, orderTypes) {
+		orderTransactions, err := s.orderRepo.ListTransactions(ctx, filter)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		transactions = append(transactions, orderTransactions...)
+	}
+
+	if selectsAny(filter.Types, balanceTransactionTypes) {
+		balanceTransactions, err := s.balanceRepo.ListTransactions(ctx, filter)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		transactions = append(transactions, balanceTransactions...)
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if filter.Ascending {
+			return newerTransaction(transactions[j], transactions[i])
+		}
+
+		return newerTransaction(transactions[i], transactions[j])
+	})
+
+	return transactions, nil
+}
+
+// notifyWithdrawal emits OutboxEventWithdrawalCreated and notifies the user webhooks, it must be called
+// in the transaction of the withdrawal.
+func (s *SimpleBalanceService) notifyWithdrawal(ctx context.Context, userID int, orderID string, sum points.Points) error {
+	op := "balanceService.notifyWithdrawal"
+
+	event := dtos.WithdrawalCreatedEvent{
+		OrderID:     orderID,
+		UserID:      userID,
+		Amount:      sum,
+		ProcessedAt: time.Now(),
+	}
+
+	if err := s.outboxRepo.Add(ctx, repository.OutboxEventWithdrawalCreated, strconv.Itoa(userID), event); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if balance.Current-sum < 0 {
-		return ErrInsufficientFunds
+	if err := s.webhookRepo.EnqueueDeliveries(ctx, userID, repository.WebhookEventWithdrawalCreated, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = s.balanceRepo.CreateWithdraw(ctx, userID, orderID, sum)
+	return nil
+}
+
+func (s *SimpleBalanceService) lockActiveHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	op := "balanceService.lockActiveHold"
+
+	hold, err := s.balanceRepo.LockHold(ctx, userID, holdID)
+
+	if errors.Is(err, repository.ErrHoldNotFound) {
+		return dtos.Hold{}, ErrHoldNotFound
+	}
 
-	return err
+	if err != nil {
+		return dtos.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if hold.Status != repository.HoldStatusHeld {
+		return dtos.Hold{}, ErrHoldNotActive
+	}
+
+	return hold, nil
 }
 
-func (s *SimpleBalanceService) GetWithdrawals(ctx context.Context, userID int) ([]dtos.Withdraw, error) {
-	return s.balanceRepo.GetWithdrawalsByUser(ctx, userID)
+// newerTransaction orders transactions the way the repositories do: by time, type and reference, newest first.
+// Type and reference are compared byte-wise.
+func newerTransaction(a dtos.Transaction, b dtos.Transaction) bool {
+	if !a.OccurredAt.Equal(b.OccurredAt) {
+		return a.OccurredAt.After(b.OccurredAt)
+	}
+
+	if a.Type != b.Type {
+		return a.Type > b.Type
+	}
+
+	return a.Reference > b.Reference
+}
+
+// selectsAny reports whether a filter by types selects any of the given types.
+func selectsAny(filter []string, types []string) bool {
+	for _, t := range filter {
+		if contains(types, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
 
-func NewService(balanceRepo repository.BalanceRepository) *SimpleBalanceService {
+func NewService(balanceRepo repository.BalanceRepository, orderRepo repository.OrderRepository, ledgerRepo repository.LedgerRepository, outboxRepo repository.OutboxRepository, webhookRepo repository.WebhookRepository, transactor repository.Transactor, policy ledger.ExpiryPolicy, holdTTL time.Duration, transferDailyLimit points.Points) *SimpleBalanceService {
 	return &SimpleBalanceService{
-		balanceRepo: balanceRepo,
+		balanceRepo:        balanceRepo,
+		orderRepo:          orderRepo,
+		ledgerRepo:         ledgerRepo,
+		outboxRepo:         outboxRepo,
+		webhookRepo:        webhookRepo,
+		transactor:         transactor,
+		policy:             policy,
+		holdTTL:            holdTTL,
+		transferDailyLimit: transferDailyLimit,
 	}
 }
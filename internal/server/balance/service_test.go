@@ -0,0 +1,685 @@
+package balance_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/balance"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBalanceService_withdraw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+	outboxRepoMock := repository.NewMockOutboxRepository(ctrl)
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := balance.NewService(balanceRepoMock, repository.NewMockOrderRepository(ctrl), ledgerRepoMock, outboxRepoMock, webhookRepoMock, transactorMock, ledger.ExpiryPolicy{}, time.Minute, 0)
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		sum           points.Points
+		expectedError error
+		wantErr       bool
+	}{
+		{
+			name: "should return error if lock failed",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(errors.New("lock failed"))
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			sum:     points.FromMinor(1000),
+			wantErr: true,
+		},
+		{
+			name: "should return error if not enough funds",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(500)}, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			sum:           points.FromMinor(1000),
+			wantErr:       true,
+			expectedError: balance.ErrInsufficientFunds,
+		},
+		{
+			name: "should not withdraw order reserved by active hold",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().HasActiveHold(gomock.Any(), "2377225624").Return(true, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			sum:           points.FromMinor(1000),
+			wantErr:       true,
+			expectedError: balance.ErrOrderAlreadyUsed,
+		},
+		{
+			name: "should success withdraw",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().HasActiveHold(gomock.Any(), "2377225624").Return(false, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), 1, "2377225624", points.FromMinor(1000)).Return(1, nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), repository.NewWithdrawalPosting(1, "2377225624", points.FromMinor(1000))).Return(int64(1), nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventWithdrawalCreated, "1", gomock.Any()).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventWithdrawalCreated, gomock.Any()).Return(nil)
+			},
+			sum:     points.FromMinor(1000),
+			wantErr: false,
+		},
+		{
+			name: "should return error if ledger posting failed",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().HasActiveHold(gomock.Any(), "2377225624").Return(false, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), 1, "2377225624", points.FromMinor(1000)).Return(1, nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("post failed"))
+			},
+			sum:     points.FromMinor(1000),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			err := s.Withdraw(context.Background(), 1, "2377225624", tc.sum)
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+			}
+
+			if tc.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBalanceService_holds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+	outboxRepoMock := repository.NewMockOutboxRepository(ctrl)
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := balance.NewService(balanceRepoMock, repository.NewMockOrderRepository(ctrl), ledgerRepoMock, outboxRepoMock, webhookRepoMock, transactorMock, ledger.ExpiryPolicy{}, time.Minute, 0)
+
+	heldHold := dtos.Hold{ID: 5, UserID: 1, OrderID: "2377225624", Amount: points.FromMinor(1000), Status: repository.HoldStatusHeld}
+
+	tests := []struct {
+		name           string
+		setupMock      func()
+		action         func() (dtos.Hold, error)
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name: "should not create hold if not enough funds",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(500), Held: points.FromMinor(700)}, nil)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CreateHold(context.Background(), 1, "2377225624", points.FromMinor(1000))
+			},
+			expectedError: balance.ErrInsufficientFunds,
+		},
+		{
+			name: "should not create hold if order already used",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().CreateHold(gomock.Any(), 1, "2377225624", points.FromMinor(1000), time.Minute).Return(dtos.Hold{}, repository.ErrHoldOrderUsed)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CreateHold(context.Background(), 1, "2377225624", points.FromMinor(1000))
+			},
+			expectedError: balance.ErrOrderAlreadyUsed,
+		},
+		{
+			name: "should create hold",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().CreateHold(gomock.Any(), 1, "2377225624", points.FromMinor(1000), time.Minute).Return(heldHold, nil)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CreateHold(context.Background(), 1, "2377225624", points.FromMinor(1000))
+			},
+			expectedStatus: repository.HoldStatusHeld,
+		},
+		{
+			name: "should create hold for order of expired hold not swept yet",
+			setupMock: func() {
+				// the expired hold is neither subtracted from the balance nor blocks the order
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().CreateHold(gomock.Any(), 1, "2377225624", points.FromMinor(1000), time.Minute).Return(heldHold, nil)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CreateHold(context.Background(), 1, "2377225624", points.FromMinor(1000))
+			},
+			expectedStatus: repository.HoldStatusHeld,
+		},
+		{
+			name: "should capture hold as withdrawal",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().LockHold(gomock.Any(), 1, int64(5)).Return(heldHold, nil)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(0), Held: points.FromMinor(1000)}, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), 1, "2377225624", points.FromMinor(1000)).Return(1, nil)
+				ledgerRepoMock.EXPECT().Post(gomock.Any(), repository.NewWithdrawalPosting(1, "2377225624", points.FromMinor(1000))).Return(int64(1), nil)
+				outboxRepoMock.EXPECT().Add(gomock.Any(), repository.OutboxEventWithdrawalCreated, "1", gomock.Any()).Return(nil)
+				webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventWithdrawalCreated, gomock.Any()).Return(nil)
+				balanceRepoMock.EXPECT().SetHoldStatus(gomock.Any(), int64(5), repository.HoldStatusCaptured).Return(nil)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CaptureHold(context.Background(), 1, 5)
+			},
+			expectedStatus: repository.HoldStatusCaptured,
+		},
+		{
+			name: "should not capture expired hold",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil)
+				balanceRepoMock.EXPECT().LockHold(gomock.Any(), 1, int64(5)).Return(dtos.Hold{ID: 5, UserID: 1, Status: repository.HoldStatusExpired}, nil)
+				balanceRepoMock.EXPECT().CreateWithdraw(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.CaptureHold(context.Background(), 1, 5)
+			},
+			expectedError: balance.ErrHoldNotActive,
+		},
+		{
+			name: "should return error if hold of another user",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockHold(gomock.Any(), 1, int64(6)).Return(dtos.Hold{}, repository.ErrHoldNotFound)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.VoidHold(context.Background(), 1, 6)
+			},
+			expectedError: balance.ErrHoldNotFound,
+		},
+		{
+			name: "should void hold",
+			setupMock: func() {
+				balanceRepoMock.EXPECT().LockHold(gomock.Any(), 1, int64(5)).Return(heldHold, nil)
+				balanceRepoMock.EXPECT().SetHoldStatus(gomock.Any(), int64(5), repository.HoldStatusVoided).Return(nil)
+			},
+			action: func() (dtos.Hold, error) {
+				return s.VoidHold(context.Background(), 1, 5)
+			},
+			expectedStatus: repository.HoldStatusVoided,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			hold, err := tc.action()
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, hold.Status)
+		})
+	}
+}
+
+func TestBalanceService_transfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := balance.NewService(balanceRepoMock, repository.NewMockOrderRepository(ctrl), ledgerRepoMock, repository.NewMockOutboxRepository(ctrl), repository.NewMockWebhookRepository(ctrl), transactorMock, ledger.ExpiryPolicy{}, time.Minute, points.FromMinor(5000))
+
+	tests := []struct {
+		name          string
+		recipient     string
+		sum           points.Points
+		setupMock     func()
+		expectedError error
+	}{
+		{
+			name:      "should return error if recipient not found",
+			recipient: "unknown",
+			sum:       points.FromMinor(1000),
+			setupMock: func() {
+				balanceRepoMock.EXPECT().GetUserIDByLogin(gomock.Any(), "unknown").Return(0, repository.ErrUserNotFound)
+			},
+			expectedError: balance.ErrRecipientNotFound,
+		},
+		{
+			name:      "should not transfer to yourself",
+			recipient: "sender",
+			sum:       points.FromMinor(1000),
+			setupMock: func() {
+				balanceRepoMock.EXPECT().GetUserIDByLogin(gomock.Any(), "sender").Return(2, nil)
+			},
+			expectedError: balance.ErrSelfTransfer,
+		},
+		{
+			name:      "should not transfer if not enough funds",
+			recipient: "recipient",
+			sum:       points.FromMinor(1000),
+			setupMock: func() {
+				balanceRepoMock.EXPECT().GetUserIDByLogin(gomock.Any(), "recipient").Return(1, nil)
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 2).Return(dtos.Balance{UserID: 2, Current: points.FromMinor(500)}, nil)
+			},
+			expectedError: balance.ErrInsufficientFunds,
+		},
+		{
+			name:      "should not transfer over daily limit",
+			recipient: "recipient",
+			sum:       points.FromMinor(1000),
+			setupMock: func() {
+				balanceRepoMock.EXPECT().GetUserIDByLogin(gomock.Any(), "recipient").Return(1, nil)
+				balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 2).Return(dtos.Balance{UserID: 2, Current: points.FromMinor(10000)}, nil)
+				balanceRepoMock.EXPECT().GetTransferredToday(gomock.Any(), 2).Return(points.FromMinor(4500), nil)
+				balanceRepoMock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: balance.ErrTransferLimitExceeded,
+		},
+		{
+			name:      "should lock users in id order and post both sides",
+			recipient: "recipient",
+			sum:       points.FromMinor(1000),
+			setupMock: func() {
+				out, in := repository.NewTransferPostings(2, 1, 7, points.FromMinor(1000))
+
+				gomock.InOrder(
+					balanceRepoMock.EXPECT().GetUserIDByLogin(gomock.Any(), "recipient").Return(1, nil),
+					balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 1).Return(nil),
+					balanceRepoMock.EXPECT().LockUserBalance(gomock.Any(), 2).Return(nil),
+					balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 2).Return(dtos.Balance{UserID: 2, Current: points.FromMinor(10000)}, nil),
+					balanceRepoMock.EXPECT().GetTransferredToday(gomock.Any(), 2).Return(points.FromMinor(4000), nil),
+					balanceRepoMock.EXPECT().CreateTransfer(gomock.Any(), 2, 1, points.FromMinor(1000)).Return(dtos.Transfer{ID: 7, Direction: repository.TransferDirectionOutgoing, Amount: points.FromMinor(1000), UserID: 2}, nil),
+					ledgerRepoMock.EXPECT().PostTransfer(gomock.Any(), out, in).Return(nil),
+				)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			transfer, err := s.Transfer(context.Background(), 2, tc.recipient, tc.sum)
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "recipient", transfer.Counterparty)
+			require.Equal(t, tc.sum, transfer.Amount)
+		})
+	}
+}
+
+func TestBalanceService_listTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+	orderRepoMock := repository.NewMockOrderRepository(ctrl)
+
+	s := balance.NewService(balanceRepoMock, orderRepoMock, repository.NewMockLedgerRepository(ctrl), repository.NewMockOutboxRepository(ctrl), repository.NewMockWebhookRepository(ctrl), repository.NewMockTransactor(ctrl), ledger.ExpiryPolicy{}, time.Minute, 0)
+
+	now := time.Date(2024, 3, 12, 10, 0, 0, 0, time.UTC)
+
+	accrual := dtos.Transaction{Type: repository.TransactionTypeAccrual, Reference: "2377225624", OrderID: "2377225624", Amount: points.FromMinor(50000), OccurredAt: now.Add(-2 * time.Hour)}
+	reversal := dtos.Transaction{Type: repository.TransactionTypeReversal, Reference: "3", OrderID: "2377225624", Amount: points.FromMinor(-1000), OccurredAt: now}
+	withdrawal := dtos.Transaction{Type: repository.TransactionTypeWithdrawal, Reference: "1", OrderID: "49927398716", Amount: points.FromMinor(-2000), OccurredAt: now.Add(-time.Hour)}
+	transferIn := dtos.Transaction{Type: repository.TransactionTypeTransferIn, Reference: "4", Counterparty: "friend", Amount: points.FromMinor(300), OccurredAt: now}
+
+	allTypes := []string{
+		repository.TransactionTypeAccrual, repository.TransactionTypeAdjustment, repository.TransactionTypeReversal,
+		repository.TransactionTypeWithdrawal, repository.TransactionTypeTransferOut, repository.TransactionTypeTransferIn, repository.TransactionTypeExpiration,
+	}
+
+	tests := []struct {
+		name          string
+		filter        dtos.TransactionFilter
+		setupMock     func()
+		expected      []dtos.Transaction
+		hasNextCursor bool
+		expectedError error
+	}{
+		{
+			name:   "should merge transactions of both repositories newest first",
+			filter: dtos.TransactionFilter{UserID: 1, Limit: 2},
+			setupMock: func() {
+				orderRepoMock.EXPECT().ListTransactions(gomock.Any(), dtos.TransactionFilter{UserID: 1, Types: allTypes, Limit: 3}).Return([]dtos.Transaction{reversal, accrual}, nil)
+				balanceRepoMock.EXPECT().ListTransactions(gomock.Any(), dtos.TransactionFilter{UserID: 1, Types: allTypes, Limit: 3}).Return([]dtos.Transaction{transferIn, withdrawal}, nil)
+			},
+			expected:      []dtos.Transaction{transferIn, reversal},
+			hasNextCursor: true,
+		},
+		{
+			name:   "should return last page without cursor",
+			filter: dtos.TransactionFilter{UserID: 1, Limit: 10},
+			setupMock: func() {
+				orderRepoMock.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return([]dtos.Transaction{reversal, accrual}, nil)
+				balanceRepoMock.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return([]dtos.Transaction{transferIn, withdrawal}, nil)
+			},
+			expected: []dtos.Transaction{transferIn, reversal, withdrawal, accrual},
+		},
+		{
+			name:   "should query only repository with selected types",
+			filter: dtos.TransactionFilter{UserID: 1, Types: []string{repository.TransactionTypeWithdrawal}},
+			setupMock: func() {
+				balanceRepoMock.EXPECT().ListTransactions(gomock.Any(), dtos.TransactionFilter{UserID: 1, Types: []string{repository.TransactionTypeWithdrawal}, Limit: balance.DefaultTransactionsLimit + 1}).Return([]dtos.Transaction{withdrawal}, nil)
+			},
+			expected: []dtos.Transaction{withdrawal},
+		},
+		{
+			name:          "should return error for unknown type",
+			filter:        dtos.TransactionFilter{UserID: 1, Types: []string{"BONUS"}},
+			setupMock:     func() {},
+			expectedError: balance.ErrInvalidTransactionType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			page, err := s.ListTransactions(context.Background(), tc.filter)
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, page.Transactions)
+			require.Equal(t, tc.hasNextCursor, page.NextCursor != "")
+
+			if tc.hasNextCursor {
+				cursor, err := balance.DecodeCursor(page.NextCursor)
+
+				require.NoError(t, err)
+				require.Equal(t, &dtos.TransactionCursor{OccurredAt: reversal.OccurredAt, Type: reversal.Type, Reference: reversal.Reference}, cursor)
+			}
+		})
+	}
+}
+
+func TestBalanceService_getTotalBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	s := balance.NewService(balanceRepoMock, repository.NewMockOrderRepository(ctrl), ledgerRepoMock, repository.NewMockOutboxRepository(ctrl), repository.NewMockWebhookRepository(ctrl), transactorMock, ledger.ExpiryPolicy{LifetimeMonths: 12, ExpiringSoon: 30 * 24 * time.Hour}, time.Minute, 0)
+
+	now := time.Now().UTC()
+	soon := now.AddDate(-1, 0, 10)
+
+	balanceRepoMock.EXPECT().GetBalanceWithWithdrawals(gomock.Any(), 1).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(1500)}, nil)
+	ledgerRepoMock.EXPECT().GetActiveLots(gomock.Any(), 1).Return([]dtos.PointLot{
+		{ID: 1, UserID: 1, Remaining: points.FromMinor(1000), EarnedAt: soon},
+		{ID: 2, UserID: 1, Remaining: points.FromMinor(500), EarnedAt: now},
+	}, nil)
+
+	result, err := s.GetTotalBalance(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, result.ExpiringSoon, 1)
+	require.Equal(t, points.FromMinor(1000), result.ExpiringSoon[0].Amount)
+	require.Equal(t, soon.AddDate(1, 0, 0).Truncate(24*time.Hour), result.ExpiringSoon[0].ExpiresAt)
+}
+
+func TestBalanceService_concurrentWithdraw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	webhookRepoMock.EXPECT().EnqueueDeliveries(gomock.Any(), 1, repository.WebhookEventWithdrawalCreated, gomock.Any()).Return(nil).AnyTimes()
+
+	store := newMemoryBalanceStore(points.FromMinor(10000))
+	s := balance.NewService(store, nil, store, store, webhookRepoMock, store, ledger.ExpiryPolicy{}, time.Minute, 0)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := s.Withdraw(context.Background(), 1, "2377225624", points.FromMinor(1000))
+
+			if err != nil {
+				assert.True(t, errors.Is(err, balance.ErrInsufficientFunds))
+				return
+			}
+
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	result, err := s.GetTotalBalance(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Equal(t, 10, succeeded)
+	require.Equal(t, 10, store.events)
+	require.Equal(t, points.FromMinor(0), result.Current)
+	require.Equal(t, points.FromMinor(10000), result.Withdrawn)
+}
+
+type memoryTxKey struct{}
+
+type memoryTx struct {
+	unlock []func()
+}
+
+// memoryBalanceStore emulates row locks of a database: a lock taken by LockUserBalance is held
+// until the transaction it was taken in is finished.
+type memoryBalanceStore struct {
+	mu        sync.Mutex
+	userLock  sync.Mutex
+	accrued   points.Points
+	withdrawn points.Points
+	events    int
+}
+
+func (m *memoryBalanceStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &memoryTx{}
+
+	defer func() {
+		for _, unlock := range tx.unlock {
+			unlock()
+		}
+	}()
+
+	return fn(context.WithValue(ctx, memoryTxKey{}, tx))
+}
+
+func (m *memoryBalanceStore) LockUserBalance(ctx context.Context, userID int) error {
+	tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx)
+
+	if !ok {
+		return errors.New("lock outside of transaction")
+	}
+
+	m.userLock.Lock()
+	tx.unlock = append(tx.unlock, m.userLock.Unlock)
+
+	return nil
+}
+
+func (m *memoryBalanceStore) GetBalanceWithWithdrawals(ctx context.Context, userID int) (dtos.Balance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return dtos.Balance{UserID: userID, Current: m.accrued - m.withdrawn, Withdrawn: m.withdrawn}, nil
+}
+
+func (m *memoryBalanceStore) CreateWithdraw(ctx context.Context, userID int, orderID string, sum points.Points) (int, error) {
+	// widen the window between the balance check and the write
+	time.Sleep(time.Millisecond)
+
+	return 1, nil
+}
+
+func (m *memoryBalanceStore) Post(ctx context.Context, posting dtos.LedgerPosting) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if posting.Type == repository.LedgerTypeWithdrawal {
+		m.withdrawn += posting.Amount
+	}
+
+	return 1, nil
+}
+
+func (m *memoryBalanceStore) PostTransfer(ctx context.Context, out dtos.LedgerPosting, in dtos.LedgerPosting) error {
+	return nil
+}
+
+func (m *memoryBalanceStore) Reconcile(ctx context.Context) ([]dtos.LedgerDiscrepancy, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) GetActiveLots(ctx context.Context, userID int) ([]dtos.PointLot, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) GetLotsEarnedBefore(ctx context.Context, earnedBefore time.Time, afterID int64, limit int64) ([]dtos.PointLot, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) ExpireLot(ctx context.Context, lotID int64) (points.Points, error) {
+	return 0, nil
+}
+
+func (m *memoryBalanceStore) CreateHold(ctx context.Context, userID int, orderID string, amount points.Points, ttl time.Duration) (dtos.Hold, error) {
+	return dtos.Hold{}, nil
+}
+
+func (m *memoryBalanceStore) LockHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	return dtos.Hold{}, nil
+}
+
+func (m *memoryBalanceStore) HasActiveHold(ctx context.Context, orderID string) (bool, error) {
+	return false, nil
+}
+
+func (m *memoryBalanceStore) SetHoldStatus(ctx context.Context, holdID int64, status string) error {
+	return nil
+}
+
+func (m *memoryBalanceStore) GetHoldsByUser(ctx context.Context, userID int) ([]dtos.Hold, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) ExpireHolds(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryBalanceStore) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	return 0, repository.ErrUserNotFound
+}
+
+func (m *memoryBalanceStore) GetTransferredToday(ctx context.Context, userID int) (points.Points, error) {
+	return 0, nil
+}
+
+func (m *memoryBalanceStore) CreateTransfer(ctx context.Context, senderID int, recipientID int, amount points.Points) (dtos.Transfer, error) {
+	return dtos.Transfer{}, nil
+}
+
+func (m *memoryBalanceStore) GetTransfersByUser(ctx context.Context, userID int) ([]dtos.Transfer, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) GetBalanceAt(ctx context.Context, userID int, at time.Time) (dtos.Balance, error) {
+	return dtos.Balance{}, nil
+}
+
+func (m *memoryBalanceStore) GetWithdrawalsByUser(ctx context.Context, userID int) ([]dtos.Withdraw, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) Add(ctx context.Context, eventType string, aggregateID string, payload any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events++
+
+	return nil
+}
+
+func (m *memoryBalanceStore) ClaimPending(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.OutboxEvent, error) {
+	return nil, nil
+}
+
+func (m *memoryBalanceStore) MarkPublished(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *memoryBalanceStore) ScheduleRetry(ctx context.Context, eventID int64, owner string, policy dtos.RetryPolicy, lastError string) error {
+	return nil
+}
+
+func newMemoryBalanceStore(accrued points.Points) *memoryBalanceStore {
+	return &memoryBalanceStore{accrued: accrued}
+}
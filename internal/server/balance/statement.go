@@ -0,0 +1,183 @@
+package balance
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const (
+	StatementFormatCSV   = "csv"
+	StatementFormatJSONL = "jsonl"
+)
+
+const (
+	StatementLineOpeningBalance = "OPENING_BALANCE"
+	StatementLineClosingBalance = "CLOSING_BALANCE"
+)
+
+// statementPageSize is how many transactions of each repository are loaded at once while exporting.
+const statementPageSize = 500
+
+var ErrInvalidPeriod = errors.New("period start must be before its end")
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// statementOrderTypes are the statement types listed by OrderRepository, order uploads are included.
+var statementOrderTypes = append([]string{repository.TransactionTypeOrder}, orderTransactionTypes...)
+
+// StatementWriter writes a statement line by line: the opening balance, the transactions oldest first
+// with the balance after each of them and the closing balance.
+type StatementWriter interface {
+	WriteOpening(at time.Time, balance points.Points) error
+	WriteLine(line dtos.StatementLine) error
+	WriteClosing(at time.Time, balance points.Points) error
+}
+
+// ExportStatement writes the statement of the user for the period [from, to). Transactions are loaded page by page,
+// so the statement is streamed to the writer. Opening and closing balances are computed from the ledger.
+func (s *SimpleBalanceService) ExportStatement(ctx context.Context, userID int, from time.Time, to time.Time, writer StatementWriter) error {
+	op := "balanceService.exportStatement"
+
+	if !from.Before(to) {
+		return ErrInvalidPeriod
+	}
+
+	opening, err := s.balanceRepo.GetBalanceAt(ctx, userID, from)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	closing, err := s.balanceRepo.GetBalanceAt(ctx, userID, to)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := writer.WriteOpening(from, opening.Current); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	filter := dtos.TransactionFilter{
+		UserID:    userID,
+		From:      &from,
+		To:        &to,
+		Types:     append(append([]string{}, statementOrderTypes...), balanceTransactionTypes...),
+		Limit:     statementPageSize + 1,
+		Ascending: true,
+	}
+
+	balance := opening.Current
+
+	for {
+		transactions, err := s.mergeTransactions(ctx, filter, statementOrderTypes)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		more := len(transactions) > statementPageSize
+
+		if more {
+			transactions = transactions[:statementPageSize]
+		}
+
+		for _, transaction := range transactions {
+			balance += transaction.Amount
+
+			if err := writer.WriteLine(dtos.StatementLine{Transaction: transaction, Balance: balance}); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if !more {
+			break
+		}
+
+		last := transactions[len(transactions)-1]
+		filter.After = &dtos.TransactionCursor{OccurredAt: last.OccurredAt, Type: last.Type, Reference: last.Reference}
+	}
+
+	if err := writer.WriteClosing(to, closing.Current); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// NewStatementWriter returns a writer of the statement format to w.
+func NewStatementWriter(format string, w io.Writer) (StatementWriter, error) {
+	switch format {
+	case StatementFormatCSV:
+		return &csvStatementWriter{w: csv.NewWriter(w)}, nil
+	case StatementFormatJSONL:
+		return &jsonlStatementWriter{encoder: json.NewEncoder(w)}, nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// StatementContentType returns the media type of the statement format.
+func StatementContentType(format string) string {
+	if format == StatementFormatJSONL {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
+
+var csvStatementHeader = []string{"occurred_at", "type", "order", "status", "counterparty", "sum", "balance"}
+
+type csvStatementWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvStatementWriter) WriteOpening(at time.Time, balance points.Points) error {
+	if err := c.w.Write(csvStatementHeader); err != nil {
+		return err
+	}
+
+	return c.write(at, StatementLineOpeningBalance, "", "", "", "", balance.String())
+}
+
+func (c *csvStatementWriter) WriteLine(line dtos.StatementLine) error {
+	return c.write(line.OccurredAt, line.Type, line.OrderID, line.Status, line.Counterparty, line.Amount.String(), line.Balance.String())
+}
+
+func (c *csvStatementWriter) WriteClosing(at time.Time, balance points.Points) error {
+	if err := c.write(at, StatementLineClosingBalance, "", "", "", "", balance.String()); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvStatementWriter) write(at time.Time, fields ...string) error {
+	return c.w.Write(append([]string{at.Format(time.RFC3339)}, fields...))
+}
+
+type jsonlStatementWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlStatementWriter) WriteOpening(at time.Time, balance points.Points) error {
+	return j.WriteLine(dtos.StatementLine{Transaction: dtos.Transaction{Type: StatementLineOpeningBalance, OccurredAt: at}, Balance: balance})
+}
+
+func (j *jsonlStatementWriter) WriteLine(line dtos.StatementLine) error {
+	return j.encoder.Encode(line)
+}
+
+func (j *jsonlStatementWriter) WriteClosing(at time.Time, balance points.Points) error {
+	return j.WriteLine(dtos.StatementLine{Transaction: dtos.Transaction{Type: StatementLineClosingBalance, OccurredAt: at}, Balance: balance})
+}
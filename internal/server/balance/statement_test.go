@@ -0,0 +1,108 @@
+package balance_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/balance"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBalanceService_exportStatement(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	order := dtos.Transaction{Type: repository.TransactionTypeOrder, Reference: "2377225624", OrderID: "2377225624", Status: repository.OrderStatusProcessed, OccurredAt: from.Add(time.Hour)}
+	accrual := dtos.Transaction{Type: repository.TransactionTypeAccrual, Reference: "2377225624", OrderID: "2377225624", Status: repository.OrderStatusProcessed, Amount: points.FromMinor(50000), OccurredAt: from.Add(2 * time.Hour)}
+	withdrawal := dtos.Transaction{Type: repository.TransactionTypeWithdrawal, Reference: "1", OrderID: "49927398716", Amount: points.FromMinor(-2050), OccurredAt: from.Add(3 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "should export csv",
+			format: balance.StatementFormatCSV,
+			expected: "occurred_at,type,order,status,counterparty,sum,balance\n" +
+				"2024-03-01T00:00:00Z,OPENING_BALANCE,,,,,100\n" +
+				"2024-03-01T01:00:00Z,ORDER,2377225624,PROCESSED,,0,100\n" +
+				"2024-03-01T02:00:00Z,ACCRUAL,2377225624,PROCESSED,,500,600\n" +
+				"2024-03-01T03:00:00Z,WITHDRAWAL,49927398716,,,-20.5,579.5\n" +
+				"2024-04-01T00:00:00Z,CLOSING_BALANCE,,,,,579.5\n",
+		},
+		{
+			name:   "should export json lines",
+			format: balance.StatementFormatJSONL,
+			expected: `{"type":"OPENING_BALANCE","sum":0,"occurred_at":"2024-03-01T00:00:00Z","balance":100}` + "\n" +
+				`{"type":"ORDER","order":"2377225624","status":"PROCESSED","sum":0,"occurred_at":"2024-03-01T01:00:00Z","balance":100}` + "\n" +
+				`{"type":"ACCRUAL","order":"2377225624","status":"PROCESSED","sum":500,"occurred_at":"2024-03-01T02:00:00Z","balance":600}` + "\n" +
+				`{"type":"WITHDRAWAL","order":"49927398716","sum":-20.5,"occurred_at":"2024-03-01T03:00:00Z","balance":579.5}` + "\n" +
+				`{"type":"CLOSING_BALANCE","sum":0,"occurred_at":"2024-04-01T00:00:00Z","balance":579.5}` + "\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			balanceRepoMock := repository.NewMockBalanceRepository(ctrl)
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+
+			s := balance.NewService(balanceRepoMock, orderRepoMock, repository.NewMockLedgerRepository(ctrl), repository.NewMockOutboxRepository(ctrl), repository.NewMockWebhookRepository(ctrl), repository.NewMockTransactor(ctrl), ledger.ExpiryPolicy{}, time.Minute, 0)
+
+			balanceRepoMock.EXPECT().GetBalanceAt(gomock.Any(), 1, from).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(10000)}, nil)
+			balanceRepoMock.EXPECT().GetBalanceAt(gomock.Any(), 1, to).Return(dtos.Balance{UserID: 1, Current: points.FromMinor(57950)}, nil)
+			orderRepoMock.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+				require.True(t, filter.Ascending)
+				require.Equal(t, from, *filter.From)
+				require.Equal(t, to, *filter.To)
+				require.Contains(t, filter.Types, repository.TransactionTypeOrder)
+
+				return []dtos.Transaction{order, accrual}, nil
+			})
+			balanceRepoMock.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return([]dtos.Transaction{withdrawal}, nil)
+
+			var buf bytes.Buffer
+
+			writer, err := balance.NewStatementWriter(tc.format, &buf)
+
+			require.NoError(t, err)
+
+			err = s.ExportStatement(context.Background(), 1, from, to, writer)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestBalanceService_exportStatementInvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := balance.NewService(repository.NewMockBalanceRepository(ctrl), repository.NewMockOrderRepository(ctrl), repository.NewMockLedgerRepository(ctrl), repository.NewMockOutboxRepository(ctrl), repository.NewMockWebhookRepository(ctrl), repository.NewMockTransactor(ctrl), ledger.ExpiryPolicy{}, time.Minute, 0)
+
+	writer, err := balance.NewStatementWriter(balance.StatementFormatCSV, &bytes.Buffer{})
+
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	err = s.ExportStatement(context.Background(), 1, now, now, writer)
+
+	require.True(t, errors.Is(err, balance.ErrInvalidPeriod))
+
+	_, err = balance.NewStatementWriter("pdf", &bytes.Buffer{})
+
+	require.True(t, errors.Is(err, balance.ErrUnsupportedFormat))
+}
@@ -0,0 +1,53 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+// HoldSweeper periodically marks expired holds. Expired holds are excluded from the held balance
+// and rejected by capture even before they are swept, sweeping keeps their status accurate.
+type HoldSweeper struct {
+	balanceRepo repository.BalanceRepository
+	logger      logger.Logger
+	interval    time.Duration
+}
+
+func (s *HoldSweeper) Run(ctx context.Context) error {
+	if s.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		expired, err := s.balanceRepo.ExpireHolds(ctx)
+
+		if err != nil {
+			s.logger.Errorw("failed to expire holds", "err", err)
+			continue
+		}
+
+		if expired > 0 {
+			s.logger.Infow("holds expired", "count", expired)
+		}
+	}
+}
+
+func NewHoldSweeper(balanceRepo repository.BalanceRepository, logger logger.Logger, interval time.Duration) *HoldSweeper {
+	return &HoldSweeper{
+		balanceRepo: balanceRepo,
+		logger:      logger,
+		interval:    interval,
+	}
+}
@@ -6,18 +6,61 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v10"
+	"github.com/sodiqit/gophermart/pkg/points"
 )
 
+// Config of the server. Secrets are tagged json:"-" to keep them out of the config logged at start.
 type Config struct {
 	Address        string `env:"RUN_ADDRESS"`
 	GRPCAddress    string `env:"GRPC_ADDRESS"`
 	AccrualAddress string `env:"ACCRUAL_SYSTEM_ADDRESS"`
 	LogLevel       string `env:"LOG_LEVEL"`
 	DatabaseDSN    string `env:"DATABASE_URI"`
-	JWTSecretKey   string `env:"JWT_SECRET"`
+	JWTSecretKey   string `env:"JWT_SECRET" json:"-"`
 	JWTTimeExp     time.Duration
 
+	JWTSigningKeyFile       string `env:"JWT_SIGNING_KEY_FILE"`
+	JWTVerificationKeyFiles string `env:"JWT_VERIFICATION_KEY_FILES"`
+
 	JWTTimeExpInMinutes int `env:"JWT_TIME_EXP"`
+
+	LedgerReconcileInterval     time.Duration `env:"LEDGER_RECONCILE_INTERVAL"`
+	IdempotencyKeyTTL           time.Duration `env:"IDEMPOTENCY_KEY_TTL"`
+	AccrualRecheckInterval      time.Duration `env:"ACCRUAL_RECHECK_INTERVAL"`
+	AccrualRecheckWindow        time.Duration `env:"ACCRUAL_RECHECK_WINDOW"`
+	PointsLifetimeMonths        int           `env:"POINTS_LIFETIME_MONTHS"`
+	PointsExpiringSoon          time.Duration `env:"POINTS_EXPIRING_SOON"`
+	PointsExpiryInterval        time.Duration `env:"POINTS_EXPIRY_INTERVAL"`
+	BalanceHoldTTL              time.Duration `env:"BALANCE_HOLD_TTL"`
+	BalanceHoldSweepInterval    time.Duration `env:"BALANCE_HOLD_SWEEP_INTERVAL"`
+	BalanceTransferDailyLimit   points.Points `env:"BALANCE_TRANSFER_DAILY_LIMIT"`
+	AccrualLeaseTTL             time.Duration `env:"ACCRUAL_LEASE_TTL"`
+	AccrualRetryBaseDelay       time.Duration `env:"ACCRUAL_RETRY_BASE_DELAY"`
+	AccrualRetryMaxDelay        time.Duration `env:"ACCRUAL_RETRY_MAX_DELAY"`
+	AccrualMaxOrderAge          time.Duration `env:"ACCRUAL_MAX_ORDER_AGE"`
+	AdminAPIKey                 string        `env:"ADMIN_API_KEY" json:"-"`
+	AccrualBreakerFailures      int           `env:"ACCRUAL_BREAKER_FAILURES"`
+	AccrualBreakerOpenTimeout   time.Duration `env:"ACCRUAL_BREAKER_OPEN_TIMEOUT"`
+	AccrualBreakerSuccesses     int           `env:"ACCRUAL_BREAKER_SUCCESSES"`
+	AccrualWebhookSecret        string        `env:"ACCRUAL_WEBHOOK_SECRET" json:"-"`
+	AccrualPushTimeout          time.Duration `env:"ACCRUAL_PUSH_TIMEOUT"`
+	OutboxPublisher             string        `env:"OUTBOX_PUBLISHER"`
+	OutboxWebhookURL            string        `env:"OUTBOX_WEBHOOK_URL"`
+	OutboxWebhookSecret         string        `env:"OUTBOX_WEBHOOK_SECRET" json:"-"`
+	OutboxFile                  string        `env:"OUTBOX_FILE"`
+	OutboxRelayInterval         time.Duration `env:"OUTBOX_RELAY_INTERVAL"`
+	OutboxRetryBaseDelay        time.Duration `env:"OUTBOX_RETRY_BASE_DELAY"`
+	OutboxRetryMaxDelay         time.Duration `env:"OUTBOX_RETRY_MAX_DELAY"`
+	WebhookDispatchInterval     time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL"`
+	WebhookRetryBaseDelay       time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY"`
+	WebhookRetryMaxDelay        time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY"`
+	WebhookMaxDeliveryAge       time.Duration `env:"WEBHOOK_MAX_DELIVERY_AGE"`
+	WebhookAllowPrivateNetworks bool          `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+	RefreshTokenTTL             time.Duration `env:"REFRESH_TOKEN_TTL"`
+	TokenRevocationCacheTTL     time.Duration `env:"TOKEN_REVOCATION_CACHE_TTL"`
+	PasswordResetNotifier       string        `env:"PASSWORD_RESET_NOTIFIER"`
+	PasswordResetFile           string        `env:"PASSWORD_RESET_FILE"`
+	PasswordResetTokenTTL       time.Duration `env:"PASSWORD_RESET_TOKEN_TTL"`
 }
 
 func ParseConfig() *Config {
@@ -28,7 +71,46 @@ func ParseConfig() *Config {
 	flag.StringVar(&config.DatabaseDSN, "d", "", "database connection string")
 	flag.StringVar(&config.JWTSecretKey, "k", "", "jwt secret key")
 	flag.IntVar(&config.JWTTimeExpInMinutes, "t", 10, "jwt time exp in minutes")
+	flag.StringVar(&config.JWTSigningKeyFile, "jwt-signing-key-file", "", "PEM file with the RSA (RS256) or Ed25519 (EdDSA) private key signing tokens, empty signs them with HS256 and the jwt secret key")
+	flag.StringVar(&config.JWTVerificationKeyFiles, "jwt-verification-key-files", "", "comma separated PEM files with keys retired from signing, tokens signed with them are still verified")
 	flag.StringVar(&config.AccrualAddress, "r", "http://localhost:8080", "accrual address")
+	flag.DurationVar(&config.LedgerReconcileInterval, "ledger-reconcile-interval", time.Hour, "interval between ledger reconciliations, 0 disables them")
+	flag.DurationVar(&config.IdempotencyKeyTTL, "idempotency-key-ttl", 24*time.Hour, "how long responses to requests with Idempotency-Key are replayed")
+	flag.DurationVar(&config.AccrualRecheckInterval, "accrual-recheck-interval", time.Hour, "how often processed orders are re-queried in the accrual system, 0 disables rechecks")
+	flag.DurationVar(&config.AccrualRecheckWindow, "accrual-recheck-window", 30*24*time.Hour, "how long after processing an order accrual can still be changed")
+	flag.IntVar(&config.PointsLifetimeMonths, "points-lifetime-months", 0, "points expire this many months after they were earned, 0 disables expiration")
+	flag.DurationVar(&config.PointsExpiringSoon, "points-expiring-soon", 30*24*time.Hour, "points expiring within this period are reported with the balance")
+	flag.DurationVar(&config.PointsExpiryInterval, "points-expiry-interval", time.Hour, "interval between expiration runs")
+	flag.DurationVar(&config.BalanceHoldTTL, "balance-hold-ttl", 15*time.Minute, "how long a balance hold reserves points before it expires")
+	flag.DurationVar(&config.BalanceHoldSweepInterval, "balance-hold-sweep-interval", time.Minute, "interval between marking expired balance holds")
+	flag.TextVar(&config.BalanceTransferDailyLimit, "balance-transfer-daily-limit", points.FromMinor(1000*points.Scale), "points a user can transfer to other users per day, 0 disables the limit")
+	flag.DurationVar(&config.AccrualLeaseTTL, "accrual-lease-ttl", time.Minute, "how long an order is leased to a processor before another replica can reclaim it")
+	flag.DurationVar(&config.AccrualRetryBaseDelay, "accrual-retry-base-delay", time.Second, "delay before polling an unresolved order again, doubled with every attempt")
+	flag.DurationVar(&config.AccrualRetryMaxDelay, "accrual-retry-max-delay", 10*time.Minute, "max delay between polls of an unresolved order")
+	flag.DurationVar(&config.AccrualMaxOrderAge, "accrual-max-order-age", 72*time.Hour, "orders unresolved for this long are dead-lettered, 0 polls them forever")
+	flag.StringVar(&config.AdminAPIKey, "admin-api-key", "", "key of the admin API passed in X-Admin-Key header, empty disables the admin API")
+	flag.IntVar(&config.AccrualBreakerFailures, "accrual-breaker-failures", 5, "consecutive accrual system failures opening the circuit, 0 disables the circuit breaker")
+	flag.DurationVar(&config.AccrualBreakerOpenTimeout, "accrual-breaker-open-timeout", 30*time.Second, "how long the accrual system circuit stays open before requests are probed")
+	flag.IntVar(&config.AccrualBreakerSuccesses, "accrual-breaker-successes", 2, "successful probes closing the accrual system circuit")
+	flag.StringVar(&config.AccrualWebhookSecret, "accrual-webhook-secret", "", "secret signing order info pushed by the accrual system, empty disables the webhook")
+	flag.DurationVar(&config.AccrualPushTimeout, "accrual-push-timeout", time.Minute, "orders are polled once the accrual system has not pushed them for this long, used with the webhook only")
+	flag.StringVar(&config.OutboxPublisher, "outbox-publisher", "", "where domain events are published: webhook, file or stdout, empty keeps them in the outbox")
+	flag.StringVar(&config.OutboxWebhookURL, "outbox-webhook-url", "", "url domain events are posted to by the webhook publisher")
+	flag.StringVar(&config.OutboxWebhookSecret, "outbox-webhook-secret", "", "secret signing domain events posted by the webhook publisher, empty sends them unsigned")
+	flag.StringVar(&config.OutboxFile, "outbox-file", "events.jsonl", "file domain events are appended to by the file publisher")
+	flag.DurationVar(&config.OutboxRelayInterval, "outbox-relay-interval", time.Second, "interval between publishing pending domain events")
+	flag.DurationVar(&config.OutboxRetryBaseDelay, "outbox-retry-base-delay", time.Second, "delay before publishing a failed domain event again, doubled with every attempt")
+	flag.DurationVar(&config.OutboxRetryMaxDelay, "outbox-retry-max-delay", 10*time.Minute, "max delay between attempts to publish a domain event")
+	flag.DurationVar(&config.WebhookDispatchInterval, "webhook-dispatch-interval", time.Second, "interval between sending pending deliveries to user webhooks, 0 disables user webhooks delivery")
+	flag.DurationVar(&config.WebhookRetryBaseDelay, "webhook-retry-base-delay", 5*time.Second, "delay before sending a failed delivery to a user webhook again, doubled with every attempt")
+	flag.DurationVar(&config.WebhookRetryMaxDelay, "webhook-retry-max-delay", time.Hour, "max delay between attempts to deliver to a user webhook")
+	flag.DurationVar(&config.WebhookMaxDeliveryAge, "webhook-max-delivery-age", 24*time.Hour, "deliveries to user webhooks failing for this long are given up, 0 retries them forever")
+	flag.BoolVar(&config.WebhookAllowPrivateNetworks, "webhook-allow-private-networks", false, "allow user webhooks on loopback, private and link-local addresses, for local runs only")
+	flag.DurationVar(&config.RefreshTokenTTL, "refresh-token-ttl", 30*24*time.Hour, "how long a refresh token can be exchanged for a new access token")
+	flag.DurationVar(&config.TokenRevocationCacheTTL, "token-revocation-cache-ttl", 30*time.Second, "how long token revocation lookups are cached, logouts on other replicas take effect within it")
+	flag.StringVar(&config.PasswordResetNotifier, "password-reset-notifier", "", "how password reset tokens are delivered to users: log or file for local runs, empty disables password reset")
+	flag.StringVar(&config.PasswordResetFile, "password-reset-file", "password_resets.jsonl", "file password reset tokens are appended to by the file notifier")
+	flag.DurationVar(&config.PasswordResetTokenTTL, "password-reset-token-ttl", 30*time.Minute, "how long a password reset token can be used")
 	flag.Parse()
 
 	if err := env.Parse(&config); err != nil {
@@ -1,17 +1,58 @@
 package dtos
 
-import "time"
+import (
+	"time"
 
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+// Balance of the user. Current is the available balance, points reserved by active holds are
+// excluded from it and reported in Held.
 type Balance struct {
-	Current   float64 `json:"current"`
-	Withdrawn float64 `json:"withdrawn"`
-	UserID    int     `json:"-"`
+	Current   points.Points `json:"current" swaggertype:"number"`
+	Withdrawn points.Points `json:"withdrawn" swaggertype:"number"`
+	Held      points.Points `json:"held" swaggertype:"number"`
+	// Points expiring soon grouped by expiration date, present when expiration is enabled
+	ExpiringSoon []ExpiringPoints `json:"expiring_soon,omitempty"`
+	UserID       int              `json:"-"`
 }
 
+// Withdraw is a movement of the user balance: a withdrawal, a reversal of an order accrual or a transfer.
+// Transfers have no order, Counterparty is the login of the other user, empty once the user deleted the account.
+// Amount of transfers is always positive, Type tells whether the points were sent or received.
 type Withdraw struct {
-	ID          int       `json:"-"`
-	OrderID     string    `json:"order"`
-	Amount      float64   `json:"sum"`
-	ProcessedAt time.Time `json:"processed_at"`
-	UserID      int       `json:"-"`
+	ID           int           `json:"-"`
+	OrderID      string        `json:"order,omitempty"`
+	Amount       points.Points `json:"sum" swaggertype:"number"`
+	Type         string        `json:"type"`
+	Counterparty string        `json:"counterparty,omitempty"`
+	ProcessedAt  time.Time     `json:"processed_at"`
+	UserID       int           `json:"-"`
+}
+
+type ExpiringPoints struct {
+	Amount    points.Points `json:"amount" swaggertype:"number"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// Hold reserves points of the user for an order until it is captured as a withdrawal, voided or expires.
+type Hold struct {
+	ID        int64         `json:"id"`
+	OrderID   string        `json:"order"`
+	Amount    points.Points `json:"sum" swaggertype:"number"`
+	Status    string        `json:"status"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	UserID    int           `json:"-"`
+}
+
+// Transfer of points between users as seen by UserID. Direction is OUTGOING for the sender and INCOMING
+// for the recipient, Counterparty is the login of the other user, empty once the user deleted the account.
+type Transfer struct {
+	ID           int64         `json:"id"`
+	Direction    string        `json:"direction"`
+	Counterparty string        `json:"counterparty"`
+	Amount       points.Points `json:"sum" swaggertype:"number"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UserID       int           `json:"-"`
 }
@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+// IdempotencyRecord is the first request made with an idempotency key. Response is nil while that request
+// is still being processed.
+type IdempotencyRecord struct {
+	UserID      int
+	Key         string
+	Fingerprint string
+	ExpiresAt   time.Time
+	Response    *IdempotencyResponse
+}
+
+// IdempotencyResponse is a stored response replayed for retries. StatusCode and ContentType are
+// transport specific: an HTTP status and header, or a gRPC code and the full name of the response message.
+type IdempotencyResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
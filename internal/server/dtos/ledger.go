@@ -0,0 +1,35 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+// LedgerPosting describes a movement of points between a user account and a system account.
+// A credit to the user account increases the user balance, a debit decreases it.
+type LedgerPosting struct {
+	Type           string
+	Reference      string
+	UserID         int
+	Amount         points.Points
+	UserDirection  string
+	CounterAccount string
+}
+
+type LedgerDiscrepancy struct {
+	UserID        int
+	TransactionID int64
+	Expected      points.Points
+	Actual        points.Points
+	Reason        string
+}
+
+// PointLot is a portion of points credited to a user at once. Remaining is consumed by debits oldest first.
+type PointLot struct {
+	ID        int64
+	UserID    int
+	Amount    points.Points
+	Remaining points.Points
+	EarnedAt  time.Time
+}
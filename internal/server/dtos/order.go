@@ -1,14 +1,81 @@
 package dtos
 
-import "time"
+import (
+	"time"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+)
 
 type Order struct {
 	ID     string `json:"number"`
 	UserID int    `json:"-"`
 	// The accrual points for the order, if available
 	// This field is optional in the JSON response
-	Accrual   *float64  `json:"accrual,omitempty"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"uploaded_at"`
-	UpdatedAt time.Time `json:"-"`
+	Accrual   *points.Points `json:"accrual,omitempty" swaggertype:"number"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"uploaded_at"`
+	UpdatedAt time.Time      `json:"-"`
+	// Changes of the accrual made by the accrual system after the order was processed
+	Adjustments []OrderAdjustment `json:"adjustments,omitempty"`
+}
+
+// OrderAdjustment is a change of a processed order accrual. Amount is negative for reversals.
+type OrderAdjustment struct {
+	ID              int64         `json:"-"`
+	OrderID         string        `json:"-"`
+	UserID          int           `json:"-"`
+	PreviousAccrual points.Points `json:"previous_accrual" swaggertype:"number"`
+	Accrual         points.Points `json:"accrual" swaggertype:"number"`
+	Amount          points.Points `json:"amount" swaggertype:"number"`
+	Reason          string        `json:"reason"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// RetryPolicy controls how orders the accrual system has not resolved yet are polled again.
+// The delay doubles with every attempt up to MaxDelay. Orders queued for longer than MaxAge
+// are dead-lettered, zero MaxAge keeps polling them forever.
+type RetryPolicy struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	MaxAge    time.Duration
+}
+
+// DeadLetterOrder is an order that stopped being polled because the accrual system did not resolve it in time.
+type DeadLetterOrder struct {
+	ID             string    `json:"number"`
+	UserID         int       `json:"user_id"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error,omitempty"`
+	CreatedAt      time.Time `json:"uploaded_at"`
+	QueuedAt       time.Time `json:"queued_at"`
+	DeadLetteredAt time.Time `json:"dead_lettered_at"`
+}
+
+// OrderStatusChange is a transition of the order status. FromStatus is empty for the upload. Seq numbers
+// transitions of all orders of the user in commit order, streams of the user orders resume from it.
+type OrderStatusChange struct {
+	ID         int64          `json:"-"`
+	Seq        int64          `json:"-"`
+	OrderID    string         `json:"-"`
+	FromStatus string         `json:"from,omitempty"`
+	Status     string         `json:"status"`
+	Accrual    *points.Points `json:"accrual,omitempty" swaggertype:"number"`
+	Source     string         `json:"source"`
+	CreatedAt  time.Time      `json:"changed_at"`
+}
+
+// OrderStatusEvent is a status transition of a user order sent to order streams.
+type OrderStatusEvent struct {
+	OrderID    string         `json:"number"`
+	FromStatus string         `json:"from,omitempty"`
+	Status     string         `json:"status"`
+	Accrual    *points.Points `json:"accrual,omitempty" swaggertype:"number"`
+	ChangedAt  time.Time      `json:"changed_at"`
+}
+
+// OrderDetails is the order with the timeline of its status transitions, oldest first.
+type OrderDetails struct {
+	Order
+	History []OrderStatusChange `json:"history"`
 }
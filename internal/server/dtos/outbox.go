@@ -0,0 +1,48 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+// OutboxEvent is a domain event waiting in the outbox to be published. It is published as is,
+// consumers deduplicate events by ID since an event can be delivered more than once.
+type OutboxEvent struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"occurred_at"`
+	Attempts    int             `json:"-"`
+}
+
+// OrderProcessedEvent is the payload of the event emitted when the accrual system processes an order.
+type OrderProcessedEvent struct {
+	OrderID     string         `json:"number"`
+	UserID      int            `json:"user_id"`
+	Accrual     *points.Points `json:"accrual,omitempty"`
+	ProcessedAt time.Time      `json:"processed_at"`
+}
+
+// OrderAccrualAdjustedEvent is the payload of the event emitted when a recheck changes the accrual of a processed
+// order or revokes it. Status is INVALID for revoked orders, Amount is the change of the user balance.
+type OrderAccrualAdjustedEvent struct {
+	OrderID         string        `json:"number"`
+	UserID          int           `json:"user_id"`
+	Status          string        `json:"status"`
+	PreviousAccrual points.Points `json:"previous_accrual"`
+	Accrual         points.Points `json:"accrual"`
+	Amount          points.Points `json:"amount"`
+	Reason          string        `json:"reason"`
+	AdjustedAt      time.Time     `json:"adjusted_at"`
+}
+
+// WithdrawalCreatedEvent is the payload of the event emitted when a user withdraws points for an order.
+type WithdrawalCreatedEvent struct {
+	OrderID     string        `json:"order"`
+	UserID      int           `json:"user_id"`
+	Amount      points.Points `json:"sum"`
+	ProcessedAt time.Time     `json:"processed_at"`
+}
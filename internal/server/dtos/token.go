@@ -0,0 +1,61 @@
+package dtos
+
+import "time"
+
+// RefreshToken is a refresh token issued to the user. Only the hash of the token is stored.
+// Tokens issued by refreshing each other share the family.
+type RefreshToken struct {
+	ID        int64
+	UserID    int
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	Expired   bool
+}
+
+// TokenPair is a short-lived access token and the refresh token to renew it.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// JSONWebKey is a public key tokens are verified with, as defined by RFC 7517.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 curve and public key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// PasswordResetToken is a single-use token resetting the password of the user. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        int64
+	UserID    int
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	Expired   bool
+}
+
+// PasswordReset is sent to the user who requested a password reset.
+type PasswordReset struct {
+	UserID    int       `json:"user_id"`
+	Login     string    `json:"login"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+// Transaction is a movement of the user balance. Amount is positive for credits and negative for debits.
+// Status is the current status of the order for order uploads and accruals.
+type Transaction struct {
+	Type         string        `json:"type"`
+	OrderID      string        `json:"order,omitempty"`
+	Counterparty string        `json:"counterparty,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	Amount       points.Points `json:"sum" swaggertype:"number"`
+	OccurredAt   time.Time     `json:"occurred_at"`
+	// Reference identifies the transaction among transactions of the same type
+	Reference string `json:"-"`
+}
+
+// TransactionCursor is the position of the last transaction of a page. Transactions are ordered
+// by OccurredAt, Type and Reference, newest first.
+type TransactionCursor struct {
+	OccurredAt time.Time `json:"t"`
+	Type       string    `json:"k"`
+	Reference  string    `json:"r"`
+}
+
+// TransactionFilter selects transactions of the user. From is inclusive, To is exclusive,
+// empty Types selects all types. Transactions are returned newest first unless Ascending is set.
+type TransactionFilter struct {
+	UserID    int
+	From      *time.Time
+	To        *time.Time
+	Types     []string
+	After     *TransactionCursor
+	Limit     int64
+	Ascending bool
+}
+
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+// StatementLine is a transaction of a statement with the balance after it.
+type StatementLine struct {
+	Transaction
+	Balance points.Points `json:"balance" swaggertype:"number"`
+}
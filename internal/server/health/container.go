@@ -0,0 +1,18 @@
+package health
+
+import (
+	"github.com/sodiqit/gophermart/internal/logger"
+)
+
+type HealthContainer struct {
+	Controller *HealthController
+}
+
+func NewContainer(logger logger.Logger, db Pinger, accrualBreaker AccrualBreaker, accrualRateLimiter AccrualRateLimiter) *HealthContainer {
+	healthService := NewSimpleHealthService(db, accrualBreaker, accrualRateLimiter)
+	healthController := NewController(logger, healthService)
+
+	return &HealthContainer{
+		Controller: healthController,
+	}
+}
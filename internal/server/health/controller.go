@@ -0,0 +1,64 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sodiqit/gophermart/internal/logger"
+)
+
+type HealthController struct {
+	logger        logger.Logger
+	healthService HealthService
+}
+
+func (c *HealthController) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get("/", c.handleHealth)
+
+	return r
+}
+
+// handleHealth godoc
+//
+//	@Summary		health check
+//	@Description	status is degraded while the accrual system circuit is not closed and down without the database
+//	@Tags			health
+//
+//	@Produce		json
+//	@Success		200	{object}	health.Report
+//	@Failure		503	{object}	health.Report
+//	@Router			/health [get]
+func (c *HealthController) handleHealth(w http.ResponseWriter, r *http.Request) {
+	op := "healthController.handleHealth"
+
+	logger := c.logger.With("op", op)
+
+	report := c.healthService.Check(r.Context())
+
+	result, err := json.Marshal(report)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	if report.Status == StatusDown {
+		logger.Warnw("health check failed", "report", report)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(result)
+}
+
+func NewController(logger logger.Logger, healthService HealthService) *HealthController {
+	return &HealthController{
+		logger,
+		healthService,
+	}
+}
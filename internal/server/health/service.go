@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// pingTimeout bounds the database check, so a hanging database fails the health check instead of blocking it.
+const pingTimeout = 2 * time.Second
+
+// Report is the state of the service and its dependencies. The service is degraded while the accrual system
+// circuit is not closed: orders are accepted but not processed. It is down without the database.
+type Report struct {
+	Status   string        `json:"status"`
+	Database string        `json:"database"`
+	Accrual  AccrualReport `json:"accrual"`
+}
+
+type AccrualReport struct {
+	Circuit   accrual.CircuitBreakerState `json:"circuit"`
+	RateLimit accrual.RateLimitState      `json:"rate_limit"`
+}
+
+type HealthService interface {
+	Check(ctx context.Context) Report
+}
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type AccrualBreaker interface {
+	State() accrual.CircuitBreakerState
+}
+
+type AccrualRateLimiter interface {
+	RateLimitState() accrual.RateLimitState
+}
+
+type SimpleHealthService struct {
+	db                 Pinger
+	accrualBreaker     AccrualBreaker
+	accrualRateLimiter AccrualRateLimiter
+}
+
+func (s *SimpleHealthService) Check(ctx context.Context) Report {
+	report := Report{
+		Status:   StatusOK,
+		Database: StatusOK,
+		Accrual: AccrualReport{
+			Circuit:   s.accrualBreaker.State(),
+			RateLimit: s.accrualRateLimiter.RateLimitState(),
+		},
+	}
+
+	if report.Accrual.Circuit.State != accrual.BreakerStateClosed {
+		report.Status = StatusDegraded
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	if err := s.db.PingContext(ctx); err != nil {
+		report.Status = StatusDown
+		report.Database = StatusDown
+	}
+
+	return report
+}
+
+func NewSimpleHealthService(db Pinger, accrualBreaker AccrualBreaker, accrualRateLimiter AccrualRateLimiter) *SimpleHealthService {
+	return &SimpleHealthService{
+		db:                 db,
+		accrualBreaker:     accrualBreaker,
+		accrualRateLimiter: accrualRateLimiter,
+	}
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/health/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/health/service.go -destination=./internal/server/health/service_mock.go -package=health
+//
+
+// Package health is a generated GoMock package.
+package health
+
+import (
+	context "context"
+	reflect "reflect"
+
+	accrual "github.com/sodiqit/gophermart/internal/server/accrual"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthService is a mock of HealthService interface.
+type MockHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthServiceMockRecorder
+}
+
+// MockHealthServiceMockRecorder is the mock recorder for MockHealthService.
+type MockHealthServiceMockRecorder struct {
+	mock *MockHealthService
+}
+
+// NewMockHealthService creates a new mock instance.
+func NewMockHealthService(ctrl *gomock.Controller) *MockHealthService {
+	mock := &MockHealthService{ctrl: ctrl}
+	mock.recorder = &MockHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthService) EXPECT() *MockHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockHealthService) Check(ctx context.Context) Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(Report)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockHealthServiceMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockHealthService)(nil).Check), ctx)
+}
+
+// MockPinger is a mock of Pinger interface.
+type MockPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockPingerMockRecorder
+}
+
+// MockPingerMockRecorder is the mock recorder for MockPinger.
+type MockPingerMockRecorder struct {
+	mock *MockPinger
+}
+
+// NewMockPinger creates a new mock instance.
+func NewMockPinger(ctrl *gomock.Controller) *MockPinger {
+	mock := &MockPinger{ctrl: ctrl}
+	mock.recorder = &MockPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPinger) EXPECT() *MockPingerMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockPinger) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockPingerMockRecorder) PingContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockPinger)(nil).PingContext), ctx)
+}
+
+// MockAccrualBreaker is a mock of AccrualBreaker interface.
+type MockAccrualBreaker struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccrualBreakerMockRecorder
+}
+
+// MockAccrualBreakerMockRecorder is the mock recorder for MockAccrualBreaker.
+type MockAccrualBreakerMockRecorder struct {
+	mock *MockAccrualBreaker
+}
+
+// NewMockAccrualBreaker creates a new mock instance.
+func NewMockAccrualBreaker(ctrl *gomock.Controller) *MockAccrualBreaker {
+	mock := &MockAccrualBreaker{ctrl: ctrl}
+	mock.recorder = &MockAccrualBreakerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccrualBreaker) EXPECT() *MockAccrualBreakerMockRecorder {
+	return m.recorder
+}
+
+// State mocks base method.
+func (m *MockAccrualBreaker) State() accrual.CircuitBreakerState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].(accrual.CircuitBreakerState)
+	return ret0
+}
+
+// State indicates an expected call of State.
+func (mr *MockAccrualBreakerMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockAccrualBreaker)(nil).State))
+}
+
+// MockAccrualRateLimiter is a mock of AccrualRateLimiter interface.
+type MockAccrualRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccrualRateLimiterMockRecorder
+}
+
+// MockAccrualRateLimiterMockRecorder is the mock recorder for MockAccrualRateLimiter.
+type MockAccrualRateLimiterMockRecorder struct {
+	mock *MockAccrualRateLimiter
+}
+
+// NewMockAccrualRateLimiter creates a new mock instance.
+func NewMockAccrualRateLimiter(ctrl *gomock.Controller) *MockAccrualRateLimiter {
+	mock := &MockAccrualRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockAccrualRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccrualRateLimiter) EXPECT() *MockAccrualRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// RateLimitState mocks base method.
+func (m *MockAccrualRateLimiter) RateLimitState() accrual.RateLimitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitState")
+	ret0, _ := ret[0].(accrual.RateLimitState)
+	return ret0
+}
+
+// RateLimitState indicates an expected call of RateLimitState.
+func (mr *MockAccrualRateLimiterMockRecorder) RateLimitState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitState", reflect.TypeOf((*MockAccrualRateLimiter)(nil).RateLimitState))
+}
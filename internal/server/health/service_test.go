@@ -0,0 +1,76 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/health"
+	"github.com/stretchr/testify/require"
+)
+
+type pingerStub struct {
+	err error
+}
+
+func (p pingerStub) PingContext(ctx context.Context) error {
+	return p.err
+}
+
+type breakerStub struct {
+	state accrual.CircuitBreakerState
+}
+
+func (b breakerStub) State() accrual.CircuitBreakerState {
+	return b.state
+}
+
+type rateLimiterStub struct{}
+
+func (rateLimiterStub) RateLimitState() accrual.RateLimitState {
+	return accrual.RateLimitState{Limit: 10}
+}
+
+func TestHealthService_check(t *testing.T) {
+	tests := []struct {
+		name             string
+		pingErr          error
+		circuit          string
+		expectedStatus   string
+		expectedDatabase string
+	}{
+		{
+			name:             "should be ok",
+			circuit:          accrual.BreakerStateClosed,
+			expectedStatus:   health.StatusOK,
+			expectedDatabase: health.StatusOK,
+		},
+		{
+			name:             "should be degraded while circuit is open",
+			circuit:          accrual.BreakerStateOpen,
+			expectedStatus:   health.StatusDegraded,
+			expectedDatabase: health.StatusOK,
+		},
+		{
+			name:             "should be down without database",
+			pingErr:          errors.New("connection refused"),
+			circuit:          accrual.BreakerStateHalfOpen,
+			expectedStatus:   health.StatusDown,
+			expectedDatabase: health.StatusDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := health.NewSimpleHealthService(pingerStub{err: tc.pingErr}, breakerStub{state: accrual.CircuitBreakerState{State: tc.circuit}}, rateLimiterStub{})
+
+			report := s.Check(context.Background())
+
+			require.Equal(t, tc.expectedStatus, report.Status)
+			require.Equal(t, tc.expectedDatabase, report.Database)
+			require.Equal(t, tc.circuit, report.Accrual.Circuit.State)
+			require.Equal(t, 10, report.Accrual.RateLimit.Limit)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package idempotency
+
+import (
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+type IdempotencyContainer struct {
+	Service *SimpleIdempotencyService
+}
+
+func NewContainer(config *config.Config, logger logger.Logger, idempotencyRepo repository.IdempotencyRepository) *IdempotencyContainer {
+	service := NewService(idempotencyRepo, logger, config.IdempotencyKeyTTL)
+
+	return &IdempotencyContainer{
+		Service: service,
+	}
+}
@@ -0,0 +1,165 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	MetadataKey         = "idempotency-key"
+	ReplayedMetadataKey = "idempotent-replayed"
+)
+
+// UnaryIdempotencyInterceptor is the gRPC counterpart of Middleware for the given methods. It must be chained
+// after auth.UnaryAuthInterceptor. Successful responses and client errors are stored, server errors release the key.
+func UnaryIdempotencyInterceptor(service IdempotencyService, logger logger.Logger, methods []string) grpc.UnaryServerInterceptor {
+	methodsMap := make(map[string]bool)
+	for _, method := range methods {
+		methodsMap[method] = true
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !methodsMap[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		values := md.Get(MetadataKey)
+		if len(values) == 0 || values[0] == "" {
+			return handler(ctx, req)
+		}
+
+		key := values[0]
+
+		if len(key) > maxKeyLength {
+			return nil, status.Error(codes.InvalidArgument, "Idempotency key is too long")
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid request")
+		}
+
+		user := auth.ExtractUserFromContext(ctx)
+
+		stored, err := service.Begin(ctx, user.ID, key, fingerprint([]byte(info.FullMethod), body))
+
+		if errors.Is(err, ErrRequestInProgress) {
+			return nil, status.Error(codes.Aborted, "Request with this idempotency key is in progress")
+		}
+
+		if errors.Is(err, ErrKeyReused) {
+			return nil, status.Error(codes.InvalidArgument, "Idempotency key was used for a different request")
+		}
+
+		if err != nil {
+			logger.Errorw("failed to begin idempotent request", "err", err)
+			return nil, status.Error(codes.Internal, "Internal server error")
+		}
+
+		if stored != nil {
+			grpc.SetHeader(ctx, metadata.Pairs(ReplayedMetadataKey, "true"))
+			return replay(stored)
+		}
+
+		handled := false
+
+		defer func() {
+			if handled {
+				return
+			}
+
+			storeCtx, cancel := storeContext()
+			defer cancel()
+
+			if err := service.Release(storeCtx, user.ID, key); err != nil {
+				logger.Errorw("failed to release idempotency key", "err", err)
+			}
+		}()
+
+		resp, handlerErr := handler(ctx, req)
+
+		st := status.Convert(handlerErr)
+
+		if isServerError(st.Code()) {
+			return resp, handlerErr
+		}
+
+		handled = true
+
+		response := dtos.IdempotencyResponse{StatusCode: int(st.Code()), Body: []byte(st.Message())}
+
+		if handlerErr == nil {
+			if message, ok := resp.(proto.Message); ok {
+				body, err := proto.Marshal(message)
+
+				if err != nil {
+					logger.Errorw("failed to store idempotent response", "err", err)
+					return resp, handlerErr
+				}
+
+				response.ContentType = string(message.ProtoReflect().Descriptor().FullName())
+				response.Body = body
+			}
+		}
+
+		storeCtx, cancel := storeContext()
+		defer cancel()
+
+		if err := service.Complete(storeCtx, user.ID, key, response); err != nil {
+			logger.Errorw("failed to store idempotent response", "err", err)
+		}
+
+		return resp, handlerErr
+	}
+}
+
+func replay(stored *dtos.IdempotencyResponse) (any, error) {
+	code := codes.Code(stored.StatusCode)
+
+	if code != codes.OK {
+		return nil, status.Error(code, string(stored.Body))
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(stored.ContentType))
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	message := messageType.New().Interface()
+
+	if err := proto.Unmarshal(stored.Body, message); err != nil {
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return message, nil
+}
+
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted, codes.Aborted,
+		codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+
+	return false
+}
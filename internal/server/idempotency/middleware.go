@@ -0,0 +1,150 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+const (
+	HeaderKey         = "Idempotency-Key"
+	ReplayedHeaderKey = "Idempotent-Replayed"
+	maxKeyLength      = 255
+	storeTimeout      = 5 * time.Second
+)
+
+// Middleware replays the stored response when a request is retried with the same Idempotency-Key.
+// Keys are scoped to the authenticated user, so it must be mounted after auth.JWTAuth.
+// Requests without the header are passed through. Responses with a 5xx status are not stored and release
+// the key, so the client can retry.
+func Middleware(service IdempotencyService, logger logger.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			user := auth.ExtractUserFromContext(r.Context())
+
+			response, err := service.Begin(r.Context(), user.ID, key, fingerprint([]byte(r.Method), []byte(r.URL.Path), body))
+
+			if errors.Is(err, ErrRequestInProgress) {
+				http.Error(w, "Request with this Idempotency-Key is in progress", http.StatusConflict)
+				return
+			}
+
+			if errors.Is(err, ErrKeyReused) {
+				http.Error(w, "Idempotency-Key was used for a different request", http.StatusUnprocessableEntity)
+				return
+			}
+
+			if err != nil {
+				logger.Errorw("failed to begin idempotent request", "err", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+
+			if response != nil {
+				if response.ContentType != "" {
+					w.Header().Set("Content-Type", response.ContentType)
+				}
+				w.Header().Set(ReplayedHeaderKey, "true")
+				w.WriteHeader(response.StatusCode)
+				w.Write(response.Body)
+				return
+			}
+
+			handled := false
+
+			defer func() {
+				if handled {
+					return
+				}
+
+				ctx, cancel := storeContext()
+				defer cancel()
+
+				if err := service.Release(ctx, user.ID, key); err != nil {
+					logger.Errorw("failed to release idempotency key", "err", err)
+				}
+			}()
+
+			var buf bytes.Buffer
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			handled = true
+
+			ctx, cancel := storeContext()
+			defer cancel()
+
+			err = service.Complete(ctx, user.ID, key, dtos.IdempotencyResponse{
+				StatusCode:  status,
+				ContentType: ww.Header().Get("Content-Type"),
+				Body:        buf.Bytes(),
+			})
+
+			if err != nil {
+				logger.Errorw("failed to store idempotent response", "err", err)
+			}
+		})
+	}
+}
+
+// storeContext returns the context the outcome of a request is stored with. It is detached from the request,
+// a client disconnecting after the handler ran must not leave the key in progress or lose the response.
+func storeContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), storeTimeout)
+}
+
+// fingerprint identifies a request, a key reused for a request with another fingerprint is rejected.
+func fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+
+	for _, part := range parts {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
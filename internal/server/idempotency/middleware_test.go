@@ -0,0 +1,223 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	idempotencyServiceMock := idempotency.NewMockIdempotencyService(ctrl)
+
+	handlerStatus := http.StatusCreated
+	handlerCalls := 0
+
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), auth.ClaimsContextKey, &auth.Claims{TokenUser: auth.TokenUser{ID: 1}})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Use(idempotency.Middleware(idempotencyServiceMock, logger.New("info")))
+
+	r.Post("/test/", func(w http.ResponseWriter, r *http.Request) {
+		handlerCalls++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(handlerStatus)
+		w.Write([]byte("created"))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	tests := []struct {
+		name                 string
+		key                  string
+		handlerStatus        int
+		setupMock            func()
+		expectedStatus       int
+		expectedResult       string
+		expectedHandlerCalls int
+		expectedReplayed     bool
+	}{
+		{
+			name:                 "should pass request without key",
+			key:                  "",
+			handlerStatus:        http.StatusCreated,
+			setupMock:            func() {},
+			expectedStatus:       http.StatusCreated,
+			expectedResult:       "created",
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:          "should store first response",
+			key:           "key-1",
+			handlerStatus: http.StatusCreated,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(nil, nil)
+				idempotencyServiceMock.EXPECT().Complete(gomock.Any(), 1, "key-1", dtos.IdempotencyResponse{
+					StatusCode:  http.StatusCreated,
+					ContentType: "text/plain",
+					Body:        []byte("created"),
+				}).Return(nil)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedResult:       "created",
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:          "should replay stored response",
+			key:           "key-1",
+			handlerStatus: http.StatusCreated,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(&dtos.IdempotencyResponse{
+					StatusCode:  http.StatusAccepted,
+					ContentType: "text/plain",
+					Body:        []byte("stored"),
+				}, nil)
+			},
+			expectedStatus:       http.StatusAccepted,
+			expectedResult:       "stored",
+			expectedHandlerCalls: 0,
+			expectedReplayed:     true,
+		},
+		{
+			name:          "should return 409 if first request in progress",
+			key:           "key-1",
+			handlerStatus: http.StatusCreated,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(nil, idempotency.ErrRequestInProgress)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedHandlerCalls: 0,
+		},
+		{
+			name:          "should return 422 if key used for another request",
+			key:           "key-1",
+			handlerStatus: http.StatusCreated,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(nil, idempotency.ErrKeyReused)
+			},
+			expectedStatus:       http.StatusUnprocessableEntity,
+			expectedHandlerCalls: 0,
+		},
+		{
+			name:          "should release key if handler failed",
+			key:           "key-2",
+			handlerStatus: http.StatusInternalServerError,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-2", gomock.Any()).Return(nil, nil)
+				idempotencyServiceMock.EXPECT().Release(gomock.Any(), 1, "key-2").Return(nil)
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedResult:       "created",
+			expectedHandlerCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+			handlerStatus = tc.handlerStatus
+			handlerCalls = 0
+
+			req := client.R().SetBody("12345678903")
+
+			if tc.key != "" {
+				req.SetHeader(idempotency.HeaderKey, tc.key)
+			}
+
+			resp, err := req.Post("/test/")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+			assert.Equal(t, tc.expectedHandlerCalls, handlerCalls)
+			assert.Equal(t, tc.expectedReplayed, resp.Header().Get(idempotency.ReplayedHeaderKey) == "true")
+
+			if tc.expectedResult != "" {
+				assert.Equal(t, tc.expectedResult, resp.String())
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_requestCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idempotencyServiceMock := idempotency.NewMockIdempotencyService(ctrl)
+
+	notCanceled := func(ctx context.Context) error {
+		assert.NoError(t, ctx.Err())
+		return nil
+	}
+
+	tests := []struct {
+		name          string
+		handlerStatus int
+		setupMock     func()
+	}{
+		{
+			name:          "should store response after request canceled",
+			handlerStatus: http.StatusCreated,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(nil, nil)
+				idempotencyServiceMock.EXPECT().Complete(gomock.Any(), 1, "key-1", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ int, _ string, _ dtos.IdempotencyResponse) error {
+						return notCanceled(ctx)
+					})
+			},
+		},
+		{
+			name:          "should release key after request canceled",
+			handlerStatus: http.StatusInternalServerError,
+			setupMock: func() {
+				idempotencyServiceMock.EXPECT().Begin(gomock.Any(), 1, "key-1", gomock.Any()).Return(nil, nil)
+				idempotencyServiceMock.EXPECT().Release(gomock.Any(), 1, "key-1").DoAndReturn(
+					func(ctx context.Context, _ int, _ string) error {
+						return notCanceled(ctx)
+					})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			ctx, cancel := context.WithCancel(context.WithValue(context.Background(), auth.ClaimsContextKey, &auth.Claims{TokenUser: auth.TokenUser{ID: 1}}))
+			defer cancel()
+
+			handler := idempotency.Middleware(idempotencyServiceMock, logger.New("info"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cancel()
+				w.WriteHeader(tc.handlerStatus)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/test/", strings.NewReader("12345678903")).WithContext(ctx)
+			req.Header.Set(idempotency.HeaderKey, "key-1")
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Error(t, ctx.Err())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+var (
+	ErrRequestInProgress = errors.New("request with this idempotency key is in progress")
+	ErrKeyReused         = errors.New("idempotency key was used for a different request")
+)
+
+const purgeInterval = 10 * time.Minute
+
+type IdempotencyService interface {
+	// Begin claims the key for the request identified by fingerprint. A nil response means the caller owns
+	// the key and must finish it with Complete or Release, a non-nil response must be replayed as is.
+	Begin(ctx context.Context, userID int, key string, fingerprint string) (*dtos.IdempotencyResponse, error)
+	Complete(ctx context.Context, userID int, key string, response dtos.IdempotencyResponse) error
+	Release(ctx context.Context, userID int, key string) error
+}
+
+type SimpleIdempotencyService struct {
+	idempotencyRepo repository.IdempotencyRepository
+	logger          logger.Logger
+	ttl             time.Duration
+	now             func() time.Time
+}
+
+func (s *SimpleIdempotencyService) Begin(ctx context.Context, userID int, key string, fingerprint string) (*dtos.IdempotencyResponse, error) {
+	now := s.now()
+
+	record, claimed, err := s.idempotencyRepo.Reserve(ctx, userID, key, fingerprint, now, now.Add(s.ttl))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claimed {
+		return nil, nil
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, ErrKeyReused
+	}
+
+	if record.Response == nil {
+		return nil, ErrRequestInProgress
+	}
+
+	return record.Response, nil
+}
+
+func (s *SimpleIdempotencyService) Complete(ctx context.Context, userID int, key string, response dtos.IdempotencyResponse) error {
+	return s.idempotencyRepo.Complete(ctx, userID, key, response)
+}
+
+func (s *SimpleIdempotencyService) Release(ctx context.Context, userID int, key string) error {
+	return s.idempotencyRepo.Release(ctx, userID, key)
+}
+
+// Run periodically deletes expired keys. Expired keys are already ignored by Begin, purging only keeps the table small.
+func (s *SimpleIdempotencyService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		deleted, err := s.idempotencyRepo.DeleteExpired(ctx, s.now())
+
+		if err != nil {
+			s.logger.Errorw("failed to purge expired idempotency keys", "err", err)
+			continue
+		}
+
+		if deleted > 0 {
+			s.logger.Infow("purged expired idempotency keys", "count", deleted)
+		}
+	}
+}
+
+func NewService(idempotencyRepo repository.IdempotencyRepository, logger logger.Logger, ttl time.Duration) *SimpleIdempotencyService {
+	return &SimpleIdempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		logger:          logger,
+		ttl:             ttl,
+		now:             time.Now,
+	}
+}
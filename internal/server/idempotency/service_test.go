@@ -0,0 +1,81 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyService_begin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idempotencyRepoMock := repository.NewMockIdempotencyRepository(ctrl)
+
+	s := idempotency.NewService(idempotencyRepoMock, logger.New("info"), time.Hour)
+
+	stored := &dtos.IdempotencyResponse{StatusCode: 200, Body: []byte("ok")}
+
+	tests := []struct {
+		name             string
+		setupMock        func()
+		expectedResponse *dtos.IdempotencyResponse
+		expectedError    error
+	}{
+		{
+			name: "should claim new key",
+			setupMock: func() {
+				idempotencyRepoMock.EXPECT().Reserve(gomock.Any(), 1, "key", "fp", gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, userID int, key string, fingerprint string, now time.Time, expiresAt time.Time) (dtos.IdempotencyRecord, bool, error) {
+						require.Equal(t, time.Hour, expiresAt.Sub(now))
+						return dtos.IdempotencyRecord{UserID: 1, Key: "key", Fingerprint: "fp"}, true, nil
+					})
+			},
+		},
+		{
+			name: "should return stored response",
+			setupMock: func() {
+				idempotencyRepoMock.EXPECT().Reserve(gomock.Any(), 1, "key", "fp", gomock.Any(), gomock.Any()).Return(dtos.IdempotencyRecord{Fingerprint: "fp", Response: stored}, false, nil)
+			},
+			expectedResponse: stored,
+		},
+		{
+			name: "should return error if first request in progress",
+			setupMock: func() {
+				idempotencyRepoMock.EXPECT().Reserve(gomock.Any(), 1, "key", "fp", gomock.Any(), gomock.Any()).Return(dtos.IdempotencyRecord{Fingerprint: "fp"}, false, nil)
+			},
+			expectedError: idempotency.ErrRequestInProgress,
+		},
+		{
+			name: "should return error if key used for another request",
+			setupMock: func() {
+				idempotencyRepoMock.EXPECT().Reserve(gomock.Any(), 1, "key", "fp", gomock.Any(), gomock.Any()).Return(dtos.IdempotencyRecord{Fingerprint: "other", Response: stored}, false, nil)
+			},
+			expectedError: idempotency.ErrKeyReused,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			response, err := s.Begin(context.Background(), 1, "key", "fp")
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/accrual"
+	"github.com/sodiqit/gophermart/internal/server/admin"
 	"github.com/sodiqit/gophermart/internal/server/auth"
 	"github.com/sodiqit/gophermart/internal/server/balance"
 	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/health"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
 	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/outbox"
 	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/internal/server/webhook"
 )
 
 type AppContainer struct {
@@ -23,8 +31,18 @@ type AppContainer struct {
 	AuthContainer         *auth.AuthContainer
 	OrderContainer        *order.OrderContainer
 	BalanceContainer      *balance.BalanceContainer
+	IdempotencyContainer  *idempotency.IdempotencyContainer
+	AdminContainer        *admin.AdminContainer
+	HealthContainer       *health.HealthContainer
 	AccrualOrderProcessor *accrual.OrderProcessor
 	AccrualHTTPClient     *accrual.HTTPAccrualClient
+	AccrualBreaker        *accrual.CircuitBreakerClient
+	AccrualWebhook        *accrual.WebhookController
+	AccrualRechecker      *accrual.Rechecker
+	LedgerReconciler      *ledger.Reconciler
+	LedgerExpirer         *ledger.Expirer
+	OutboxContainer       *outbox.OutboxContainer
+	WebhookContainer      *webhook.WebhookContainer
 }
 
 func NewAppContainer(ctx context.Context, config *config.Config) (*AppContainer, error) {
@@ -48,13 +66,57 @@ func NewAppContainer(ctx context.Context, config *config.Config) (*AppContainer,
 	userRepo := repository.NewDBUserRepository(db)
 	orderRepo := repository.NewDBOrderRepository(db)
 	balanceRepo := repository.NewDBBalanceRepository(db)
+	ledgerRepo := repository.NewDBLedgerRepository(db)
+	transactor := repository.NewDBTransactor(db)
+	idempotencyRepo := repository.NewDBIdempotencyRepository(db)
+	outboxRepo := repository.NewDBOutboxRepository(db)
+	webhookRepo := repository.NewDBWebhookRepository(db)
+	refreshTokenRepo := repository.NewDBRefreshTokenRepository(db)
+	tokenRevocationRepo := repository.NewDBTokenRevocationRepository(db)
+	passwordResetRepo := repository.NewDBPasswordResetRepository(db)
+
+	orderStatusBroker := order.NewStatusBroker()
 
 	accrualClient := accrual.NewHTTPAccrualClient(fmt.Sprintf("%s/api/orders/", config.AccrualAddress) + "%s")
-	accrualOrderProcessor := accrual.NewOrderProcessor(20, orderRepo, logger, accrualClient)
+	// without the webhook nothing is pushed, so orders are polled right away
+	var accrualPushTimeout time.Duration
+
+	if config.AccrualWebhookSecret != "" {
+		accrualPushTimeout = config.AccrualPushTimeout
+	}
+
+	accrualBreaker := accrual.NewCircuitBreakerClient(accrualClient, logger, accrual.BreakerConfig{
+		FailureThreshold:  config.AccrualBreakerFailures,
+		OpenTimeout:       config.AccrualBreakerOpenTimeout,
+		HalfOpenSuccesses: config.AccrualBreakerSuccesses,
+	})
+	accrualOrderProcessor := accrual.NewOrderProcessor(20, orderRepo, ledgerRepo, outboxRepo, webhookRepo, orderStatusBroker, transactor, logger, accrualBreaker, config.AccrualLeaseTTL, dtos.RetryPolicy{
+		BaseDelay: config.AccrualRetryBaseDelay,
+		MaxDelay:  config.AccrualRetryMaxDelay,
+		MaxAge:    config.AccrualMaxOrderAge,
+	}, accrualPushTimeout)
+	accrualWebhook := accrual.NewWebhookController(logger, config.AccrualWebhookSecret, accrualOrderProcessor)
+	accrualRechecker := accrual.NewRechecker(orderRepo, ledgerRepo, outboxRepo, webhookRepo, orderStatusBroker, transactor, accrualBreaker, logger, config.AccrualRecheckInterval, config.AccrualRecheckWindow)
+	ledgerReconciler := ledger.NewReconciler(ledgerRepo, logger, config.LedgerReconcileInterval)
+	ledgerExpirer := ledger.NewExpirer(ledgerRepo, logger, ledger.ExpiryPolicy{LifetimeMonths: config.PointsLifetimeMonths, ExpiringSoon: config.PointsExpiringSoon}, config.PointsExpiryInterval)
 
-	authContainer := auth.NewContainer(config, logger, userRepo)
-	orderContainer := order.NewContainer(config, logger, authContainer.TokenService, orderRepo)
-	balanceContainer := balance.NewContainer(config, logger, authContainer.TokenService, balanceRepo)
+	authContainer, err := auth.NewContainer(config, logger, userRepo, refreshTokenRepo, passwordResetRepo, tokenRevocationRepo, transactor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	idempotencyContainer := idempotency.NewContainer(config, logger, idempotencyRepo)
+	orderContainer := order.NewContainer(config, logger, authContainer.TokenService, orderRepo, orderStatusBroker, idempotencyContainer.Service)
+	balanceContainer := balance.NewContainer(config, logger, authContainer.TokenService, balanceRepo, orderRepo, ledgerRepo, outboxRepo, webhookRepo, transactor, idempotencyContainer.Service)
+	adminContainer := admin.NewContainer(config, logger, orderRepo, accrualClient)
+	healthContainer := health.NewContainer(logger, db, accrualBreaker, accrualClient)
+	webhookContainer := webhook.NewContainer(config, logger, authContainer.TokenService, webhookRepo, transactor)
+	outboxContainer, err := outbox.NewContainer(config, logger, outboxRepo)
+
+	if err != nil {
+		return nil, err
+	}
 
 	return &AppContainer{
 		Config:                config,
@@ -63,7 +125,17 @@ func NewAppContainer(ctx context.Context, config *config.Config) (*AppContainer,
 		AuthContainer:         authContainer,
 		OrderContainer:        orderContainer,
 		BalanceContainer:      balanceContainer,
+		IdempotencyContainer:  idempotencyContainer,
+		AdminContainer:        adminContainer,
+		HealthContainer:       healthContainer,
 		AccrualOrderProcessor: accrualOrderProcessor,
 		AccrualHTTPClient:     accrualClient,
+		AccrualBreaker:        accrualBreaker,
+		AccrualWebhook:        accrualWebhook,
+		AccrualRechecker:      accrualRechecker,
+		LedgerReconciler:      ledgerReconciler,
+		LedgerExpirer:         ledgerExpirer,
+		OutboxContainer:       outboxContainer,
+		WebhookContainer:      webhookContainer,
 	}, nil
 }
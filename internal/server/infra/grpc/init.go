@@ -11,8 +11,10 @@ import (
 	authv1 "github.com/sodiqit/gophermart/gen/proto/auth/v1"
 	balancev1 "github.com/sodiqit/gophermart/gen/proto/balance/v1"
 	orderv1 "github.com/sodiqit/gophermart/gen/proto/order/v1"
+	webhookv1 "github.com/sodiqit/gophermart/gen/proto/webhook/v1"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
 	"github.com/sodiqit/gophermart/internal/server/infra"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
@@ -39,17 +41,36 @@ func RunServer(ctx context.Context, deps *infra.AppContainer) error {
 		}),
 	}
 
-	protectedMethods := []string{orderv1.OrderService_Upload_FullMethodName, orderv1.OrderService_GetList_FullMethodName, balancev1.BalanceService_GetBalance_FullMethodName, balancev1.BalanceService_GetWithdrawals_FullMethodName, balancev1.BalanceService_Withdraw_FullMethodName}
+	protectedMethods := []string{authv1.AuthService_Logout_FullMethodName, authv1.AuthService_LogoutAll_FullMethodName, authv1.AuthService_ChangePassword_FullMethodName, authv1.AuthService_DeleteAccount_FullMethodName, orderv1.OrderService_Upload_FullMethodName, orderv1.OrderService_GetList_FullMethodName, orderv1.OrderService_GetOrder_FullMethodName, balancev1.BalanceService_GetBalance_FullMethodName, balancev1.BalanceService_GetWithdrawals_FullMethodName, balancev1.BalanceService_Withdraw_FullMethodName, balancev1.BalanceService_CreateHold_FullMethodName, balancev1.BalanceService_CaptureHold_FullMethodName, balancev1.BalanceService_VoidHold_FullMethodName, balancev1.BalanceService_GetHolds_FullMethodName, balancev1.BalanceService_Transfer_FullMethodName, balancev1.BalanceService_GetTransfers_FullMethodName, balancev1.BalanceService_ListTransactions_FullMethodName, webhookv1.WebhookService_RegisterWebhook_FullMethodName, webhookv1.WebhookService_ListWebhooks_FullMethodName, webhookv1.WebhookService_DeleteWebhook_FullMethodName, webhookv1.WebhookService_ListDeliveries_FullMethodName}
 
-	srv = grpc.NewServer(grpc.ChainUnaryInterceptor(
-		recovery.UnaryServerInterceptor(recoveryOpts...),
-		logging.UnaryServerInterceptor(InterceptorLogger(logger), []logging.Option{}...),
-		auth.UnaryAuthInterceptor(deps.AuthContainer.TokenService, protectedMethods),
-	))
+	idempotentMethods := []string{orderv1.OrderService_Upload_FullMethodName, balancev1.BalanceService_Withdraw_FullMethodName, balancev1.BalanceService_CreateHold_FullMethodName, balancev1.BalanceService_Transfer_FullMethodName}
+
+	protectedStreams := []string{balancev1.BalanceService_ExportStatement_FullMethodName, orderv1.OrderService_WatchOrders_FullMethodName}
+
+	srv = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recovery.UnaryServerInterceptor(recoveryOpts...),
+			logging.UnaryServerInterceptor(InterceptorLogger(logger), []logging.Option{}...),
+			auth.UnaryAuthInterceptor(deps.AuthContainer.TokenService, protectedMethods),
+			idempotency.UnaryIdempotencyInterceptor(deps.IdempotencyContainer.Service, logger, idempotentMethods),
+		),
+		grpc.ChainStreamInterceptor(
+			recovery.StreamServerInterceptor(recoveryOpts...),
+			logging.StreamServerInterceptor(InterceptorLogger(logger), []logging.Option{}...),
+			auth.StreamAuthInterceptor(deps.AuthContainer.TokenService, protectedStreams),
+		),
+	)
 
 	authv1.RegisterAuthServiceServer(srv, deps.AuthContainer.GRPCServer)
 	orderv1.RegisterOrderServiceServer(srv, deps.OrderContainer.GRPCServer)
 	balancev1.RegisterBalanceServiceServer(srv, deps.BalanceContainer.GRPCServer)
+	webhookv1.RegisterWebhookServiceServer(srv, deps.WebhookContainer.GRPCServer)
+
+	// order watches never end by themselves, graceful stop waits for them otherwise
+	go func() {
+		<-ctx.Done()
+		deps.OrderContainer.StatusBroker.Close()
+	}()
 
 	logger.Infow("start gRPC server", "port", config.GRPCAddress)
 
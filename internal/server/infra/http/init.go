@@ -20,7 +20,16 @@ func RunServer(ctx context.Context, deps *infra.AppContainer) error {
 	authContainer := deps.AuthContainer
 	orderContainer := deps.OrderContainer
 	balanceContainer := deps.BalanceContainer
+	adminContainer := deps.AdminContainer
+	healthContainer := deps.HealthContainer
+	accrualWebhook := deps.AccrualWebhook
 	accrualOrderProcessor := deps.AccrualOrderProcessor
+	accrualRechecker := deps.AccrualRechecker
+	ledgerReconciler := deps.LedgerReconciler
+	ledgerExpirer := deps.LedgerExpirer
+	idempotencyService := deps.IdempotencyContainer.Service
+	outboxRelay := deps.OutboxContainer.Relay
+	webhookContainer := deps.WebhookContainer
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
@@ -32,8 +41,13 @@ func RunServer(ctx context.Context, deps *infra.AppContainer) error {
 		httpSwagger.URL("/swagger/doc.json"), //The url pointing to API definition
 	))
 	r.Mount("/debug", middleware.Profiler())
+	r.Mount("/.well-known", authContainer.JWKSController.Route())
 	r.Mount("/api/user", authContainer.Controller.Route())
 	r.Mount("/api/user/orders", orderContainer.Controller.Route())
+	r.Mount("/api/user/webhooks", webhookContainer.Controller.Route())
+	r.Mount("/api/admin", adminContainer.Controller.Route())
+	r.Mount("/health", healthContainer.Controller.Route())
+	r.Mount("/api/accrual/webhook", accrualWebhook.Route())
 	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(3 * time.Second)
 		w.Write([]byte("pong"))
@@ -41,9 +55,19 @@ func RunServer(ctx context.Context, deps *infra.AppContainer) error {
 	balanceContainer.Controller.Connect(r, "/api/")
 
 	go accrualOrderProcessor.Run(ctx)
+	go outboxRelay.Run(ctx)
+	go webhookContainer.Dispatcher.Run(ctx)
+	go accrualRechecker.Run(ctx)
+	go ledgerReconciler.Run(ctx)
+	go ledgerExpirer.Run(ctx)
+	go balanceContainer.HoldSweeper.Run(ctx)
+	go idempotencyService.Run(ctx)
+	go authContainer.RevocationStore.Run(ctx)
 
 	logger.Infow("start HTTP server", "address", config.Address, "config", config)
 	srv = http.Server{Addr: config.Address, Handler: r}
+	// order event streams never end by themselves, shutdown waits for them otherwise
+	srv.RegisterOnShutdown(orderContainer.StatusBroker.Close)
 	return srv.ListenAndServe()
 }
 
@@ -0,0 +1,148 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const expireBatchSize = 100
+
+// ExpiryPolicy defines when points expire: LifetimeMonths after the lot was earned. Zero lifetime
+// disables expiration. Points expiring within ExpiringSoon are reported with the balance.
+type ExpiryPolicy struct {
+	LifetimeMonths int
+	ExpiringSoon   time.Duration
+}
+
+func (p ExpiryPolicy) Enabled() bool {
+	return p.LifetimeMonths > 0
+}
+
+func (p ExpiryPolicy) ExpiresAt(earnedAt time.Time) time.Time {
+	return earnedAt.AddDate(0, p.LifetimeMonths, 0)
+}
+
+// EarnedBefore returns the time lots earned before have expired at now.
+func (p ExpiryPolicy) EarnedBefore(now time.Time) time.Time {
+	return now.AddDate(0, -p.LifetimeMonths, 0)
+}
+
+// ExpiringSoonAmounts groups points of open lots expiring before now+ExpiringSoon by expiration date.
+// Lots are consumed oldest first, so the reported amounts are capped by the current balance from the oldest lot.
+func (p ExpiryPolicy) ExpiringSoonAmounts(lots []dtos.PointLot, current points.Points, now time.Time) []dtos.ExpiringPoints {
+	result := make([]dtos.ExpiringPoints, 0)
+
+	if !p.Enabled() {
+		return result
+	}
+
+	left := current
+	deadline := now.Add(p.ExpiringSoon)
+
+	for _, lot := range lots {
+		if left <= 0 {
+			break
+		}
+
+		expiresAt := p.ExpiresAt(lot.EarnedAt)
+
+		if expiresAt.After(deadline) {
+			break
+		}
+
+		amount := lot.Remaining
+
+		if amount > left {
+			amount = left
+		}
+
+		left -= amount
+
+		day := time.Date(expiresAt.Year(), expiresAt.Month(), expiresAt.Day(), 0, 0, 0, 0, expiresAt.Location())
+
+		if n := len(result); n > 0 && result[n-1].ExpiresAt.Equal(day) {
+			result[n-1].Amount += amount
+			continue
+		}
+
+		result = append(result, dtos.ExpiringPoints{Amount: amount, ExpiresAt: day})
+	}
+
+	return result
+}
+
+// Expirer periodically expires lots older than the policy lifetime.
+type Expirer struct {
+	ledgerRepo repository.LedgerRepository
+	logger     logger.Logger
+	policy     ExpiryPolicy
+	interval   time.Duration
+}
+
+func (e *Expirer) Run(ctx context.Context) error {
+	if !e.policy.Enabled() || e.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Expirer) expire(ctx context.Context) {
+	earnedBefore := e.policy.EarnedBefore(time.Now())
+	afterID := int64(0)
+
+	for ctx.Err() == nil {
+		lots, err := e.ledgerRepo.GetLotsEarnedBefore(ctx, earnedBefore, afterID, expireBatchSize)
+
+		if err != nil {
+			e.logger.Errorw("failed to get expired point lots", "err", err)
+			return
+		}
+
+		if len(lots) == 0 {
+			return
+		}
+
+		for _, lot := range lots {
+			afterID = lot.ID
+
+			expired, err := e.ledgerRepo.ExpireLot(ctx, lot.ID)
+
+			if err != nil {
+				e.logger.Errorw("failed to expire point lot", "lotID", lot.ID, "err", err)
+				return
+			}
+
+			if expired == 0 {
+				continue
+			}
+
+			e.logger.Infow("points expired", "userID", lot.UserID, "lotID", lot.ID, "amount", expired)
+		}
+	}
+}
+
+func NewExpirer(ledgerRepo repository.LedgerRepository, logger logger.Logger, policy ExpiryPolicy, interval time.Duration) *Expirer {
+	return &Expirer{
+		ledgerRepo: ledgerRepo,
+		logger:     logger,
+		policy:     policy,
+		interval:   interval,
+	}
+}
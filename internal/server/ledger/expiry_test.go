@@ -0,0 +1,111 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/ledger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpiryPolicy_ExpiringSoonAmounts(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	policy := ledger.ExpiryPolicy{LifetimeMonths: 6, ExpiringSoon: 30 * 24 * time.Hour}
+
+	lots := []dtos.PointLot{
+		{ID: 1, Remaining: points.FromMinor(100), EarnedAt: time.Date(2026, time.April, 20, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, Remaining: points.FromMinor(200), EarnedAt: time.Date(2026, time.April, 20, 18, 0, 0, 0, time.UTC)},
+		{ID: 3, Remaining: points.FromMinor(300), EarnedAt: time.Date(2026, time.May, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 4, Remaining: points.FromMinor(400), EarnedAt: time.Date(2026, time.September, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name           string
+		policy         ledger.ExpiryPolicy
+		current        points.Points
+		expectedResult []dtos.ExpiringPoints
+	}{
+		{
+			name:           "should return nothing if expiration disabled",
+			policy:         ledger.ExpiryPolicy{},
+			current:        points.FromMinor(1000),
+			expectedResult: []dtos.ExpiringPoints{},
+		},
+		{
+			name:    "should group lots by expiration date",
+			policy:  policy,
+			current: points.FromMinor(1000),
+			expectedResult: []dtos.ExpiringPoints{
+				{Amount: points.FromMinor(300), ExpiresAt: time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)},
+				{Amount: points.FromMinor(300), ExpiresAt: time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:    "should cap amounts by current balance",
+			policy:  policy,
+			current: points.FromMinor(150),
+			expectedResult: []dtos.ExpiringPoints{
+				{Amount: points.FromMinor(150), ExpiresAt: time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, tc.policy.ExpiringSoonAmounts(lots, tc.current, now))
+		})
+	}
+}
+
+func TestExpirer_heldLot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ledgerRepoMock := repository.NewMockLedgerRepository(ctrl)
+
+	e := ledger.NewExpirer(ledgerRepoMock, logger.New("info"), ledger.ExpiryPolicy{LifetimeMonths: 6}, time.Hour)
+
+	heldLot := dtos.PointLot{ID: 1, UserID: 1, Remaining: points.FromMinor(100)}
+	lot := dtos.PointLot{ID: 2, UserID: 2, Remaining: points.FromMinor(50)}
+
+	t.Run("should pass over points of lot reserved by hold", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		gomock.InOrder(
+			ledgerRepoMock.EXPECT().GetLotsEarnedBefore(gomock.Any(), gomock.Any(), int64(0), gomock.Any()).Return([]dtos.PointLot{heldLot, lot}, nil),
+			ledgerRepoMock.EXPECT().ExpireLot(gomock.Any(), heldLot.ID).Return(points.FromMinor(40), nil),
+			ledgerRepoMock.EXPECT().ExpireLot(gomock.Any(), lot.ID).Return(points.FromMinor(50), nil),
+			ledgerRepoMock.EXPECT().GetLotsEarnedBefore(gomock.Any(), gomock.Any(), lot.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, earnedBefore time.Time, afterID int64, limit int64) ([]dtos.PointLot, error) {
+				cancel()
+				return []dtos.PointLot{}, nil
+			}),
+		)
+
+		require.ErrorIs(t, e.Run(ctx), context.Canceled)
+	})
+
+	t.Run("should expire rest of lot once hold is released", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		heldLot.Remaining = points.FromMinor(60)
+
+		gomock.InOrder(
+			ledgerRepoMock.EXPECT().GetLotsEarnedBefore(gomock.Any(), gomock.Any(), int64(0), gomock.Any()).Return([]dtos.PointLot{heldLot}, nil),
+			ledgerRepoMock.EXPECT().ExpireLot(gomock.Any(), heldLot.ID).Return(points.FromMinor(60), nil),
+			ledgerRepoMock.EXPECT().GetLotsEarnedBefore(gomock.Any(), gomock.Any(), heldLot.ID, gomock.Any()).DoAndReturn(func(ctx context.Context, earnedBefore time.Time, afterID int64, limit int64) ([]dtos.PointLot, error) {
+				cancel()
+				return []dtos.PointLot{}, nil
+			}),
+		)
+
+		require.ErrorIs(t, e.Run(ctx), context.Canceled)
+	})
+}
@@ -0,0 +1,59 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+// Reconciler periodically checks that every ledger transaction balances and that maintained
+// user balances match the ledger. Discrepancies are only reported, never corrected automatically.
+type Reconciler struct {
+	ledgerRepo repository.LedgerRepository
+	logger     logger.Logger
+	interval   time.Duration
+}
+
+func (r *Reconciler) Run(ctx context.Context) error {
+	if r.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) {
+	discrepancies, err := r.ledgerRepo.Reconcile(ctx)
+
+	if err != nil {
+		r.logger.Errorw("failed to reconcile ledger", "err", err)
+		return
+	}
+
+	for _, d := range discrepancies {
+		r.logger.Warnw("ledger discrepancy", "reason", d.Reason, "userID", d.UserID, "transactionID", d.TransactionID, "expected", d.Expected, "actual", d.Actual)
+	}
+
+	r.logger.Infow("ledger reconciled", "discrepancies", len(discrepancies))
+}
+
+func NewReconciler(ledgerRepo repository.LedgerRepository, logger logger.Logger, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		ledgerRepo: ledgerRepo,
+		logger:     logger,
+		interval:   interval,
+	}
+}
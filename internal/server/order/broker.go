@@ -0,0 +1,92 @@
+package order
+
+import "sync"
+
+// StatusNotifier is told about users whose orders changed status, once the change is committed.
+type StatusNotifier interface {
+	Notify(userID int)
+}
+
+// StatusBroker wakes up the order streams of users on this instance. Notifications carry no data: a stream reads
+// the changes from the status history, so a missed notification, e.g. of a change made by another replica,
+// only delays the change until the stream polls the history again.
+type StatusBroker struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+	closed      bool
+}
+
+// Subscribe returns a channel receiving a value when orders of the user change and a function to unsubscribe.
+// Notifications are coalesced while the subscriber is busy. The channel is closed when the broker is closed.
+func (b *StatusBroker) Subscribe(userID int) (<-chan struct{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan struct{}]struct{})
+	}
+
+	b.subscribers[userID][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[userID][ch]; !ok {
+			return
+		}
+
+		delete(b.subscribers[userID], ch)
+
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+	}
+}
+
+func (b *StatusBroker) Notify(userID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close ends all subscriptions, so open streams do not hold the server on shutdown.
+func (b *StatusBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+
+	for _, subscribers := range b.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+	}
+
+	b.subscribers = make(map[int]map[chan struct{}]struct{})
+}
+
+var _ StatusNotifier = (*StatusBroker)(nil)
+
+func NewStatusBroker() *StatusBroker {
+	return &StatusBroker{
+		subscribers: make(map[int]map[chan struct{}]struct{}),
+	}
+}
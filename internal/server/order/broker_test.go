@@ -0,0 +1,47 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusBroker(t *testing.T) {
+	broker := order.NewStatusBroker()
+
+	first, unsubscribeFirst := broker.Subscribe(1)
+	second, unsubscribeSecond := broker.Subscribe(1)
+	other, unsubscribeOther := broker.Subscribe(2)
+	defer unsubscribeOther()
+
+	broker.Notify(1)
+	broker.Notify(1)
+
+	require.Len(t, first, 1, "notifications should be coalesced")
+	require.Len(t, second, 1)
+	require.Len(t, other, 0)
+
+	<-first
+	unsubscribeSecond()
+	<-second
+
+	broker.Notify(1)
+
+	require.Len(t, first, 1)
+	require.Len(t, second, 0, "unsubscribed channel should not be notified")
+
+	<-first
+	unsubscribeFirst()
+
+	broker.Close()
+
+	_, ok := <-other
+	require.False(t, ok, "channels should be closed with the broker")
+
+	closed, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	_, ok = <-closed
+	require.False(t, ok, "subscription to closed broker should be closed")
+}
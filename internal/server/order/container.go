@@ -4,21 +4,24 @@ import (
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
 	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
 	"github.com/sodiqit/gophermart/internal/server/repository"
 )
 
 type OrderContainer struct {
-	Controller *OrderController
-	GRPCServer *OrderServer
+	Controller   *OrderController
+	GRPCServer   *OrderServer
+	StatusBroker *StatusBroker
 }
 
-func NewContainer(config *config.Config, logger logger.Logger, tokenService auth.TokenService, orderRepo repository.OrderRepository) *OrderContainer {
-	orderService := NewSimpleOrderService(orderRepo)
-	orderController := NewController(logger, tokenService, orderService)
+func NewContainer(config *config.Config, logger logger.Logger, tokenService auth.TokenService, orderRepo repository.OrderRepository, statusBroker *StatusBroker, idempotencyService idempotency.IdempotencyService) *OrderContainer {
+	orderService := NewSimpleOrderService(orderRepo, statusBroker)
+	orderController := NewController(logger, tokenService, orderService, idempotencyService)
 	orderServer := NewOrderServer(logger, orderService)
 
 	return &OrderContainer{
-		Controller: orderController,
-		GRPCServer: orderServer,
+		Controller:   orderController,
+		GRPCServer:   orderServer,
+		StatusBroker: statusBroker,
 	}
 }
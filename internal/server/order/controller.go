@@ -1,22 +1,32 @@
 package order
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
 	"github.com/sodiqit/gophermart/pkg/luhn"
 )
 
+// eventsKeepAliveInterval is how often an idle event stream sends a comment, so proxies do not close it.
+const eventsKeepAliveInterval = 15 * time.Second
+
 type OrderController struct {
-	logger       logger.Logger
-	tokenService auth.TokenService
-	orderService OrderService
+	logger             logger.Logger
+	tokenService       auth.TokenService
+	orderService       OrderService
+	idempotencyService idempotency.IdempotencyService
 }
 
 func (c *OrderController) Route() *chi.Mux {
@@ -24,8 +34,10 @@ func (c *OrderController) Route() *chi.Mux {
 
 	r.Use(auth.JWTAuth(c.tokenService))
 
-	r.With(middleware.AllowContentType("text/plain")).Post("/", c.handleUploadOrder)
+	r.With(middleware.AllowContentType("text/plain"), idempotency.Middleware(c.idempotencyService, c.logger)).Post("/", c.handleUploadOrder)
 	r.Get("/", c.handleGetUserList)
+	r.Get("/events", c.handleOrderEvents)
+	r.Get("/{number}", c.handleGetOrder)
 
 	return r
 }
@@ -35,7 +47,8 @@ func (c *OrderController) Route() *chi.Mux {
 //	@Summary		upload new order
 //	@Tags			order
 //
-//	@Param			body	body	string	true	"OrderID"
+//	@Param			body			body	string	true	"OrderID"
+//	@Param			Idempotency-Key	header	string	false	"replay the first response for retries with the same key"
 //	@Security		ApiKeyAuth
 //	@Accept			plain/text
 //	@Produce		json
@@ -118,11 +131,168 @@ func (c *OrderController) handleGetUserList(w http.ResponseWriter, r *http.Reque
 	w.Write(result)
 }
 
-func NewController(logger logger.Logger, tokenService auth.TokenService, orderService OrderService) *OrderController {
+// handleGetOrder godoc
+//
+//	@Summary		get user order with its status timeline
+//	@Tags			order
+//
+//	@Param			number	path	string	true	"order number"
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{object}	dtos.OrderDetails
+//	@Failure		401
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/user/orders/{number} [get]
+func (c *OrderController) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	op := "orderController.handleGetOrder"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	order, err := c.orderService.GetUserOrder(r.Context(), user.ID, chi.URLParam(r, "number"))
+
+	if errors.Is(err, ErrOrderNotFound) {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while get user order", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := json.Marshal(order)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Write(result)
+}
+
+// handleOrderEvents godoc
+//
+//	@Summary		stream status changes of user orders
+//	@Description	Server-sent events, every status transition of the user orders is sent as an "order" event with the sequence number of the transition among transitions of the user orders as the event id. Pass the id of the last received event in Last-Event-ID header to resume the stream, without it only transitions made after connecting are sent
+//	@Tags			order
+//
+//	@Param			Last-Event-ID	header	int	false	"id of the last received event"
+//	@Security		ApiKeyAuth
+//	@Produce		text/event-stream
+//	@Success		200	{object}	dtos.OrderStatusEvent
+//	@Failure		400
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/orders/events [get]
+func (c *OrderController) handleOrderEvents(w http.ResponseWriter, r *http.Request) {
+	op := "orderController.handleOrderEvents"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	var afterID *int64
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+
+		if err != nil || id < 0 {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+
+		afterID = &id
+	}
+
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		logger.Errorw("response writer does not support streaming")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// the watch sends changes to the loop below, so writes of events and keep-alives never interleave
+	changes := make(chan dtos.OrderStatusChange)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.orderService.Watch(ctx, user.ID, afterID, func(change dtos.OrderStatusChange) error {
+			select {
+			case changes <- change:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		var err error
+
+		select {
+		case change := <-changes:
+			err = writeOrderEvent(w, change)
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		case err := <-done:
+			if err != nil && ctx.Err() == nil {
+				logger.Errorw("error while watch user orders", "err", err.Error())
+			}
+			return
+		}
+
+		if err != nil {
+			return
+		}
+
+		flusher.Flush()
+	}
+}
+
+func writeOrderEvent(w io.Writer, change dtos.OrderStatusChange) error {
+	data, err := json.Marshal(dtos.OrderStatusEvent{
+		OrderID:    change.OrderID,
+		FromStatus: change.FromStatus,
+		Status:     change.Status,
+		Accrual:    change.Accrual,
+		ChangedAt:  change.CreatedAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: order\ndata: %s\n\n", change.Seq, data)
+
+	return err
+}
+
+func NewController(logger logger.Logger, tokenService auth.TokenService, orderService OrderService, idempotencyService idempotency.IdempotencyService) *OrderController {
 	return &OrderController{
 		logger,
 		tokenService,
 		orderService,
+		idempotencyService,
 	}
 }
 
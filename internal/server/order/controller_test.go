@@ -1,6 +1,7 @@
 package order_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -12,8 +13,10 @@ import (
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
 	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/idempotency"
 	"github.com/sodiqit/gophermart/internal/server/order"
 	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/points"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -28,7 +31,7 @@ func TestOrderController_handleOrderUpload(t *testing.T) {
 	tokenServiceMock := auth.NewMockTokenService(ctrl)
 	logger := logger.New("info")
 
-	c := order.NewController(logger, tokenServiceMock, orderServiceMock)
+	c := order.NewController(logger, tokenServiceMock, orderServiceMock, idempotency.NewMockIdempotencyService(ctrl))
 
 	r.Mount("/orders", c.Route())
 
@@ -163,7 +166,7 @@ func TestOrderController_handleGetUserList(t *testing.T) {
 	tokenServiceMock := auth.NewMockTokenService(ctrl)
 	logger := logger.New("info")
 
-	c := order.NewController(logger, tokenServiceMock, orderServiceMock)
+	c := order.NewController(logger, tokenServiceMock, orderServiceMock, idempotency.NewMockIdempotencyService(ctrl))
 
 	r.Mount("/orders", c.Route())
 
@@ -262,3 +265,161 @@ func TestOrderController_handleGetUserList(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderController_handleGetOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	orderServiceMock := order.NewMockOrderService(ctrl)
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+
+	c := order.NewController(logger.New("info"), tokenServiceMock, orderServiceMock, idempotency.NewMockIdempotencyService(ctrl))
+
+	r.Mount("/orders", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	uploadedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	processedAt := uploadedAt.Add(time.Minute)
+	accrual := points.FromMinor(50000)
+
+	tests := []struct {
+		name           string
+		setupMock      func()
+		expectedStatus int
+		expectedResult string
+	}{
+		{
+			name: "should return order with status timeline",
+			setupMock: func() {
+				orderServiceMock.EXPECT().GetUserOrder(gomock.Any(), 1, "2377225624").Return(dtos.OrderDetails{
+					Order: dtos.Order{ID: "2377225624", Status: repository.OrderStatusProcessed, Accrual: &accrual, CreatedAt: uploadedAt},
+					History: []dtos.OrderStatusChange{
+						{Status: repository.OrderStatusNew, Source: repository.OrderStatusSourceUpload, CreatedAt: uploadedAt},
+						{FromStatus: repository.OrderStatusNew, Status: repository.OrderStatusProcessed, Accrual: &accrual, Source: repository.OrderStatusSourceAccrual, CreatedAt: processedAt},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: `{"number":"2377225624","status":"PROCESSED","accrual":500,"uploaded_at":"2024-03-01T10:00:00Z","history":[` +
+				`{"status":"NEW","source":"UPLOAD","changed_at":"2024-03-01T10:00:00Z"},` +
+				`{"from":"NEW","status":"PROCESSED","accrual":500,"source":"ACCRUAL","changed_at":"2024-03-01T10:01:00Z"}]}`,
+		},
+		{
+			name: "should return 404 if order not found",
+			setupMock: func() {
+				orderServiceMock.EXPECT().GetUserOrder(gomock.Any(), 1, "2377225624").Return(dtos.OrderDetails{}, order.ErrOrderNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "should handle unexpected error",
+			setupMock: func() {
+				orderServiceMock.EXPECT().GetUserOrder(gomock.Any(), 1, "2377225624").Return(dtos.OrderDetails{}, errors.New("error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+			tc.setupMock()
+
+			resp, err := client.R().SetHeader("Authorization", "Bearer test").Get("/orders/2377225624")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+			if tc.expectedStatus == http.StatusOK {
+				require.JSONEq(t, tc.expectedResult, resp.String())
+			}
+		})
+	}
+}
+
+func TestOrderController_handleOrderEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	orderServiceMock := order.NewMockOrderService(ctrl)
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	logger := logger.New("info")
+
+	c := order.NewController(logger, tokenServiceMock, orderServiceMock, idempotency.NewMockIdempotencyService(ctrl))
+
+	r.Mount("/orders", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	changedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	accrual := points.FromMinor(50000)
+
+	tests := []struct {
+		name           string
+		lastEventID    string
+		setupMock      func()
+		expectedStatus int
+		expectedResult string
+	}{
+		{
+			name:           "should return 400 if Last-Event-ID invalid",
+			lastEventID:    "abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				orderServiceMock.EXPECT().Watch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should stream changes after Last-Event-ID",
+			lastEventID:    "5",
+			expectedStatus: http.StatusOK,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				orderServiceMock.EXPECT().Watch(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int, afterID *int64, send func(change dtos.OrderStatusChange) error) error {
+					require.NotNil(t, afterID)
+					require.Equal(t, int64(5), *afterID)
+
+					err := send(dtos.OrderStatusChange{ID: 16, Seq: 6, OrderID: "2377225624", FromStatus: repository.OrderStatusNew, Status: repository.OrderStatusProcessing, CreatedAt: changedAt})
+
+					if err != nil {
+						return err
+					}
+
+					return send(dtos.OrderStatusChange{ID: 17, Seq: 7, OrderID: "2377225624", FromStatus: repository.OrderStatusProcessing, Status: repository.OrderStatusProcessed, Accrual: &accrual, CreatedAt: changedAt})
+				})
+			},
+			expectedResult: "id: 6\nevent: order\ndata: {\"number\":\"2377225624\",\"from\":\"NEW\",\"status\":\"PROCESSING\",\"changed_at\":\"2024-01-02T03:04:05Z\"}\n\n" +
+				"id: 7\nevent: order\ndata: {\"number\":\"2377225624\",\"from\":\"PROCESSING\",\"status\":\"PROCESSED\",\"accrual\":500,\"changed_at\":\"2024-01-02T03:04:05Z\"}\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			resp, err := client.R().
+				SetHeader("Authorization", "Bearer test").
+				SetHeader("Last-Event-ID", tc.lastEventID).
+				Get("/orders/events")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+
+			if tc.expectedResult != "" {
+				require.Equal(t, "text/event-stream", resp.Header().Get("Content-Type"))
+				require.Equal(t, tc.expectedResult, string(resp.Body()))
+			}
+		})
+	}
+}
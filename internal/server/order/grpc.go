@@ -8,6 +8,7 @@ import (
 	proto "github.com/sodiqit/gophermart/gen/proto/order/v1"
 	"github.com/sodiqit/gophermart/internal/logger"
 	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
 	"github.com/sodiqit/gophermart/internal/server/repository"
 	"github.com/sodiqit/gophermart/pkg/luhn"
 	"google.golang.org/grpc/codes"
@@ -73,12 +74,7 @@ func (s *OrderServer) GetList(ctx context.Context, in *proto.GetListRequest) (*p
 	result := make([]*proto.Order, 0, len(orders))
 
 	for _, order := range orders {
-		result = append(result, &proto.Order{
-			Number:     order.ID,
-			Accrual:    order.Accrual,
-			UploadedAt: timestamppb.New(order.CreatedAt),
-			Status:     mapOrderStatusToProto(order.Status),
-		})
+		result = append(result, mapOrderToProto(order))
 	}
 
 	response.Orders = result
@@ -86,6 +82,126 @@ func (s *OrderServer) GetList(ctx context.Context, in *proto.GetListRequest) (*p
 	return &response, nil
 }
 
+func (s *OrderServer) GetOrder(ctx context.Context, in *proto.GetOrderRequest) (*proto.GetOrderResponse, error) {
+	logger := s.logger.With("op", proto.OrderService_GetOrder_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	order, err := s.orderService.GetUserOrder(ctx, user.ID, in.Number)
+
+	if errors.Is(err, ErrOrderNotFound) {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+
+	if err != nil {
+		logger.Errorw("failed to get order", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	response := proto.GetOrderResponse{Order: mapOrderToProto(order.Order)}
+
+	for _, change := range order.History {
+		response.History = append(response.History, mapStatusChangeToProto(change))
+	}
+
+	return &response, nil
+}
+
+// WatchOrders sends the order with every status transition of the user orders. The order is read when the transition
+// is sent, so after a burst of transitions several events may carry the same latest order.
+func (s *OrderServer) WatchOrders(in *proto.WatchOrdersRequest, stream proto.OrderService_WatchOrdersServer) error {
+	logger := s.logger.With("op", proto.OrderService_WatchOrders_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := stream.Context()
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	err = s.orderService.Watch(ctx, user.ID, in.AfterEventId, func(change dtos.OrderStatusChange) error {
+		order, err := s.orderService.GetUserOrder(ctx, user.ID, change.OrderID)
+
+		// the order is deleted with its history, there is nothing to send
+		if errors.Is(err, ErrOrderNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return stream.Send(&proto.WatchOrdersResponse{
+			EventId: change.Seq,
+			Order:   mapOrderToProto(order.Order),
+			Change:  mapStatusChangeToProto(change),
+		})
+	})
+
+	if err != nil && ctx.Err() == nil {
+		logger.Errorw("failed to watch orders", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	return nil
+}
+
+func mapStatusChangeToProto(change dtos.OrderStatusChange) *proto.StatusChange {
+	protoChange := &proto.StatusChange{
+		Status:    mapOrderStatusToProto(change.Status),
+		Source:    proto.StatusChange_Source(proto.StatusChange_Source_value[change.Source]),
+		ChangedAt: timestamppb.New(change.CreatedAt),
+	}
+
+	if change.FromStatus != "" {
+		from := mapOrderStatusToProto(change.FromStatus)
+		protoChange.From = &from
+	}
+
+	if change.Accrual != nil {
+		accrualMinor := change.Accrual.Minor()
+		protoChange.AccrualMinor = &accrualMinor
+	}
+
+	return protoChange
+}
+
+func mapOrderToProto(order dtos.Order) *proto.Order {
+	protoOrder := &proto.Order{
+		Number:     order.ID,
+		UploadedAt: timestamppb.New(order.CreatedAt),
+		Status:     mapOrderStatusToProto(order.Status),
+	}
+
+	if order.Accrual != nil {
+		accrual := order.Accrual.Float64()
+		accrualMinor := order.Accrual.Minor()
+		protoOrder.Accrual = &accrual
+		protoOrder.AccrualMinor = &accrualMinor
+	}
+
+	for _, adjustment := range order.Adjustments {
+		protoOrder.Adjustments = append(protoOrder.Adjustments, &proto.AccrualAdjustment{
+			PreviousAccrualMinor: adjustment.PreviousAccrual.Minor(),
+			AccrualMinor:         adjustment.Accrual.Minor(),
+			AmountMinor:          adjustment.Amount.Minor(),
+			Reason:               mapAdjustmentReasonToProto(adjustment.Reason),
+			CreatedAt:            timestamppb.New(adjustment.CreatedAt),
+		})
+	}
+
+	return protoOrder
+}
+
 func mapOrderStatusToProto(status string) proto.Order_OrderStatus {
 	switch status {
 	case repository.OrderStatusNew:
@@ -101,6 +217,17 @@ func mapOrderStatusToProto(status string) proto.Order_OrderStatus {
 	panic("invalid order status")
 }
 
+func mapAdjustmentReasonToProto(reason string) proto.AccrualAdjustment_Reason {
+	switch reason {
+	case repository.OrderAdjustmentReasonChanged:
+		return proto.AccrualAdjustment_CHANGED
+	case repository.OrderAdjustmentReasonRevoked:
+		return proto.AccrualAdjustment_REVOKED
+	}
+
+	panic("invalid adjustment reason")
+}
+
 func mapUploadServiceError(err error, logger logger.Logger) error {
 	code := codes.Internal
 	msg := "Internal server error"
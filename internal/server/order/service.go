@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sodiqit/gophermart/internal/server/dtos"
 	"github.com/sodiqit/gophermart/internal/server/repository"
@@ -12,13 +13,24 @@ import (
 type OrderService interface {
 	Upload(ctx context.Context, userID int, orderNumber string) error
 	GetUserOrders(ctx context.Context, userID int) ([]dtos.Order, error)
+	GetUserOrder(ctx context.Context, userID int, orderNumber string) (dtos.OrderDetails, error)
+	Watch(ctx context.Context, userID int, afterSeq *int64, send func(change dtos.OrderStatusChange) error) error
 }
 
+const (
+	// watchPollInterval is how often a watch reads the status history without being notified,
+	// changes made by other replicas reach it this late.
+	watchPollInterval = 10 * time.Second
+	watchBatchSize    = 100
+)
+
 var ErrUserAlreadyUploadOrder = errors.New("user already upload this order")
 var ErrOrderAlreadyUploadByAnotherUser = errors.New("another user already upload this order")
+var ErrOrderNotFound = errors.New("order not found")
 
 type SimpleOrderService struct {
 	orderRepo repository.OrderRepository
+	broker    *StatusBroker
 }
 
 func (s *SimpleOrderService) Upload(ctx context.Context, userID int, orderNumber string) error {
@@ -47,8 +59,91 @@ func (s *SimpleOrderService) GetUserOrders(ctx context.Context, userID int) ([]d
 	return s.orderRepo.GetListByUser(ctx, userID)
 }
 
-func NewSimpleOrderService(orderRepo repository.OrderRepository) *SimpleOrderService {
+// GetUserOrder returns the order of the user with its status timeline. Orders of other users are not found.
+func (s *SimpleOrderService) GetUserOrder(ctx context.Context, userID int, orderNumber string) (dtos.OrderDetails, error) {
+	op := "orderService.getUserOrder"
+
+	order, err := s.orderRepo.FindByOrderNumber(ctx, orderNumber)
+
+	if errors.Is(err, repository.ErrOrderNotFound) || (err == nil && order.UserID != userID) {
+		return dtos.OrderDetails{}, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	if err != nil {
+		return dtos.OrderDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	history, err := s.orderRepo.GetStatusHistory(ctx, orderNumber)
+
+	if err != nil {
+		return dtos.OrderDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dtos.OrderDetails{Order: order, History: history}, nil
+}
+
+// Watch calls send with status transitions of the user orders, oldest first, until ctx is done or the broker
+// is closed on shutdown, then nil is returned. An error of send stops it and is returned wrapped.
+// Transitions with seq after afterSeq are sent first, without afterSeq only transitions recorded after
+// the call are sent. The seq of a transition is sent to resume from it.
+func (s *SimpleOrderService) Watch(ctx context.Context, userID int, afterSeq *int64, send func(change dtos.OrderStatusChange) error) error {
+	op := "orderService.watch"
+
+	// subscribe before reading the history, so a change committed in between is not missed
+	notifications, unsubscribe := s.broker.Subscribe(userID)
+	defer unsubscribe()
+
+	var lastSeq int64
+
+	if afterSeq != nil {
+		lastSeq = *afterSeq
+	} else {
+		var err error
+
+		lastSeq, err = s.orderRepo.GetLastUserStatusChangeSeq(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := s.orderRepo.GetUserStatusChanges(ctx, userID, lastSeq, watchBatchSize)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, change := range changes {
+			if err := send(change); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			lastSeq = change.Seq
+		}
+
+		if len(changes) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+func NewSimpleOrderService(orderRepo repository.OrderRepository, broker *StatusBroker) *SimpleOrderService {
 	return &SimpleOrderService{
 		orderRepo: orderRepo,
+		broker:    broker,
 	}
 }
@@ -40,6 +40,21 @@ func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
 	return m.recorder
 }
 
+// GetUserOrder mocks base method.
+func (m *MockOrderService) GetUserOrder(ctx context.Context, userID int, orderNumber string) (dtos.OrderDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrder", ctx, userID, orderNumber)
+	ret0, _ := ret[0].(dtos.OrderDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrder indicates an expected call of GetUserOrder.
+func (mr *MockOrderServiceMockRecorder) GetUserOrder(ctx, userID, orderNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrder", reflect.TypeOf((*MockOrderService)(nil).GetUserOrder), ctx, userID, orderNumber)
+}
+
 // GetUserOrders mocks base method.
 func (m *MockOrderService) GetUserOrders(ctx context.Context, userID int) ([]dtos.Order, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +83,17 @@ func (mr *MockOrderServiceMockRecorder) Upload(ctx, userID, orderNumber any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockOrderService)(nil).Upload), ctx, userID, orderNumber)
 }
+
+// Watch mocks base method.
+func (m *MockOrderService) Watch(ctx context.Context, userID int, afterSeq *int64, send func(dtos.OrderStatusChange) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, userID, afterSeq, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockOrderServiceMockRecorder) Watch(ctx, userID, afterSeq, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockOrderService)(nil).Watch), ctx, userID, afterSeq, send)
+}
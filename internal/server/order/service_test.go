@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-jet/jet/v2/qrm"
 	"github.com/sodiqit/gophermart/internal/server/auth"
@@ -21,7 +22,7 @@ func TestOrderService_upload(t *testing.T) {
 
 	orderRepoMock := repository.NewMockOrderRepository(ctrl)
 
-	s := order.NewSimpleOrderService(orderRepoMock)
+	s := order.NewSimpleOrderService(orderRepoMock, order.NewStatusBroker())
 
 	tests := []struct {
 		name           string
@@ -88,14 +89,73 @@ func TestOrderService_upload(t *testing.T) {
 	}
 }
 
+func TestOrderService_getUserOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepoMock := repository.NewMockOrderRepository(ctrl)
+
+	s := order.NewSimpleOrderService(orderRepoMock, order.NewStatusBroker())
+
+	history := []dtos.OrderStatusChange{{Status: repository.OrderStatusNew, Source: repository.OrderStatusSourceUpload}}
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expected      dtos.OrderDetails
+		expectedError error
+	}{
+		{
+			name: "should return order with history",
+			setupMock: func() {
+				orderRepoMock.EXPECT().FindByOrderNumber(gomock.Any(), "2377225624").Return(dtos.Order{ID: "2377225624", UserID: 1, Status: repository.OrderStatusNew}, nil)
+				orderRepoMock.EXPECT().GetStatusHistory(gomock.Any(), "2377225624").Return(history, nil)
+			},
+			expected: dtos.OrderDetails{Order: dtos.Order{ID: "2377225624", UserID: 1, Status: repository.OrderStatusNew}, History: history},
+		},
+		{
+			name: "should not return order of another user",
+			setupMock: func() {
+				orderRepoMock.EXPECT().FindByOrderNumber(gomock.Any(), "2377225624").Return(dtos.Order{ID: "2377225624", UserID: 2}, nil)
+				orderRepoMock.EXPECT().GetStatusHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: order.ErrOrderNotFound,
+		},
+		{
+			name: "should return error if order not found",
+			setupMock: func() {
+				orderRepoMock.EXPECT().FindByOrderNumber(gomock.Any(), "2377225624").Return(dtos.Order{}, repository.ErrOrderNotFound)
+			},
+			expectedError: order.ErrOrderNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			result, err := s.GetUserOrder(context.Background(), 1, "2377225624")
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestAuthService_login(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	tokenServiceMock := auth.NewMockTokenService(ctrl)
 	userRepoMock := repository.NewMockUserRepository(ctrl)
+	refreshTokenRepoMock := repository.NewMockRefreshTokenRepository(ctrl)
 
-	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock)
+	s := auth.NewSimpleAuthService(tokenServiceMock, userRepoMock, refreshTokenRepoMock, repository.NewMockPasswordResetRepository(ctrl), auth.NewMockRevocationStore(ctrl), nil, repository.NewMockTransactor(ctrl), time.Hour, time.Hour)
 
 	tests := []struct {
 		name           string
@@ -128,6 +188,8 @@ func TestAuthService_login(t *testing.T) {
 				passHash, _ := bcrypt.GenerateFromPassword([]byte("test"), bcrypt.DefaultCost)
 				userRepoMock.EXPECT().FindByLogin(gomock.Any(), "test").Return(dtos.User{ID: 1, Login: "test", PasswordHash: string(passHash)}, nil)
 				tokenServiceMock.EXPECT().Build(1).Return("test_token", nil)
+				tokenServiceMock.EXPECT().TTL().Return(10 * time.Minute)
+				refreshTokenRepoMock.EXPECT().CreateRefreshToken(gomock.Any(), 1, gomock.Any(), gomock.Any(), time.Hour).Return(nil)
 			},
 			wantErr:        false,
 			expectedResult: "test_token",
@@ -150,8 +212,111 @@ func TestAuthService_login(t *testing.T) {
 
 			if !tc.wantErr {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedResult, token)
+				require.Equal(t, tc.expectedResult, token.AccessToken)
+				require.NotEmpty(t, token.RefreshToken)
+				require.Equal(t, int64(600), token.ExpiresIn)
 			}
 		})
 	}
 }
+
+func TestOrderService_watch(t *testing.T) {
+	changes := []dtos.OrderStatusChange{
+		// the transition recorded first may commit last, streams follow the seq taken under the user lock
+		{ID: 12, Seq: 6, OrderID: "2377225624", Status: repository.OrderStatusNew, Source: repository.OrderStatusSourceUpload},
+		{ID: 11, Seq: 7, OrderID: "2377225624", FromStatus: repository.OrderStatusNew, Status: repository.OrderStatusProcessing, Source: repository.OrderStatusSourceAccrual},
+	}
+
+	afterSeq := int64(5)
+
+	errSend := errors.New("connection closed")
+
+	tests := []struct {
+		name          string
+		afterSeq      *int64
+		setupMock     func(orderRepoMock *repository.MockOrderRepository, broker *order.StatusBroker, cancel context.CancelFunc)
+		sendErr       error
+		expectedSent  []int64
+		expectedError error
+	}{
+		{
+			name:     "should send changes after last event and wait for notification",
+			afterSeq: &afterSeq,
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, broker *order.StatusBroker, cancel context.CancelFunc) {
+				gomock.InOrder(
+					orderRepoMock.EXPECT().GetUserStatusChanges(gomock.Any(), 1, int64(5), int64(100)).DoAndReturn(func(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error) {
+						broker.Notify(1)
+						return changes, nil
+					}),
+					orderRepoMock.EXPECT().GetUserStatusChanges(gomock.Any(), 1, int64(7), int64(100)).DoAndReturn(func(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error) {
+						cancel()
+						return nil, nil
+					}),
+				)
+			},
+			expectedSent: []int64{6, 7},
+		},
+		{
+			name: "should start from last change without last event",
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, broker *order.StatusBroker, cancel context.CancelFunc) {
+				orderRepoMock.EXPECT().GetLastUserStatusChangeSeq(gomock.Any(), 1).Return(int64(9), nil)
+				orderRepoMock.EXPECT().GetUserStatusChanges(gomock.Any(), 1, int64(9), int64(100)).DoAndReturn(func(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error) {
+					cancel()
+					return nil, nil
+				})
+			},
+		},
+		{
+			name:     "should stop when broker closed",
+			afterSeq: &afterSeq,
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, broker *order.StatusBroker, cancel context.CancelFunc) {
+				orderRepoMock.EXPECT().GetUserStatusChanges(gomock.Any(), 1, int64(5), int64(100)).DoAndReturn(func(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error) {
+					broker.Close()
+					return nil, nil
+				})
+			},
+		},
+		{
+			name:     "should return error if send failed",
+			afterSeq: &afterSeq,
+			setupMock: func(orderRepoMock *repository.MockOrderRepository, broker *order.StatusBroker, cancel context.CancelFunc) {
+				orderRepoMock.EXPECT().GetUserStatusChanges(gomock.Any(), 1, int64(5), int64(100)).Return(changes, nil)
+			},
+			sendErr:       errSend,
+			expectedSent:  []int64{6},
+			expectedError: errSend,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepoMock := repository.NewMockOrderRepository(ctrl)
+			broker := order.NewStatusBroker()
+
+			s := order.NewSimpleOrderService(orderRepoMock, broker)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tc.setupMock(orderRepoMock, broker, cancel)
+
+			var sent []int64
+
+			err := s.Watch(ctx, 1, tc.afterSeq, func(change dtos.OrderStatusChange) error {
+				sent = append(sent, change.Seq)
+				return tc.sendErr
+			})
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedSent, sent)
+		})
+	}
+}
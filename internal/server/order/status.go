@@ -0,0 +1,62 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+// AccrualStatusRegistered is reported by the accrual system for orders it has not started processing.
+const AccrualStatusRegistered = "REGISTERED"
+
+var ErrUnknownStatus = errors.New("unknown order status")
+var ErrIllegalTransition = errors.New("illegal order status transition")
+
+// transitions are the statuses an order can move to from its status. Orders move forward while the accrual
+// system processes them. A processed order can only be changed by the recheck: its accrual is changed or revoked.
+// An invalid order is never changed.
+var transitions = map[string][]string{
+	repository.OrderStatusNew:        {repository.OrderStatusProcessing, repository.OrderStatusInvalid, repository.OrderStatusProcessed},
+	repository.OrderStatusProcessing: {repository.OrderStatusInvalid, repository.OrderStatusProcessed},
+	repository.OrderStatusProcessed:  {repository.OrderStatusProcessed, repository.OrderStatusInvalid},
+	repository.OrderStatusInvalid:    {},
+}
+
+// MapAccrualStatus maps a status of the accrual system to the order status.
+func MapAccrualStatus(status string) (string, error) {
+	switch status {
+	case AccrualStatusRegistered, repository.OrderStatusNew:
+		return repository.OrderStatusNew, nil
+	case repository.OrderStatusProcessing, repository.OrderStatusInvalid, repository.OrderStatusProcessed:
+		return status, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownStatus, status)
+}
+
+// ValidateTransition returns ErrIllegalTransition unless the order can move from one status to another.
+func ValidateTransition(from string, to string) error {
+	allowed, ok := transitions[from]
+
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownStatus, from)
+	}
+
+	if _, ok := transitions[to]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, from, to)
+}
+
+// IsFinal reports whether the accrual system has finished processing the order.
+func IsFinal(status string) bool {
+	return status == repository.OrderStatusInvalid || status == repository.OrderStatusProcessed
+}
@@ -0,0 +1,71 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/sodiqit/gophermart/internal/server/order"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAccrualStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected string
+		wantErr  bool
+	}{
+		{status: "REGISTERED", expected: repository.OrderStatusNew},
+		{status: "PROCESSING", expected: repository.OrderStatusProcessing},
+		{status: "INVALID", expected: repository.OrderStatusInvalid},
+		{status: "PROCESSED", expected: repository.OrderStatusProcessed},
+		{status: "DONE", wantErr: true},
+		{status: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.status, func(t *testing.T) {
+			status, err := order.MapAccrualStatus(tc.status)
+
+			if tc.wantErr {
+				require.ErrorIs(t, err, order.ErrUnknownStatus)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from        string
+		to          string
+		expectedErr error
+	}{
+		{from: repository.OrderStatusNew, to: repository.OrderStatusProcessing},
+		{from: repository.OrderStatusNew, to: repository.OrderStatusProcessed},
+		{from: repository.OrderStatusNew, to: repository.OrderStatusInvalid},
+		{from: repository.OrderStatusProcessing, to: repository.OrderStatusProcessed},
+		{from: repository.OrderStatusProcessing, to: repository.OrderStatusInvalid},
+		{from: repository.OrderStatusProcessed, to: repository.OrderStatusProcessed},
+		{from: repository.OrderStatusProcessed, to: repository.OrderStatusInvalid},
+		{from: repository.OrderStatusProcessing, to: repository.OrderStatusNew, expectedErr: order.ErrIllegalTransition},
+		{from: repository.OrderStatusProcessed, to: repository.OrderStatusProcessing, expectedErr: order.ErrIllegalTransition},
+		{from: repository.OrderStatusInvalid, to: repository.OrderStatusProcessed, expectedErr: order.ErrIllegalTransition},
+		{from: repository.OrderStatusNew, to: "REGISTERED", expectedErr: order.ErrUnknownStatus},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.from+" -> "+tc.to, func(t *testing.T) {
+			err := order.ValidateTransition(tc.from, tc.to)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+const (
+	relayBatchSize = 100
+	// relayLeaseTTL bounds the time a relay publishes a claimed batch, events left are claimed again after it
+	relayLeaseTTL = 5 * time.Minute
+)
+
+type OutboxContainer struct {
+	Relay *Relay
+}
+
+func NewContainer(config *config.Config, logger logger.Logger, outboxRepo repository.OutboxRepository) (*OutboxContainer, error) {
+	var publisher Publisher
+
+	if config.OutboxPublisher != "" {
+		target := config.OutboxWebhookURL
+
+		if config.OutboxPublisher == PublisherFile {
+			target = config.OutboxFile
+		}
+
+		var err error
+
+		publisher, err = NewPublisher(config.OutboxPublisher, target, config.OutboxWebhookSecret)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	relay := NewRelay(outboxRepo, publisher, logger, relayLeaseTTL, config.OutboxRelayInterval, relayBatchSize, dtos.RetryPolicy{
+		BaseDelay: config.OutboxRetryBaseDelay,
+		MaxDelay:  config.OutboxRetryMaxDelay,
+	})
+
+	return &OutboxContainer{
+		Relay: relay,
+	}, nil
+}
@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/pkg/signature"
+)
+
+const (
+	PublisherWebhook = "webhook"
+	PublisherFile    = "file"
+	PublisherStdout  = "stdout"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the request body keyed with the webhook secret.
+	SignatureHeader = "X-Gophermart-Signature"
+	EventIDHeader   = "X-Gophermart-Event-ID"
+	EventTypeHeader = "X-Gophermart-Event-Type"
+)
+
+var ErrUnknownPublisher = errors.New("unknown outbox publisher")
+
+// Publisher delivers outbox events to the other services. An event is published at least once,
+// so Publish may be called again for an event it has already delivered.
+type Publisher interface {
+	Publish(ctx context.Context, event dtos.OutboxEvent) error
+}
+
+// WebhookPublisher posts every event as JSON to the webhook URL. Any response but 2xx is a failure.
+type WebhookPublisher struct {
+	url        string
+	secret     string
+	httpClient *resty.Client
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event dtos.OutboxEvent) error {
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	request := p.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(EventIDHeader, fmt.Sprint(event.ID)).
+		SetHeader(EventTypeHeader, event.Type).
+		SetBody(body)
+
+	if p.secret != "" {
+		request.SetHeader(SignatureHeader, signature.Sign(p.secret, body))
+	}
+
+	response, err := request.Post(p.url)
+
+	if err != nil {
+		return err
+	}
+
+	if !response.IsSuccess() {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode())
+	}
+
+	return nil
+}
+
+// WriterPublisher writes every event as a line of JSON, e.g. to a file or stdout for local runs and tests.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, event dtos.OutboxEvent) error {
+	line, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.writer.Write(append(line, '\n'))
+
+	return err
+}
+
+// NewPublisher returns the publisher of the kind. target is the URL of the webhook or the path of the file.
+func NewPublisher(kind string, target string, secret string) (Publisher, error) {
+	switch kind {
+	case PublisherWebhook:
+		if target == "" {
+			return nil, errors.New("outbox webhook url is not set")
+		}
+		return NewWebhookPublisher(target, secret), nil
+	case PublisherFile:
+		file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return NewWriterPublisher(file), nil
+	case PublisherStdout:
+		return NewWriterPublisher(os.Stdout), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPublisher, kind)
+}
+
+func NewWebhookPublisher(url string, secret string) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:        url,
+		secret:     secret,
+		httpClient: resty.New().SetTimeout(10 * time.Second),
+	}
+}
+
+func NewWriterPublisher(writer io.Writer) *WriterPublisher {
+	return &WriterPublisher{writer: writer}
+}
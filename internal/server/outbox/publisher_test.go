@@ -0,0 +1,113 @@
+package outbox_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/outbox"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = dtos.OutboxEvent{
+	ID:          7,
+	Type:        repository.OutboxEventOrderProcessed,
+	AggregateID: "2377225624",
+	Payload:     json.RawMessage(`{"number":"2377225624","user_id":1,"accrual":500}`),
+	CreatedAt:   time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+}
+
+func TestWebhookPublisher_publish(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "should post signed event",
+			secret:     "secret",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "should post unsigned event without secret",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "should fail on non 2xx response",
+			secret:     "secret",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received dtos.OutboxEvent
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				require.Equal(t, "7", r.Header.Get(outbox.EventIDHeader))
+				require.Equal(t, repository.OutboxEventOrderProcessed, r.Header.Get(outbox.EventTypeHeader))
+
+				if tc.secret == "" {
+					require.Empty(t, r.Header.Get(outbox.SignatureHeader))
+				} else {
+					require.True(t, signature.Valid(tc.secret, body, r.Header.Get(outbox.SignatureHeader)))
+				}
+
+				require.NoError(t, json.Unmarshal(body, &received))
+
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			err := outbox.NewWebhookPublisher(server.URL, tc.secret).Publish(context.Background(), testEvent)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testEvent.ID, received.ID)
+			require.JSONEq(t, string(testEvent.Payload), string(received.Payload))
+		})
+	}
+}
+
+func TestWriterPublisher_publish(t *testing.T) {
+	var buf bytes.Buffer
+
+	p := outbox.NewWriterPublisher(&buf)
+
+	require.NoError(t, p.Publish(context.Background(), testEvent))
+	require.NoError(t, p.Publish(context.Background(), testEvent))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"id":7,"type":"order.processed","aggregate_id":"2377225624","payload":{"number":"2377225624","user_id":1,"accrual":500},"occurred_at":"2026-10-17T12:00:00Z"}`, string(lines[0]))
+}
+
+func TestNewPublisher(t *testing.T) {
+	_, err := outbox.NewPublisher("kafka", "", "")
+	require.ErrorIs(t, err, outbox.ErrUnknownPublisher)
+
+	_, err = outbox.NewPublisher(outbox.PublisherWebhook, "", "")
+	require.Error(t, err)
+
+	p, err := outbox.NewPublisher(outbox.PublisherFile, t.TempDir()+"/events.jsonl", "")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+}
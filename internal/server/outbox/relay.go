@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+// Relay publishes events written to the outbox. A batch of events is leased to the relay before it is published,
+// so several gophermart replicas can run relays side by side without publishing an event concurrently.
+// Events are published outside of any transaction, a slow publisher holds no row locks.
+// An event that failed to publish is retried with backoff, events after it are published meanwhile.
+type Relay struct {
+	outboxRepo  repository.OutboxRepository
+	publisher   Publisher
+	logger      logger.Logger
+	owner       string
+	leaseTTL    time.Duration
+	interval    time.Duration
+	batchSize   int64
+	retryPolicy dtos.RetryPolicy
+}
+
+// Run publishes pending events every interval until ctx is done. Full batches are followed
+// by the next one right away. Without a publisher events are kept in the outbox.
+func (r *Relay) Run(ctx context.Context) error {
+	if r.publisher == nil || r.interval <= 0 {
+		return nil
+	}
+
+	r.logger.Infow("start relaying outbox events")
+
+	for {
+		relayed, err := r.relayBatch(ctx)
+
+		if err != nil && ctx.Err() == nil {
+			r.logger.Errorw("failed to relay outbox events", "err", err)
+		}
+
+		if err == nil && int64(relayed) == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Infow("stop relaying outbox events")
+			return ctx.Err()
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// relayBatch publishes a batch of pending events and returns its size. Events left when the lease is
+// about to expire are not published, they are claimed again once it expires.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	op := "outboxRelay.relayBatch"
+
+	deadline := time.Now().Add(r.leaseTTL)
+
+	events, err := r.outboxRepo.ClaimPending(ctx, r.owner, r.leaseTTL, r.batchSize)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, event := range events {
+		if !time.Now().Before(deadline) {
+			r.logger.Warnw("outbox lease expired before the batch was published", "eventID", event.ID)
+			break
+		}
+
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			r.logger.Warnw("failed to publish outbox event", "eventID", event.ID, "type", event.Type, "attempts", event.Attempts+1, "err", err)
+
+			if err := r.outboxRepo.ScheduleRetry(ctx, event.ID, r.owner, r.retryPolicy, err.Error()); err != nil {
+				return len(events), fmt.Errorf("%s: %w", op, err)
+			}
+
+			continue
+		}
+
+		if err := r.outboxRepo.MarkPublished(ctx, event.ID); err != nil {
+			return len(events), fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return len(events), nil
+}
+
+func NewRelay(outboxRepo repository.OutboxRepository, publisher Publisher, logger logger.Logger, leaseTTL time.Duration, interval time.Duration, batchSize int64, retryPolicy dtos.RetryPolicy) *Relay {
+	return &Relay{
+		outboxRepo:  outboxRepo,
+		publisher:   publisher,
+		logger:      logger,
+		owner:       repository.NewLeaseOwner(),
+		leaseTTL:    leaseTTL,
+		interval:    interval,
+		batchSize:   batchSize,
+		retryPolicy: retryPolicy,
+	}
+}
@@ -0,0 +1,96 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/outbox"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRelay_run(t *testing.T) {
+	retryPolicy := dtos.RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}
+	events := []dtos.OutboxEvent{
+		{ID: 1, Type: repository.OutboxEventOrderProcessed, AggregateID: "2377225624", Payload: []byte(`{"number":"2377225624"}`)},
+		{ID: 2, Type: repository.OutboxEventWithdrawalCreated, AggregateID: "1", Payload: []byte(`{"order":"2377225624"}`)},
+	}
+
+	tests := []struct {
+		name      string
+		leaseTTL  time.Duration
+		setupMock func(outboxRepoMock *repository.MockOutboxRepository, publisherMock *outbox.MockPublisher)
+	}{
+		{
+			name:     "should mark published events",
+			leaseTTL: time.Minute,
+			setupMock: func(outboxRepoMock *repository.MockOutboxRepository, publisherMock *outbox.MockPublisher) {
+				publisherMock.EXPECT().Publish(gomock.Any(), events[0]).Return(nil)
+				publisherMock.EXPECT().Publish(gomock.Any(), events[1]).Return(nil)
+				outboxRepoMock.EXPECT().MarkPublished(gomock.Any(), int64(1)).Return(nil)
+				outboxRepoMock.EXPECT().MarkPublished(gomock.Any(), int64(2)).Return(nil)
+			},
+		},
+		{
+			name:     "should retry failed event and publish the next one",
+			leaseTTL: time.Minute,
+			setupMock: func(outboxRepoMock *repository.MockOutboxRepository, publisherMock *outbox.MockPublisher) {
+				publisherMock.EXPECT().Publish(gomock.Any(), events[0]).Return(errors.New("connection refused"))
+				outboxRepoMock.EXPECT().ScheduleRetry(gomock.Any(), int64(1), gomock.Any(), retryPolicy, "connection refused").Return(nil)
+				outboxRepoMock.EXPECT().MarkPublished(gomock.Any(), int64(1)).Times(0)
+				publisherMock.EXPECT().Publish(gomock.Any(), events[1]).Return(nil)
+				outboxRepoMock.EXPECT().MarkPublished(gomock.Any(), int64(2)).Return(nil)
+			},
+		},
+		{
+			name: "should leave events to be claimed again once the lease expired",
+			setupMock: func(outboxRepoMock *repository.MockOutboxRepository, publisherMock *outbox.MockPublisher) {
+				publisherMock.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(0)
+				outboxRepoMock.EXPECT().MarkPublished(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outboxRepoMock := repository.NewMockOutboxRepository(ctrl)
+			publisherMock := outbox.NewMockPublisher(ctrl)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			gomock.InOrder(
+				outboxRepoMock.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), tc.leaseTTL, int64(10)).Return(events, nil),
+				outboxRepoMock.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), tc.leaseTTL, int64(10)).DoAndReturn(func(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.OutboxEvent, error) {
+					cancel()
+					return nil, nil
+				}),
+			)
+
+			tc.setupMock(outboxRepoMock, publisherMock)
+
+			r := outbox.NewRelay(outboxRepoMock, publisherMock, logger.New("info"), tc.leaseTTL, time.Millisecond, 10, retryPolicy)
+
+			err := r.Run(ctx)
+
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestRelay_runWithoutPublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := outbox.NewRelay(repository.NewMockOutboxRepository(ctrl), nil, logger.New("info"), time.Minute, time.Second, 10, dtos.RetryPolicy{})
+
+	require.NoError(t, r.Run(context.Background()))
+}
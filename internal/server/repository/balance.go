@@ -3,18 +3,58 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
 	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
 	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
 	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/pkg/points"
 )
 
+const (
+	WithdrawTypeWithdrawal  = "WITHDRAWAL"
+	WithdrawTypeReversal    = "REVERSAL"
+	WithdrawTypeTransferOut = "TRANSFER_OUT"
+	WithdrawTypeTransferIn  = "TRANSFER_IN"
+)
+
+const (
+	TransferDirectionOutgoing = "OUTGOING"
+	TransferDirectionIncoming = "INCOMING"
+)
+
+const (
+	HoldStatusHeld     = "HELD"
+	HoldStatusCaptured = "CAPTURED"
+	HoldStatusVoided   = "VOIDED"
+	HoldStatusExpired  = "EXPIRED"
+)
+
+var ErrHoldNotFound = errors.New("hold not found")
+var ErrHoldOrderUsed = errors.New("order already used by a withdrawal or a hold")
+var ErrUserNotFound = errors.New("user not found")
+
 type BalanceRepository interface {
 	GetBalanceWithWithdrawals(ctx context.Context, userID int) (dtos.Balance, error)
-	CreateWithdraw(ctx context.Context, userID int, orderID string, sum float64) (int, error)
+	CreateWithdraw(ctx context.Context, userID int, orderID string, sum points.Points) (int, error)
 	GetWithdrawalsByUser(ctx context.Context, userID int) ([]dtos.Withdraw, error)
+	LockUserBalance(ctx context.Context, userID int) error
+	CreateHold(ctx context.Context, userID int, orderID string, amount points.Points, ttl time.Duration) (dtos.Hold, error)
+	LockHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error)
+	HasActiveHold(ctx context.Context, orderID string) (bool, error)
+	SetHoldStatus(ctx context.Context, holdID int64, status string) error
+	GetHoldsByUser(ctx context.Context, userID int) ([]dtos.Hold, error)
+	ExpireHolds(ctx context.Context) (int64, error)
+	GetUserIDByLogin(ctx context.Context, login string) (int, error)
+	GetTransferredToday(ctx context.Context, userID int) (points.Points, error)
+	CreateTransfer(ctx context.Context, senderID int, recipientID int, amount points.Points) (dtos.Transfer, error)
+	GetTransfersByUser(ctx context.Context, userID int) ([]dtos.Transfer, error)
+	ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error)
+	GetBalanceAt(ctx context.Context, userID int, at time.Time) (dtos.Balance, error)
 }
 
 type DBBalanceRepository struct {
@@ -25,66 +65,89 @@ func (r *DBBalanceRepository) GetBalanceWithWithdrawals(ctx context.Context, use
 	op := "balanceRepo.getBalanceWithWithdrawals"
 
 	query := `
-		WITH accrued AS (
-			SELECT 
-				user_id, 
-				SUM(accrual) AS total_accrued
-			FROM 
-				orders
-			WHERE 
-				status = $2
-			GROUP BY 
-				user_id
-		), withdrawn AS (
-			SELECT 
-				user_id, 
-				SUM(amount) AS total_withdrawn
-			FROM 
-				withdraws
-			GROUP BY 
-				user_id
-		)
-		SELECT 
-			u.id AS user_id, 
-			COALESCE(a.total_accrued, 0) - COALESCE(w.total_withdrawn, 0) AS current_balance,
-			COALESCE(w.total_withdrawn, 0) AS total_withdrawn
-		FROM 
+		SELECT
+			u.id AS user_id,
+			COALESCE(b.current, 0) AS current_balance,
+			COALESCE(b.withdrawn, 0) AS total_withdrawn,
+			COALESCE(h.held, 0) AS total_held
+		FROM
 			users u
-		LEFT JOIN 
-			accrued a ON u.id = a.user_id
-		LEFT JOIN 
-			withdrawn w ON u.id = w.user_id
+		LEFT JOIN
+			user_balances b ON u.id = b.user_id
+		LEFT JOIN LATERAL (
+			SELECT SUM(amount) AS held
+			FROM balance_holds
+			WHERE user_id = u.id AND status = 'HELD' AND expires_at > LOCALTIMESTAMP
+		) h ON TRUE
 		WHERE
 			u.id = $1;
 	`
 
-	row := r.db.QueryRow(query, userID, OrderStatusProcessed)
+	row := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, userID)
 
 	var dest struct {
-		UserID         int     `db:"user_id"`
-		CurrentBalance float64 `db:"current_balance"`
-		TotalWithdrawn float64 `db:"total_withdrawn"`
+		UserID         int   `db:"user_id"`
+		CurrentBalance int64 `db:"current_balance"`
+		TotalWithdrawn int64 `db:"total_withdrawn"`
+		TotalHeld      int64 `db:"total_held"`
+	}
+
+	err := row.Scan(&dest.UserID, &dest.CurrentBalance, &dest.TotalWithdrawn, &dest.TotalHeld)
+
+	if err != nil {
+		return dtos.Balance{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err := row.Scan(&dest.UserID, &dest.CurrentBalance, &dest.TotalWithdrawn)
+	return dtos.Balance{
+		UserID:    dest.UserID,
+		Current:   points.FromMinor(dest.CurrentBalance - dest.TotalHeld),
+		Withdrawn: points.FromMinor(dest.TotalWithdrawn),
+		Held:      points.FromMinor(dest.TotalHeld),
+	}, nil
+}
+
+// GetBalanceAt returns the balance of the user before at. It sums the ledger entries the maintained balance
+// read by GetBalanceWithWithdrawals is built from, holds are not taken into account.
+func (r *DBBalanceRepository) GetBalanceAt(ctx context.Context, userID int, at time.Time) (dtos.Balance, error) {
+	op := "balanceRepo.getBalanceAt"
+
+	query := `
+		SELECT
+			COALESCE(SUM(CASE e.direction WHEN 'CREDIT' THEN e.amount ELSE -e.amount END), 0) AS current_balance,
+			COALESCE(SUM(CASE WHEN t.type = 'WITHDRAWAL' THEN e.amount ELSE 0 END), 0) AS total_withdrawn
+		FROM
+			ledger_entries e
+		JOIN
+			ledger_transactions t ON t.id = e.transaction_id
+		WHERE
+			e.account = 'USER' AND e.user_id = $1 AND e.created_at < $2;
+	`
+
+	var current, withdrawn int64
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, userID, at).Scan(&current, &withdrawn)
 
 	if err != nil {
 		return dtos.Balance{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return dtos.Balance{UserID: dest.UserID, Current: dest.CurrentBalance, Withdrawn: dest.TotalWithdrawn}, nil
+	return dtos.Balance{
+		UserID:    userID,
+		Current:   points.FromMinor(current),
+		Withdrawn: points.FromMinor(withdrawn),
+	}, nil
 }
 
-func (r *DBBalanceRepository) CreateWithdraw(ctx context.Context, userID int, orderID string, sum float64) (int, error) {
+func (r *DBBalanceRepository) CreateWithdraw(ctx context.Context, userID int, orderID string, sum points.Points) (int, error) {
 	op := "balanceRepo.createWithdraw"
 
 	stmt := table.Withdraws.INSERT(table.Withdraws.Amount, table.Withdraws.UserID, table.Withdraws.OrderID).
-		VALUES(sum, userID, orderID).
+		VALUES(sum.Minor(), userID, orderID).
 		RETURNING(table.Withdraws.ID)
 
 	var dest model.Withdraws
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
 
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
@@ -93,41 +156,417 @@ func (r *DBBalanceRepository) CreateWithdraw(ctx context.Context, userID int, or
 	return int(dest.ID), nil
 }
 
+// GetWithdrawalsByUser returns withdrawals of the user together with reversals of order accruals and
+// transfers sent or received by the user, oldest first.
 func (r *DBBalanceRepository) GetWithdrawalsByUser(ctx context.Context, userID int) ([]dtos.Withdraw, error) {
 	op := "balanceRepo.getWithdrawalsByUser"
 
-	stmt := table.Withdraws.SELECT(table.Withdraws.AllColumns).WHERE(table.Withdraws.UserID.EQ(postgres.Int64(int64(userID))))
+	query := `
+		SELECT id, user_id, order_id, amount, type, counterparty, created_at
+		FROM (
+			SELECT id, user_id, order_id, amount, 'WITHDRAWAL' AS type, '' AS counterparty, created_at
+			FROM withdraws
+			WHERE user_id = $1
+			UNION ALL
+			SELECT id, user_id, order_id, -amount AS amount, 'REVERSAL' AS type, '' AS counterparty, created_at
+			FROM order_adjustments
+			WHERE user_id = $1 AND amount < 0
+			UNION ALL
+			SELECT t.id, t.sender_id, '' AS order_id, t.amount, 'TRANSFER_OUT' AS type, COALESCE(u.login, '') AS counterparty, t.created_at
+			FROM point_transfers t
+			LEFT JOIN users u ON u.id = t.recipient_id
+			WHERE t.sender_id = $1
+			UNION ALL
+			SELECT t.id, t.recipient_id, '' AS order_id, t.amount, 'TRANSFER_IN' AS type, COALESCE(u.login, '') AS counterparty, t.created_at
+			FROM point_transfers t
+			LEFT JOIN users u ON u.id = t.sender_id
+			WHERE t.recipient_id = $1
+		) w
+		ORDER BY created_at, id;
+	`
+
+	result := make([]dtos.Withdraw, 0)
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var amount int64
+		var withdraw dtos.Withdraw
+
+		if err := rows.Scan(&id, &withdraw.UserID, &withdraw.OrderID, &amount, &withdraw.Type, &withdraw.Counterparty, &withdraw.ProcessedAt); err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		withdraw.ID = int(id)
+		withdraw.Amount = points.FromMinor(amount)
+
+		result = append(result, withdraw)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// LockUserBalance locks the user row until the end of the current transaction, so balance checks
+// and withdrawals of the same user are serialized. It must be called within Transactor.WithinTransaction.
+func (r *DBBalanceRepository) LockUserBalance(ctx context.Context, userID int) error {
+	op := "balanceRepo.lockUserBalance"
+
+	stmt := table.Users.SELECT(table.Users.ID).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID)))).
+		FOR(postgres.NO_KEY_UPDATE())
+
+	var dest model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// holdColumns reports holds past their expiration as EXPIRED even before the sweeper has marked them.
+const holdColumns = `
+	id,
+	user_id,
+	order_id,
+	amount,
+	CASE WHEN status = 'HELD' AND expires_at <= LOCALTIMESTAMP THEN 'EXPIRED' ELSE status END AS status,
+	expires_at,
+	created_at
+`
+
+// CreateHold reserves amount for the order until now + ttl. The order must not be used by a withdrawal
+// or another active or captured hold, otherwise ErrHoldOrderUsed is returned. A hold of the order past its
+// expiration is marked EXPIRED by the same statement, so the order can be held again before the sweeper runs.
+func (r *DBBalanceRepository) CreateHold(ctx context.Context, userID int, orderID string, amount points.Points, ttl time.Duration) (dtos.Hold, error) {
+	op := "balanceRepo.createHold"
+
+	// expired is read before the insert, so the expired hold leaves the unique index before the new hold enters it
+	query := `
+		WITH expired AS (
+			UPDATE balance_holds SET status = 'EXPIRED', updated_at = LOCALTIMESTAMP
+			WHERE order_id = $2 AND status = 'HELD' AND expires_at <= LOCALTIMESTAMP
+			RETURNING id
+		)
+		INSERT INTO balance_holds (user_id, order_id, amount, status, expires_at)
+		SELECT $1, $2, $3, 'HELD', LOCALTIMESTAMP + $4 * INTERVAL '1 microsecond'
+		WHERE
+			(SELECT COUNT(*) FROM expired) >= 0 AND
+			NOT EXISTS (SELECT 1 FROM withdraws WHERE order_id = $2) AND
+			NOT EXISTS (
+				SELECT 1 FROM balance_holds
+				WHERE order_id = $2 AND (status = 'CAPTURED' OR (status = 'HELD' AND expires_at > LOCALTIMESTAMP))
+			)
+		RETURNING ` + holdColumns
+
+	row := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, userID, orderID, amount.Minor(), ttl.Microseconds())
+
+	hold, err := scanHold(row)
+
+	// a concurrent hold for the order passes the NOT EXISTS check and fails on the unique index
+	if errors.Is(err, sql.ErrNoRows) || isUniqueViolation(err) {
+		return dtos.Hold{}, fmt.Errorf("%s: %w", op, ErrHoldOrderUsed)
+	}
+
+	if err != nil {
+		return dtos.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hold, nil
+}
+
+// LockHold returns the hold of the user and locks it until the end of the current transaction.
+// It must be called within Transactor.WithinTransaction.
+func (r *DBBalanceRepository) LockHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	op := "balanceRepo.lockHold"
+
+	query := `SELECT ` + holdColumns + ` FROM balance_holds WHERE id = $1 AND user_id = $2 FOR UPDATE`
+
+	hold, err := scanHold(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, holdID, userID))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return dtos.Hold{}, fmt.Errorf("%s: %w", op, ErrHoldNotFound)
+	}
+
+	if err != nil {
+		return dtos.Hold{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hold, nil
+}
+
+// HasActiveHold reports whether the order is reserved by a hold which is not captured, voided or expired.
+func (r *DBBalanceRepository) HasActiveHold(ctx context.Context, orderID string) (bool, error) {
+	op := "balanceRepo.hasActiveHold"
+
+	query := `SELECT EXISTS (SELECT 1 FROM balance_holds WHERE order_id = $1 AND status = 'HELD' AND expires_at > LOCALTIMESTAMP)`
+
+	var held bool
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, orderID).Scan(&held)
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return held, nil
+}
+
+func (r *DBBalanceRepository) SetHoldStatus(ctx context.Context, holdID int64, status string) error {
+	op := "balanceRepo.setHoldStatus"
+
+	stmt := table.BalanceHolds.
+		UPDATE(table.BalanceHolds.Status, table.BalanceHolds.UpdatedAt).
+		SET(status, postgres.LOCALTIMESTAMP()).
+		WHERE(table.BalanceHolds.ID.EQ(postgres.Int(holdID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBBalanceRepository) GetHoldsByUser(ctx context.Context, userID int) ([]dtos.Hold, error) {
+	op := "balanceRepo.getHoldsByUser"
+
+	query := `SELECT ` + holdColumns + ` FROM balance_holds WHERE user_id = $1 ORDER BY created_at, id`
 
-	var dest []model.Withdraws
+	result := make([]dtos.Hold, 0)
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, userID)
 
-	result := make([]dtos.Withdraw, len(dest))
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		hold, err := scanHold(rows)
+
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ExpireHolds marks active holds past their expiration as EXPIRED and returns how many were marked.
+func (r *DBBalanceRepository) ExpireHolds(ctx context.Context) (int64, error) {
+	op := "balanceRepo.expireHolds"
+
+	stmt := table.BalanceHolds.
+		UPDATE(table.BalanceHolds.Status, table.BalanceHolds.UpdatedAt).
+		SET(HoldStatusExpired, postgres.LOCALTIMESTAMP()).
+		WHERE(
+			table.BalanceHolds.Status.EQ(postgres.String(HoldStatusHeld)).
+				AND(table.BalanceHolds.ExpiresAt.LT_EQ(postgres.LOCALTIMESTAMP())),
+		)
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expired, err := res.RowsAffected()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, nil
+}
+
+func (r *DBBalanceRepository) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	op := "balanceRepo.getUserIDByLogin"
+
+	stmt := table.Users.SELECT(table.Users.ID).WHERE(table.Users.Login.EQ(postgres.String(login)))
+
+	var dest model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(dest.ID), nil
+}
+
+// GetTransferredToday returns the sum of transfers sent by the user since the start of the current day
+// in the database time zone.
+func (r *DBBalanceRepository) GetTransferredToday(ctx context.Context, userID int) (points.Points, error) {
+	op := "balanceRepo.getTransferredToday"
+
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM point_transfers
+		WHERE sender_id = $1 AND created_at >= date_trunc('day', LOCALTIMESTAMP)
+	`
+
+	var sum int64
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&sum)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return points.FromMinor(sum), nil
+}
+
+// CreateTransfer registers the transfer, the result is seen by the sender. The ledger postings are made
+// by the caller within the same transaction.
+func (r *DBBalanceRepository) CreateTransfer(ctx context.Context, senderID int, recipientID int, amount points.Points) (dtos.Transfer, error) {
+	op := "balanceRepo.createTransfer"
+
+	stmt := table.PointTransfers.
+		INSERT(table.PointTransfers.SenderID, table.PointTransfers.RecipientID, table.PointTransfers.Amount).
+		VALUES(senderID, recipientID, amount.Minor()).
+		RETURNING(table.PointTransfers.AllColumns)
+
+	var dest model.PointTransfers
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return dtos.Transfer{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dtos.Transfer{
+		ID:        dest.ID,
+		Direction: TransferDirectionOutgoing,
+		Amount:    points.FromMinor(dest.Amount),
+		CreatedAt: dest.CreatedAt,
+		UserID:    senderID,
+	}, nil
+}
+
+// GetTransfersByUser returns transfers sent and received by the user, oldest first. The counterparty is empty
+// for transfers with users who deleted their account.
+func (r *DBBalanceRepository) GetTransfersByUser(ctx context.Context, userID int) ([]dtos.Transfer, error) {
+	op := "balanceRepo.getTransfersByUser"
+
+	query := `
+		SELECT
+			t.id,
+			CASE WHEN t.sender_id = $1 THEN 'OUTGOING' ELSE 'INCOMING' END AS direction,
+			COALESCE(u.login, '') AS counterparty,
+			t.amount,
+			t.created_at
+		FROM point_transfers t
+		LEFT JOIN users u ON u.id = CASE WHEN t.sender_id = $1 THEN t.recipient_id ELSE t.sender_id END
+		WHERE t.sender_id = $1 OR t.recipient_id = $1
+		ORDER BY t.created_at, t.id
+	`
+
+	result := make([]dtos.Transfer, 0)
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, userID)
 
 	if err != nil {
 		return result, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for i, entity := range dest {
-		result[i] = mapWithdrawnEntityToDto(entity)
+	defer rows.Close()
+
+	for rows.Next() {
+		var amount int64
+
+		transfer := dtos.Transfer{UserID: userID}
+
+		if err := rows.Scan(&transfer.ID, &transfer.Direction, &transfer.Counterparty, &amount, &transfer.CreatedAt); err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		transfer.Amount = points.FromMinor(amount)
+
+		result = append(result, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return result, nil
 }
 
-func NewDBBalanceRepository(db *sql.DB) *DBBalanceRepository {
-	return &DBBalanceRepository{db}
+// ListTransactions returns withdrawals, transfers and expirations of the user points.
+func (r *DBBalanceRepository) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	op := "balanceRepo.listTransactions"
+
+	source := `
+		SELECT 'WITHDRAWAL' AS type, id::text AS reference, order_id, '' AS counterparty, '' AS status, -amount AS amount, created_at AS occurred_at
+		FROM withdraws
+		WHERE user_id = $1
+		UNION ALL
+		SELECT 'TRANSFER_OUT', t.id::text, '', COALESCE(u.login, ''), '', -t.amount, t.created_at
+		FROM point_transfers t
+		LEFT JOIN users u ON u.id = t.recipient_id
+		WHERE t.sender_id = $1
+		UNION ALL
+		SELECT 'TRANSFER_IN', t.id::text, '', COALESCE(u.login, ''), '', t.amount, t.created_at
+		FROM point_transfers t
+		LEFT JOIN users u ON u.id = t.sender_id
+		WHERE t.recipient_id = $1
+		UNION ALL
+		SELECT 'EXPIRATION', id::text, '', '', '', -amount, created_at
+		FROM ledger_transactions
+		WHERE user_id = $1 AND type = 'EXPIRATION'
+	`
+
+	return queryTransactions(ctx, executorFromContext(ctx, r.db), op, source, filter)
 }
 
-var _ BalanceRepository = &DBBalanceRepository{}
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHold(row rowScanner) (dtos.Hold, error) {
+	var hold dtos.Hold
+	var amount int64
+
+	err := row.Scan(&hold.ID, &hold.UserID, &hold.OrderID, &amount, &hold.Status, &hold.ExpiresAt, &hold.CreatedAt)
 
-func mapWithdrawnEntityToDto(entity model.Withdraws) dtos.Withdraw {
-	return dtos.Withdraw{
-		ID:          int(entity.ID),
-		UserID:      int(entity.UserID),
-		OrderID:     entity.OrderID,
-		Amount:      entity.Amount,
-		ProcessedAt: entity.CreatedAt,
+	if err != nil {
+		return dtos.Hold{}, err
 	}
 
+	hold.Amount = points.FromMinor(amount)
+
+	return hold, nil
 }
+
+func NewDBBalanceRepository(db *sql.DB) *DBBalanceRepository {
+	return &DBBalanceRepository{db}
+}
+
+var _ BalanceRepository = &DBBalanceRepository{}
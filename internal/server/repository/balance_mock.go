@@ -0,0 +1,322 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/balance.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/repository/balance.go -destination=./internal/server/repository/balance_mock.go -package=repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dtos "github.com/sodiqit/gophermart/internal/server/dtos"
+	points "github.com/sodiqit/gophermart/pkg/points"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBalanceRepository is a mock of BalanceRepository interface.
+type MockBalanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepositoryMockRecorder
+}
+
+// MockBalanceRepositoryMockRecorder is the mock recorder for MockBalanceRepository.
+type MockBalanceRepositoryMockRecorder struct {
+	mock *MockBalanceRepository
+}
+
+// NewMockBalanceRepository creates a new mock instance.
+func NewMockBalanceRepository(ctrl *gomock.Controller) *MockBalanceRepository {
+	mock := &MockBalanceRepository{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepository) EXPECT() *MockBalanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockBalanceRepository) CreateHold(ctx context.Context, userID int, orderID string, amount points.Points, ttl time.Duration) (dtos.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, userID, orderID, amount, ttl)
+	ret0, _ := ret[0].(dtos.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockBalanceRepositoryMockRecorder) CreateHold(ctx, userID, orderID, amount, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockBalanceRepository)(nil).CreateHold), ctx, userID, orderID, amount, ttl)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockBalanceRepository) CreateTransfer(ctx context.Context, senderID, recipientID int, amount points.Points) (dtos.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, senderID, recipientID, amount)
+	ret0, _ := ret[0].(dtos.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockBalanceRepositoryMockRecorder) CreateTransfer(ctx, senderID, recipientID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockBalanceRepository)(nil).CreateTransfer), ctx, senderID, recipientID, amount)
+}
+
+// CreateWithdraw mocks base method.
+func (m *MockBalanceRepository) CreateWithdraw(ctx context.Context, userID int, orderID string, sum points.Points) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithdraw", ctx, userID, orderID, sum)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithdraw indicates an expected call of CreateWithdraw.
+func (mr *MockBalanceRepositoryMockRecorder) CreateWithdraw(ctx, userID, orderID, sum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithdraw", reflect.TypeOf((*MockBalanceRepository)(nil).CreateWithdraw), ctx, userID, orderID, sum)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockBalanceRepository) ExpireHolds(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockBalanceRepositoryMockRecorder) ExpireHolds(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockBalanceRepository)(nil).ExpireHolds), ctx)
+}
+
+// GetBalanceAt mocks base method.
+func (m *MockBalanceRepository) GetBalanceAt(ctx context.Context, userID int, at time.Time) (dtos.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAt", ctx, userID, at)
+	ret0, _ := ret[0].(dtos.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAt indicates an expected call of GetBalanceAt.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceAt(ctx, userID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAt", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceAt), ctx, userID, at)
+}
+
+// GetBalanceWithWithdrawals mocks base method.
+func (m *MockBalanceRepository) GetBalanceWithWithdrawals(ctx context.Context, userID int) (dtos.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceWithWithdrawals", ctx, userID)
+	ret0, _ := ret[0].(dtos.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceWithWithdrawals indicates an expected call of GetBalanceWithWithdrawals.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceWithWithdrawals(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceWithWithdrawals", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceWithWithdrawals), ctx, userID)
+}
+
+// GetHoldsByUser mocks base method.
+func (m *MockBalanceRepository) GetHoldsByUser(ctx context.Context, userID int) ([]dtos.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldsByUser", ctx, userID)
+	ret0, _ := ret[0].([]dtos.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldsByUser indicates an expected call of GetHoldsByUser.
+func (mr *MockBalanceRepositoryMockRecorder) GetHoldsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldsByUser", reflect.TypeOf((*MockBalanceRepository)(nil).GetHoldsByUser), ctx, userID)
+}
+
+// GetTransferredToday mocks base method.
+func (m *MockBalanceRepository) GetTransferredToday(ctx context.Context, userID int) (points.Points, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferredToday", ctx, userID)
+	ret0, _ := ret[0].(points.Points)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferredToday indicates an expected call of GetTransferredToday.
+func (mr *MockBalanceRepositoryMockRecorder) GetTransferredToday(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferredToday", reflect.TypeOf((*MockBalanceRepository)(nil).GetTransferredToday), ctx, userID)
+}
+
+// GetTransfersByUser mocks base method.
+func (m *MockBalanceRepository) GetTransfersByUser(ctx context.Context, userID int) ([]dtos.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfersByUser", ctx, userID)
+	ret0, _ := ret[0].([]dtos.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersByUser indicates an expected call of GetTransfersByUser.
+func (mr *MockBalanceRepositoryMockRecorder) GetTransfersByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersByUser", reflect.TypeOf((*MockBalanceRepository)(nil).GetTransfersByUser), ctx, userID)
+}
+
+// GetUserIDByLogin mocks base method.
+func (m *MockBalanceRepository) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByLogin", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByLogin indicates an expected call of GetUserIDByLogin.
+func (mr *MockBalanceRepositoryMockRecorder) GetUserIDByLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByLogin", reflect.TypeOf((*MockBalanceRepository)(nil).GetUserIDByLogin), ctx, login)
+}
+
+// GetWithdrawalsByUser mocks base method.
+func (m *MockBalanceRepository) GetWithdrawalsByUser(ctx context.Context, userID int) ([]dtos.Withdraw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalsByUser", ctx, userID)
+	ret0, _ := ret[0].([]dtos.Withdraw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalsByUser indicates an expected call of GetWithdrawalsByUser.
+func (mr *MockBalanceRepositoryMockRecorder) GetWithdrawalsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalsByUser", reflect.TypeOf((*MockBalanceRepository)(nil).GetWithdrawalsByUser), ctx, userID)
+}
+
+// HasActiveHold mocks base method.
+func (m *MockBalanceRepository) HasActiveHold(ctx context.Context, orderID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveHold", ctx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveHold indicates an expected call of HasActiveHold.
+func (mr *MockBalanceRepositoryMockRecorder) HasActiveHold(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveHold", reflect.TypeOf((*MockBalanceRepository)(nil).HasActiveHold), ctx, orderID)
+}
+
+// ListTransactions mocks base method.
+func (m *MockBalanceRepository) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, filter)
+	ret0, _ := ret[0].([]dtos.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockBalanceRepositoryMockRecorder) ListTransactions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockBalanceRepository)(nil).ListTransactions), ctx, filter)
+}
+
+// LockHold mocks base method.
+func (m *MockBalanceRepository) LockHold(ctx context.Context, userID int, holdID int64) (dtos.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockHold", ctx, userID, holdID)
+	ret0, _ := ret[0].(dtos.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockHold indicates an expected call of LockHold.
+func (mr *MockBalanceRepositoryMockRecorder) LockHold(ctx, userID, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockHold", reflect.TypeOf((*MockBalanceRepository)(nil).LockHold), ctx, userID, holdID)
+}
+
+// LockUserBalance mocks base method.
+func (m *MockBalanceRepository) LockUserBalance(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUserBalance", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUserBalance indicates an expected call of LockUserBalance.
+func (mr *MockBalanceRepositoryMockRecorder) LockUserBalance(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserBalance", reflect.TypeOf((*MockBalanceRepository)(nil).LockUserBalance), ctx, userID)
+}
+
+// SetHoldStatus mocks base method.
+func (m *MockBalanceRepository) SetHoldStatus(ctx context.Context, holdID int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHoldStatus", ctx, holdID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHoldStatus indicates an expected call of SetHoldStatus.
+func (mr *MockBalanceRepositoryMockRecorder) SetHoldStatus(ctx, holdID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHoldStatus", reflect.TypeOf((*MockBalanceRepository)(nil).SetHoldStatus), ctx, holdID, status)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
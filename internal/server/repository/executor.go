@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolationCode = "23505"
+
+type executor interface {
+	qrm.Queryable
+	qrm.Executable
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// executorFromContext returns the transaction started by Transactor if ctx carries one, db otherwise.
+func executorFromContext(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation, e.g. when a concurrent transaction
+// inserted the same key after a NOT EXISTS check.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, userID int, key string, fingerprint string, now time.Time, expiresAt time.Time) (dtos.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, userID int, key string, response dtos.IdempotencyResponse) error
+	Release(ctx context.Context, userID int, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type DBIdempotencyRepository struct {
+	db *sql.DB
+}
+
+// Reserve claims the key for the user. It returns true when the key was claimed by this call: it did not exist
+// or the previous record has expired. Otherwise the stored record is returned.
+func (r *DBIdempotencyRepository) Reserve(ctx context.Context, userID int, key string, fingerprint string, now time.Time, expiresAt time.Time) (dtos.IdempotencyRecord, bool, error) {
+	op := "idempotencyRepo.reserve"
+
+	exec := executorFromContext(ctx, r.db)
+
+	insertStmt := table.IdempotencyKeys.
+		INSERT(table.IdempotencyKeys.UserID, table.IdempotencyKeys.Key, table.IdempotencyKeys.Fingerprint, table.IdempotencyKeys.ExpiresAt).
+		VALUES(userID, key, fingerprint, expiresAt).
+		ON_CONFLICT(table.IdempotencyKeys.UserID, table.IdempotencyKeys.Key).
+		DO_UPDATE(postgres.SET(
+			table.IdempotencyKeys.Fingerprint.SET(table.IdempotencyKeys.EXCLUDED.Fingerprint),
+			table.IdempotencyKeys.StatusCode.SET(table.IdempotencyKeys.EXCLUDED.StatusCode),
+			table.IdempotencyKeys.ContentType.SET(table.IdempotencyKeys.EXCLUDED.ContentType),
+			table.IdempotencyKeys.Body.SET(table.IdempotencyKeys.EXCLUDED.Body),
+			table.IdempotencyKeys.CreatedAt.SET(table.IdempotencyKeys.EXCLUDED.CreatedAt),
+			table.IdempotencyKeys.ExpiresAt.SET(table.IdempotencyKeys.EXCLUDED.ExpiresAt),
+		).WHERE(table.IdempotencyKeys.ExpiresAt.LT_EQ(postgres.TimestampT(now)))).
+		RETURNING(table.IdempotencyKeys.AllColumns)
+
+	var dest model.IdempotencyKeys
+
+	err := insertStmt.QueryContext(ctx, exec, &dest)
+
+	if err == nil {
+		return mapIdempotencyEntityToDto(dest), true, nil
+	}
+
+	if !errors.Is(err, qrm.ErrNoRows) {
+		return dtos.IdempotencyRecord{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	selectStmt := table.IdempotencyKeys.
+		SELECT(table.IdempotencyKeys.AllColumns).
+		WHERE(table.IdempotencyKeys.UserID.EQ(postgres.Int(int64(userID))).AND(table.IdempotencyKeys.Key.EQ(postgres.String(key))))
+
+	err = selectStmt.QueryContext(ctx, exec, &dest)
+
+	if err != nil {
+		return dtos.IdempotencyRecord{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapIdempotencyEntityToDto(dest), false, nil
+}
+
+func (r *DBIdempotencyRepository) Complete(ctx context.Context, userID int, key string, response dtos.IdempotencyResponse) error {
+	op := "idempotencyRepo.complete"
+
+	stmt := table.IdempotencyKeys.
+		UPDATE(table.IdempotencyKeys.StatusCode, table.IdempotencyKeys.ContentType, table.IdempotencyKeys.Body).
+		SET(response.StatusCode, response.ContentType, response.Body).
+		WHERE(table.IdempotencyKeys.UserID.EQ(postgres.Int(int64(userID))).AND(table.IdempotencyKeys.Key.EQ(postgres.String(key))))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Release removes a key whose request has not completed, so the client can retry with the same key.
+func (r *DBIdempotencyRepository) Release(ctx context.Context, userID int, key string) error {
+	op := "idempotencyRepo.release"
+
+	stmt := table.IdempotencyKeys.
+		DELETE().
+		WHERE(
+			table.IdempotencyKeys.UserID.EQ(postgres.Int(int64(userID))).
+				AND(table.IdempotencyKeys.Key.EQ(postgres.String(key))).
+				AND(table.IdempotencyKeys.StatusCode.IS_NULL()),
+		)
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	op := "idempotencyRepo.deleteExpired"
+
+	stmt := table.IdempotencyKeys.
+		DELETE().
+		WHERE(table.IdempotencyKeys.ExpiresAt.LT_EQ(postgres.TimestampT(now)))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+func mapIdempotencyEntityToDto(entity model.IdempotencyKeys) dtos.IdempotencyRecord {
+	record := dtos.IdempotencyRecord{
+		UserID:      int(entity.UserID),
+		Key:         entity.Key,
+		Fingerprint: entity.Fingerprint,
+		ExpiresAt:   entity.ExpiresAt,
+	}
+
+	if entity.StatusCode != nil {
+		response := dtos.IdempotencyResponse{StatusCode: int(*entity.StatusCode)}
+
+		if entity.ContentType != nil {
+			response.ContentType = *entity.ContentType
+		}
+
+		if entity.Body != nil {
+			response.Body = *entity.Body
+		}
+
+		record.Response = &response
+	}
+
+	return record
+}
+
+var _ IdempotencyRepository = (*DBIdempotencyRepository)(nil)
+
+func NewDBIdempotencyRepository(db *sql.DB) *DBIdempotencyRepository {
+	return &DBIdempotencyRepository{db: db}
+}
@@ -0,0 +1,653 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const (
+	LedgerTypeAccrual     = "ACCRUAL"
+	LedgerTypeWithdrawal  = "WITHDRAWAL"
+	LedgerTypeReversal    = "REVERSAL"
+	LedgerTypeAdjustment  = "ADJUSTMENT"
+	LedgerTypeExpiration  = "EXPIRATION"
+	LedgerTypeTransferOut = "TRANSFER_OUT"
+	LedgerTypeTransferIn  = "TRANSFER_IN"
+)
+
+const (
+	LedgerAccountUser        = "USER"
+	LedgerAccountAccruals    = "ACCRUALS"
+	LedgerAccountRedemptions = "REDEMPTIONS"
+	LedgerAccountAdjustments = "ADJUSTMENTS"
+	LedgerAccountExpirations = "EXPIRATIONS"
+	LedgerAccountTransfers   = "TRANSFERS"
+)
+
+const (
+	LedgerDirectionDebit  = "DEBIT"
+	LedgerDirectionCredit = "CREDIT"
+)
+
+var ErrLedgerDuplicatePosting = errors.New("ledger transaction already posted")
+
+type LedgerRepository interface {
+	Post(ctx context.Context, posting dtos.LedgerPosting) (int64, error)
+	Reconcile(ctx context.Context) ([]dtos.LedgerDiscrepancy, error)
+	PostTransfer(ctx context.Context, out dtos.LedgerPosting, in dtos.LedgerPosting) error
+	GetActiveLots(ctx context.Context, userID int) ([]dtos.PointLot, error)
+	GetLotsEarnedBefore(ctx context.Context, earnedBefore time.Time, afterID int64, limit int64) ([]dtos.PointLot, error)
+	ExpireLot(ctx context.Context, lotID int64) (points.Points, error)
+}
+
+type DBLedgerRepository struct {
+	db         *sql.DB
+	transactor Transactor
+}
+
+// Post writes a balanced pair of entries for the posting and applies it to the maintained user balance.
+// A transaction is identified by its type and reference, posting it twice returns ErrLedgerDuplicatePosting.
+// A credit to the user opens a point lot, a debit consumes open lots oldest first. Expirations consume
+// their lot in ExpireLot.
+func (r *DBLedgerRepository) Post(ctx context.Context, posting dtos.LedgerPosting) (int64, error) {
+	op := "ledgerRepo.post"
+
+	var transactionID int64
+
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		transactionID, _, err = r.post(ctx, posting, nil)
+
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return transactionID, nil
+}
+
+// PostTransfer posts both sides of a transfer. The recipient gets lots earned at the time the sender lots consumed
+// by the transfer were, so passing points back and forth does not extend their lifetime.
+func (r *DBLedgerRepository) PostTransfer(ctx context.Context, out dtos.LedgerPosting, in dtos.LedgerPosting) error {
+	op := "ledgerRepo.postTransfer"
+
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, consumed, err := r.post(ctx, out, nil)
+
+		if err != nil {
+			return err
+		}
+
+		_, _, err = r.post(ctx, in, consumed)
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// post applies the posting within the transaction of ctx. A credit opens lots with the earned times of inherited
+// parts, points not covered by them start their lifetime now. A debit returns the parts of lots it consumed.
+func (r *DBLedgerRepository) post(ctx context.Context, posting dtos.LedgerPosting, inherited []lotPart) (int64, []lotPart, error) {
+	exec := executorFromContext(ctx, r.db)
+
+	insertTransaction := table.LedgerTransactions.
+		INSERT(table.LedgerTransactions.Type, table.LedgerTransactions.Reference, table.LedgerTransactions.UserID, table.LedgerTransactions.Amount).
+		VALUES(posting.Type, posting.Reference, posting.UserID, posting.Amount.Minor()).
+		ON_CONFLICT(table.LedgerTransactions.Type, table.LedgerTransactions.Reference).DO_NOTHING().
+		RETURNING(table.LedgerTransactions.ID)
+
+	var transaction model.LedgerTransactions
+
+	err := insertTransaction.QueryContext(ctx, exec, &transaction)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return 0, nil, ErrLedgerDuplicatePosting
+	}
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	insertEntries := table.LedgerEntries.
+		INSERT(table.LedgerEntries.TransactionID, table.LedgerEntries.Account, table.LedgerEntries.UserID, table.LedgerEntries.Direction, table.LedgerEntries.Amount).
+		VALUES(transaction.ID, LedgerAccountUser, posting.UserID, posting.UserDirection, posting.Amount.Minor()).
+		VALUES(transaction.ID, posting.CounterAccount, nil, oppositeDirection(posting.UserDirection), posting.Amount.Minor())
+
+	_, err = insertEntries.ExecContext(ctx, exec)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	current := posting.Amount.Minor()
+	withdrawn := int64(0)
+
+	if posting.UserDirection == LedgerDirectionDebit {
+		current = -current
+	}
+
+	if posting.Type == LedgerTypeWithdrawal {
+		withdrawn = posting.Amount.Minor()
+	}
+
+	upsertBalance := table.UserBalances.
+		INSERT(table.UserBalances.UserID, table.UserBalances.Current, table.UserBalances.Withdrawn).
+		VALUES(posting.UserID, current, withdrawn).
+		ON_CONFLICT(table.UserBalances.UserID).
+		DO_UPDATE(postgres.SET(
+			table.UserBalances.Current.SET(table.UserBalances.Current.ADD(table.UserBalances.EXCLUDED.Current)),
+			table.UserBalances.Withdrawn.SET(table.UserBalances.Withdrawn.ADD(table.UserBalances.EXCLUDED.Withdrawn)),
+			table.UserBalances.UpdatedAt.SET(postgres.LOCALTIMESTAMP()),
+		))
+
+	_, err = upsertBalance.ExecContext(ctx, exec)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if posting.UserDirection == LedgerDirectionCredit {
+		return transaction.ID, nil, r.openLots(ctx, exec, posting.UserID, transaction.ID, posting.Amount, inherited)
+	}
+
+	if posting.Type == LedgerTypeExpiration {
+		return transaction.ID, nil, nil
+	}
+
+	consumed, err := r.consumeLots(ctx, exec, posting.UserID, posting.Amount)
+
+	return transaction.ID, consumed, err
+}
+
+// Reconcile returns transactions whose entries do not balance and users whose maintained balance
+// differs from the sum of their ledger entries.
+func (r *DBLedgerRepository) Reconcile(ctx context.Context) ([]dtos.LedgerDiscrepancy, error) {
+	op := "ledgerRepo.reconcile"
+
+	exec := executorFromContext(ctx, r.db)
+
+	result := make([]dtos.LedgerDiscrepancy, 0)
+
+	transactionsQuery := `
+		SELECT
+			transaction_id,
+			SUM(CASE direction WHEN 'CREDIT' THEN amount ELSE -amount END) AS diff
+		FROM
+			ledger_entries
+		GROUP BY
+			transaction_id
+		HAVING
+			SUM(CASE direction WHEN 'CREDIT' THEN amount ELSE -amount END) <> 0;
+	`
+
+	rows, err := exec.QueryContext(ctx, transactionsQuery)
+
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for rows.Next() {
+		var transactionID, diff int64
+
+		if err := rows.Scan(&transactionID, &diff); err != nil {
+			rows.Close()
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, dtos.LedgerDiscrepancy{TransactionID: transactionID, Actual: points.FromMinor(diff), Reason: "unbalanced transaction"})
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	balancesQuery := `
+		WITH ledger AS (
+			SELECT
+				user_id,
+				SUM(CASE direction WHEN 'CREDIT' THEN amount ELSE -amount END) AS total
+			FROM
+				ledger_entries
+			WHERE
+				account = $1
+			GROUP BY
+				user_id
+		)
+		SELECT
+			b.user_id,
+			COALESCE(l.total, 0) AS expected,
+			b.current AS actual
+		FROM
+			user_balances b
+		LEFT JOIN
+			ledger l ON l.user_id = b.user_id
+		WHERE
+			COALESCE(l.total, 0) <> b.current;
+	`
+
+	rows, err = exec.QueryContext(ctx, balancesQuery, LedgerAccountUser)
+
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var expected, actual int64
+
+		if err := rows.Scan(&userID, &expected, &actual); err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		result = append(result, dtos.LedgerDiscrepancy{UserID: userID, Expected: points.FromMinor(expected), Actual: points.FromMinor(actual), Reason: "user balance differs from ledger"})
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// lotPart is the part of a lot consumed by a debit.
+type lotPart struct {
+	amount   int64
+	earnedAt time.Time
+}
+
+// openLots opens a lot for every inherited part and one earned now for amount not covered by them.
+func (r *DBLedgerRepository) openLots(ctx context.Context, exec executor, userID int, transactionID int64, amount points.Points, inherited []lotPart) error {
+	left := amount.Minor()
+
+	for _, part := range inherited {
+		if part.amount > left {
+			part.amount = left
+		}
+
+		if part.amount <= 0 {
+			continue
+		}
+
+		stmt := table.PointLots.
+			INSERT(table.PointLots.UserID, table.PointLots.TransactionID, table.PointLots.Amount, table.PointLots.Remaining, table.PointLots.EarnedAt).
+			VALUES(userID, transactionID, part.amount, part.amount, part.earnedAt)
+
+		if _, err := stmt.ExecContext(ctx, exec); err != nil {
+			return err
+		}
+
+		left -= part.amount
+	}
+
+	if left == 0 {
+		return nil
+	}
+
+	stmt := table.PointLots.
+		INSERT(table.PointLots.UserID, table.PointLots.TransactionID, table.PointLots.Amount, table.PointLots.Remaining).
+		VALUES(userID, transactionID, left, left)
+
+	_, err := stmt.ExecContext(ctx, exec)
+
+	return err
+}
+
+// consumeLots takes amount from open lots of the user, oldest first, and returns the consumed parts. Amount not
+// covered by lots is left uncovered, it happens when a reversal makes the balance negative.
+func (r *DBLedgerRepository) consumeLots(ctx context.Context, exec executor, userID int, amount points.Points) ([]lotPart, error) {
+	stmt := table.PointLots.
+		SELECT(table.PointLots.ID, table.PointLots.Remaining, table.PointLots.EarnedAt).
+		WHERE(table.PointLots.UserID.EQ(postgres.Int(int64(userID))).AND(table.PointLots.Remaining.GT(postgres.Int(0)))).
+		ORDER_BY(table.PointLots.EarnedAt, table.PointLots.ID).
+		FOR(postgres.UPDATE())
+
+	var lots []model.PointLots
+
+	err := stmt.QueryContext(ctx, exec, &lots)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var consumed []lotPart
+
+	left := amount.Minor()
+
+	for _, lot := range lots {
+		if left == 0 {
+			break
+		}
+
+		part := lot.Remaining
+
+		if part > left {
+			part = left
+		}
+
+		update := table.PointLots.
+			UPDATE(table.PointLots.Remaining).
+			SET(table.PointLots.Remaining.SUB(postgres.Int(part))).
+			WHERE(table.PointLots.ID.EQ(postgres.Int(lot.ID)))
+
+		if _, err := update.ExecContext(ctx, exec); err != nil {
+			return nil, err
+		}
+
+		consumed = append(consumed, lotPart{amount: part, earnedAt: lot.EarnedAt})
+
+		left -= part
+	}
+
+	return consumed, nil
+}
+
+// GetActiveLots returns lots of the user with remaining points, oldest first.
+func (r *DBLedgerRepository) GetActiveLots(ctx context.Context, userID int) ([]dtos.PointLot, error) {
+	op := "ledgerRepo.getActiveLots"
+
+	stmt := table.PointLots.
+		SELECT(table.PointLots.AllColumns).
+		WHERE(table.PointLots.UserID.EQ(postgres.Int(int64(userID))).AND(table.PointLots.Remaining.GT(postgres.Int(0)))).
+		ORDER_BY(table.PointLots.EarnedAt, table.PointLots.ID)
+
+	var dest []model.PointLots
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.PointLot, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapPointLotEntitiesToDto(dest), nil
+}
+
+// GetLotsEarnedBefore returns lots of all users with remaining points earned before the given time, ordered by ID
+// after the lot with afterID. Lots partly reserved by holds stay open, the cursor lets a run pass over them.
+func (r *DBLedgerRepository) GetLotsEarnedBefore(ctx context.Context, earnedBefore time.Time, afterID int64, limit int64) ([]dtos.PointLot, error) {
+	op := "ledgerRepo.getLotsEarnedBefore"
+
+	stmt := table.PointLots.
+		SELECT(table.PointLots.AllColumns).
+		WHERE(
+			table.PointLots.Remaining.GT(postgres.Int(0)).
+				AND(table.PointLots.EarnedAt.LT(postgres.TimestampT(earnedBefore))).
+				AND(table.PointLots.ID.GT(postgres.Int(afterID))),
+		).
+		ORDER_BY(table.PointLots.ID).
+		LIMIT(limit)
+
+	var dest []model.PointLots
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.PointLot, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapPointLotEntitiesToDto(dest), nil
+}
+
+// ExpireLot posts an expiration of the remaining points of the lot. The expired amount is capped by the available
+// user balance, so expiration never makes it negative or breaks an active hold. Points left by the cap stay in the lot
+// and expire in a later run once the hold is voided or expired, a lot is closed when nothing remains.
+// It returns the expired amount.
+func (r *DBLedgerRepository) ExpireLot(ctx context.Context, lotID int64) (points.Points, error) {
+	op := "ledgerRepo.expireLot"
+
+	var expired points.Points
+
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		exec := executorFromContext(ctx, r.db)
+
+		lotStmt := table.PointLots.
+			SELECT(table.PointLots.AllColumns).
+			WHERE(table.PointLots.ID.EQ(postgres.Int(lotID)))
+
+		var lot model.PointLots
+
+		if err := lotStmt.QueryContext(ctx, exec, &lot); err != nil {
+			return err
+		}
+
+		// lock the user first, as withdrawals do, then the lot
+		lockUser := table.Users.
+			SELECT(table.Users.ID).
+			WHERE(table.Users.ID.EQ(postgres.Int(int64(lot.UserID)))).
+			FOR(postgres.NO_KEY_UPDATE())
+
+		var user model.Users
+
+		if err := lockUser.QueryContext(ctx, exec, &user); err != nil {
+			return err
+		}
+
+		lockLot := table.PointLots.
+			SELECT(table.PointLots.AllColumns).
+			WHERE(table.PointLots.ID.EQ(postgres.Int(lotID))).
+			FOR(postgres.UPDATE())
+
+		if err := lockLot.QueryContext(ctx, exec, &lot); err != nil {
+			return err
+		}
+
+		if lot.Remaining == 0 {
+			return nil
+		}
+
+		// points reserved by active holds are not expired while the hold is active
+		balanceQuery := `
+			SELECT
+				COALESCE((SELECT current FROM user_balances WHERE user_id = $1), 0),
+				COALESCE((SELECT SUM(amount) FROM balance_holds WHERE user_id = $1 AND status = 'HELD' AND expires_at > LOCALTIMESTAMP), 0);
+		`
+
+		var current, held int64
+
+		err := exec.QueryRowContext(ctx, balanceQuery, lot.UserID).Scan(&current, &held)
+
+		if err != nil {
+			return err
+		}
+
+		amount, remaining := splitExpiringLot(lot.Remaining, current, held)
+
+		if remaining == lot.Remaining {
+			return nil
+		}
+
+		var expireLot postgres.UpdateStatement
+
+		if remaining == 0 {
+			expireLot = table.PointLots.
+				UPDATE(table.PointLots.Remaining, table.PointLots.ExpiredAt).
+				SET(0, postgres.LOCALTIMESTAMP()).
+				WHERE(table.PointLots.ID.EQ(postgres.Int(lot.ID)))
+		} else {
+			expireLot = table.PointLots.
+				UPDATE(table.PointLots.Remaining).
+				SET(remaining).
+				WHERE(table.PointLots.ID.EQ(postgres.Int(lot.ID)))
+		}
+
+		if _, err := expireLot.ExecContext(ctx, exec); err != nil {
+			return err
+		}
+
+		if amount == 0 {
+			return nil
+		}
+
+		expired = points.FromMinor(amount)
+
+		_, err = r.Post(ctx, NewExpirationPosting(int(lot.UserID), lot.ID, points.FromMinor(lot.Remaining), expired))
+
+		return err
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, nil
+}
+
+// splitExpiringLot returns the amount to expire from the remaining points of a lot and the points left in the lot.
+// Points of the lot above the current balance are not backed by the balance any more, they are dropped without
+// an expiration. Points reserved by holds are left in the lot.
+func splitExpiringLot(remaining int64, current int64, held int64) (int64, int64) {
+	backed := remaining
+
+	if backed > current {
+		backed = current
+	}
+
+	if backed < 0 {
+		backed = 0
+	}
+
+	amount := backed
+
+	if amount > current-held {
+		amount = current - held
+	}
+
+	if amount < 0 {
+		amount = 0
+	}
+
+	return amount, backed - amount
+}
+
+func mapPointLotEntitiesToDto(entities []model.PointLots) []dtos.PointLot {
+	result := make([]dtos.PointLot, len(entities))
+
+	for i, entity := range entities {
+		result[i] = dtos.PointLot{
+			ID:        entity.ID,
+			UserID:    int(entity.UserID),
+			Amount:    points.FromMinor(entity.Amount),
+			Remaining: points.FromMinor(entity.Remaining),
+			EarnedAt:  entity.EarnedAt,
+		}
+	}
+
+	return result
+}
+
+func NewAccrualPosting(userID int, orderID string, amount points.Points) dtos.LedgerPosting {
+	return dtos.LedgerPosting{
+		Type:           LedgerTypeAccrual,
+		Reference:      orderID,
+		UserID:         userID,
+		Amount:         amount,
+		UserDirection:  LedgerDirectionCredit,
+		CounterAccount: LedgerAccountAccruals,
+	}
+}
+
+func NewWithdrawalPosting(userID int, orderID string, amount points.Points) dtos.LedgerPosting {
+	return dtos.LedgerPosting{
+		Type:           LedgerTypeWithdrawal,
+		Reference:      orderID,
+		UserID:         userID,
+		Amount:         amount,
+		UserDirection:  LedgerDirectionDebit,
+		CounterAccount: LedgerAccountRedemptions,
+	}
+}
+
+// NewAccrualAdjustmentPosting compensates a change of an already posted order accrual. A negative delta is
+// posted as a reversal debiting the user, a positive one as an adjustment crediting the user.
+func NewAccrualAdjustmentPosting(userID int, orderID string, adjustmentID int64, delta points.Points) dtos.LedgerPosting {
+	posting := dtos.LedgerPosting{
+		Type:           LedgerTypeAdjustment,
+		Reference:      fmt.Sprintf("%s:%d", orderID, adjustmentID),
+		UserID:         userID,
+		Amount:         delta,
+		UserDirection:  LedgerDirectionCredit,
+		CounterAccount: LedgerAccountAccruals,
+	}
+
+	if delta < 0 {
+		posting.Type = LedgerTypeReversal
+		posting.Amount = -delta
+		posting.UserDirection = LedgerDirectionDebit
+	}
+
+	return posting
+}
+
+// NewExpirationPosting expires amount of the lot. A lot partly reserved by holds expires in parts, the remaining
+// points of the lot before the expiration tell the parts apart.
+func NewExpirationPosting(userID int, lotID int64, remaining points.Points, amount points.Points) dtos.LedgerPosting {
+	return dtos.LedgerPosting{
+		Type:           LedgerTypeExpiration,
+		Reference:      fmt.Sprintf("lot:%d:%d", lotID, remaining.Minor()),
+		UserID:         userID,
+		Amount:         amount,
+		UserDirection:  LedgerDirectionDebit,
+		CounterAccount: LedgerAccountExpirations,
+	}
+}
+
+// NewTransferPostings returns the debit of the sender and the credit of the recipient for the transfer, see PostTransfer.
+// Both go through the TRANSFERS clearing account, so it nets to zero once both are posted.
+func NewTransferPostings(senderID int, recipientID int, transferID int64, amount points.Points) (dtos.LedgerPosting, dtos.LedgerPosting) {
+	reference := fmt.Sprintf("transfer:%d", transferID)
+
+	out := dtos.LedgerPosting{
+		Type:           LedgerTypeTransferOut,
+		Reference:      reference,
+		UserID:         senderID,
+		Amount:         amount,
+		UserDirection:  LedgerDirectionDebit,
+		CounterAccount: LedgerAccountTransfers,
+	}
+
+	in := dtos.LedgerPosting{
+		Type:           LedgerTypeTransferIn,
+		Reference:      reference,
+		UserID:         recipientID,
+		Amount:         amount,
+		UserDirection:  LedgerDirectionCredit,
+		CounterAccount: LedgerAccountTransfers,
+	}
+
+	return out, in
+}
+
+func oppositeDirection(direction string) string {
+	if direction == LedgerDirectionCredit {
+		return LedgerDirectionDebit
+	}
+
+	return LedgerDirectionCredit
+}
+
+var _ LedgerRepository = (*DBLedgerRepository)(nil)
+
+func NewDBLedgerRepository(db *sql.DB) *DBLedgerRepository {
+	return &DBLedgerRepository{db: db, transactor: NewDBTransactor(db)}
+}
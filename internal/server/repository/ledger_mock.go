@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/ledger.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/server/repository/ledger.go -destination=./internal/server/repository/ledger_mock.go -package=repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dtos "github.com/sodiqit/gophermart/internal/server/dtos"
+	points "github.com/sodiqit/gophermart/pkg/points"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExpireLot mocks base method.
+func (m *MockLedgerRepository) ExpireLot(ctx context.Context, lotID int64) (points.Points, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireLot", ctx, lotID)
+	ret0, _ := ret[0].(points.Points)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireLot indicates an expected call of ExpireLot.
+func (mr *MockLedgerRepositoryMockRecorder) ExpireLot(ctx, lotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireLot", reflect.TypeOf((*MockLedgerRepository)(nil).ExpireLot), ctx, lotID)
+}
+
+// GetActiveLots mocks base method.
+func (m *MockLedgerRepository) GetActiveLots(ctx context.Context, userID int) ([]dtos.PointLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveLots", ctx, userID)
+	ret0, _ := ret[0].([]dtos.PointLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveLots indicates an expected call of GetActiveLots.
+func (mr *MockLedgerRepositoryMockRecorder) GetActiveLots(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveLots", reflect.TypeOf((*MockLedgerRepository)(nil).GetActiveLots), ctx, userID)
+}
+
+// GetLotsEarnedBefore mocks base method.
+func (m *MockLedgerRepository) GetLotsEarnedBefore(ctx context.Context, earnedBefore time.Time, afterID, limit int64) ([]dtos.PointLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLotsEarnedBefore", ctx, earnedBefore, afterID, limit)
+	ret0, _ := ret[0].([]dtos.PointLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLotsEarnedBefore indicates an expected call of GetLotsEarnedBefore.
+func (mr *MockLedgerRepositoryMockRecorder) GetLotsEarnedBefore(ctx, earnedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLotsEarnedBefore", reflect.TypeOf((*MockLedgerRepository)(nil).GetLotsEarnedBefore), ctx, earnedBefore, afterID, limit)
+}
+
+// Post mocks base method.
+func (m *MockLedgerRepository) Post(ctx context.Context, posting dtos.LedgerPosting) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, posting)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerRepositoryMockRecorder) Post(ctx, posting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgerRepository)(nil).Post), ctx, posting)
+}
+
+// PostTransfer mocks base method.
+func (m *MockLedgerRepository) PostTransfer(ctx context.Context, out, in dtos.LedgerPosting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTransfer", ctx, out, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostTransfer indicates an expected call of PostTransfer.
+func (mr *MockLedgerRepositoryMockRecorder) PostTransfer(ctx, out, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTransfer", reflect.TypeOf((*MockLedgerRepository)(nil).PostTransfer), ctx, out, in)
+}
+
+// Reconcile mocks base method.
+func (m *MockLedgerRepository) Reconcile(ctx context.Context) ([]dtos.LedgerDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].([]dtos.LedgerDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockLedgerRepositoryMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockLedgerRepository)(nil).Reconcile), ctx)
+}
@@ -5,15 +5,18 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/qrm"
 	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
 	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
 	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/pkg/points"
 )
 
 var ErrOrderNotFound = errors.New("order not found")
+var ErrOrderFinalized = errors.New("order already has a final status")
 
 const (
 	OrderStatusNew        = "NEW"
@@ -22,34 +25,68 @@ const (
 	OrderStatusProcessed  = "PROCESSED"
 )
 
+const (
+	OrderStatusSourceUpload  = "UPLOAD"
+	OrderStatusSourceAccrual = "ACCRUAL"
+	OrderStatusSourceRecheck = "RECHECK"
+)
+
+const (
+	OrderAdjustmentReasonChanged = "CHANGED"
+	OrderAdjustmentReasonRevoked = "REVOKED"
+)
+
 type OrderRepository interface {
 	Create(ctx context.Context, userID int, orderNumber string, status string) (string, error)
 	FindByOrderNumber(ctx context.Context, orderNumber string) (dtos.Order, error)
 	GetListByUser(ctx context.Context, userID int) ([]dtos.Order, error)
-	GetOrdersForProcessing(ctx context.Context, pool int64) ([]string, error)
-	UpdateOrder(ctx context.Context, orderID string, status string, accrual *float64) error
+	ClaimOrdersForProcessing(ctx context.Context, owner string, leaseTTL time.Duration, pushTimeout time.Duration, limit int64) ([]string, error)
+	MarkOrderPushed(ctx context.Context, orderID string) error
+	ReleaseOrder(ctx context.Context, orderID string, owner string) error
+	ScheduleRetry(ctx context.Context, orderID string, owner string, policy dtos.RetryPolicy, lastError string) (bool, error)
+	GetDeadLetterOrders(ctx context.Context, limit int64, offset int64) ([]dtos.DeadLetterOrder, error)
+	RequeueOrder(ctx context.Context, orderID string) error
+	UpdateOrder(ctx context.Context, orderID string, status string, accrual *points.Points) error
+	GetOrdersForRecheck(ctx context.Context, window time.Duration, staleAfter time.Duration, limit int64) ([]string, error)
+	LockOrder(ctx context.Context, orderID string) (dtos.Order, error)
+	SetAccrual(ctx context.Context, orderID string, status string, accrual *points.Points) error
+	MarkAccrualChecked(ctx context.Context, orderID string) error
+	CreateAdjustment(ctx context.Context, adjustment dtos.OrderAdjustment) (int64, error)
+	ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error)
+	CreateStatusChange(ctx context.Context, change dtos.OrderStatusChange) error
+	GetStatusHistory(ctx context.Context, orderID string) ([]dtos.OrderStatusChange, error)
+	GetUserStatusChanges(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error)
+	GetLastUserStatusChangeSeq(ctx context.Context, userID int) (int64, error)
 }
 
 type DBOrderRepository struct {
 	db *sql.DB
 }
 
+// Create inserts the order and records its upload as the first status transition in the same statement.
 func (r *DBOrderRepository) Create(ctx context.Context, userID int, orderNumber string, status string) (string, error) {
 	op := "orderRepo.create"
 
-	stmt := table.Orders.INSERT(table.Orders.ID, table.Orders.UserID, table.Orders.Status).
-		VALUES(orderNumber, userID, status).
-		RETURNING(table.Orders.ID)
+	query := `
+		WITH created AS (
+			INSERT INTO orders (id, user_id, status) VALUES ($1, $2, $3) RETURNING id, user_id, status
+		), seq AS (
+			` + nextStatusChangeSeqQuery + ` WHERE id = $2 RETURNING status_change_seq
+		)
+		INSERT INTO order_status_history (order_id, user_id, seq, to_status, source)
+		SELECT created.id, created.user_id, seq.status_change_seq, created.status, $4 FROM created, seq
+		RETURNING order_id
+	`
 
-	var dest model.Orders
+	var id string
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, orderNumber, userID, status, OrderStatusSourceUpload).Scan(&id)
 
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return dest.ID, nil
+	return id, nil
 }
 
 func (r *DBOrderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (dtos.Order, error) {
@@ -59,7 +96,7 @@ func (r *DBOrderRepository) FindByOrderNumber(ctx context.Context, orderNumber s
 
 	var dest model.Orders
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
 
 	if err != nil && errors.Is(qrm.ErrNoRows, err) {
 		return dtos.Order{}, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
@@ -79,7 +116,7 @@ func (r *DBOrderRepository) GetListByUser(ctx context.Context, userID int) ([]dt
 
 	var dest []model.Orders
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
 
 	if err != nil {
 		return make([]dtos.Order, 0), fmt.Errorf("%s: %w", op, err)
@@ -87,25 +124,281 @@ func (r *DBOrderRepository) GetListByUser(ctx context.Context, userID int) ([]dt
 
 	result := make([]dtos.Order, len(dest))
 
+	adjustmentsStmt := table.OrderAdjustments.
+		SELECT(table.OrderAdjustments.AllColumns).
+		WHERE(table.OrderAdjustments.UserID.EQ(postgres.Int(int64(userID)))).
+		ORDER_BY(table.OrderAdjustments.ID)
+
+	var adjustments []model.OrderAdjustments
+
+	err = adjustmentsStmt.QueryContext(ctx, executorFromContext(ctx, r.db), &adjustments)
+
+	if err != nil {
+		return make([]dtos.Order, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	adjustmentsByOrder := make(map[string][]dtos.OrderAdjustment)
+
+	for _, entity := range adjustments {
+		adjustmentsByOrder[entity.OrderID] = append(adjustmentsByOrder[entity.OrderID], mapOrderAdjustmentEntityToDto(entity))
+	}
+
 	for i, entity := range dest {
 		result[i] = mapOrderEntityToDto(entity)
+		result[i].Adjustments = adjustmentsByOrder[entity.ID]
+	}
+
+	return result, nil
+}
+
+// ClaimOrdersForProcessing leases up to limit orders still being processed to owner for leaseTTL, oldest first.
+// Orders leased by other owners are skipped until their lease expires, so an order is polled by one processor
+// at a time. Rows locked by a concurrent claim are skipped instead of waited for. Orders waiting for their next
+// attempt and dead-lettered orders are not claimed. With positive pushTimeout orders are claimed only when
+// the accrual system has not pushed them for pushTimeout since they were queued or last pushed.
+func (r *DBOrderRepository) ClaimOrdersForProcessing(ctx context.Context, owner string, leaseTTL time.Duration, pushTimeout time.Duration, limit int64) ([]string, error) {
+	op := "orderRepo.claimOrdersForProcessing"
+
+	now := postgres.LOCALTIMESTAMP()
+
+	condition := table.Orders.Status.IN(postgres.String(OrderStatusNew), postgres.String(OrderStatusProcessing)).
+		AND(table.Orders.LeaseExpiresAt.IS_NULL().OR(table.Orders.LeaseExpiresAt.LT_EQ(now))).
+		AND(table.Orders.NextAttemptAt.IS_NULL().OR(table.Orders.NextAttemptAt.LT_EQ(now))).
+		AND(table.Orders.DeadLetteredAt.IS_NULL())
+
+	if pushTimeout > 0 {
+		lastActivity := postgres.TimestampExp(postgres.COALESCE(table.Orders.PushedAt, table.Orders.QueuedAt))
+		condition = condition.AND(lastActivity.LT_EQ(now.SUB(postgres.INTERVALd(pushTimeout))))
+	}
+
+	claimable := table.Orders.SELECT(table.Orders.ID).
+		WHERE(condition).
+		ORDER_BY(table.Orders.CreatedAt.ASC()).
+		LIMIT(limit).
+		FOR(postgres.UPDATE().SKIP_LOCKED())
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.LeaseOwner, table.Orders.LeaseExpiresAt).
+		SET(owner, now.ADD(postgres.INTERVALd(leaseTTL))).
+		WHERE(table.Orders.ID.IN(claimable)).
+		RETURNING(table.Orders.ID)
+
+	var dest []model.Orders
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]string, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]string, len(dest))
+
+	for i, entity := range dest {
+		result[i] = entity.ID
+	}
+
+	return result, nil
+}
+
+// ReleaseOrder ends the lease of owner on the order, so it can be claimed again right away.
+// A lease that has expired and was claimed by another owner is left untouched.
+func (r *DBOrderRepository) ReleaseOrder(ctx context.Context, orderID string, owner string) error {
+	op := "orderRepo.releaseOrder"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.LeaseOwner, table.Orders.LeaseExpiresAt).
+		SET(postgres.NULL, postgres.NULL).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID)).AND(table.Orders.LeaseOwner.EQ(postgres.String(owner))))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkOrderPushed records that the accrual system pushed the order, which postpones polling it.
+// ErrOrderNotFound is returned for unknown orders.
+func (r *DBOrderRepository) MarkOrderPushed(ctx context.Context, orderID string) error {
+	op := "orderRepo.markOrderPushed"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.PushedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID)))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	return nil
+}
+
+// ScheduleRetry ends the lease of owner on an order the accrual system has not resolved yet and postpones
+// its next attempt by the policy backoff. Once the order is queued for longer than the policy max age it is
+// dead-lettered instead. It returns whether the order was dead-lettered. An order leased by another owner
+// is left untouched.
+func (r *DBOrderRepository) ScheduleRetry(ctx context.Context, orderID string, owner string, policy dtos.RetryPolicy, lastError string) (bool, error) {
+	op := "orderRepo.scheduleRetry"
+
+	// attempts in SET refers to the value before the update, so the first retry waits for the base delay
+	query := `
+		UPDATE orders SET
+			attempts = attempts + 1,
+			next_attempt_at = LOCALTIMESTAMP + LEAST($3::bigint * power(2, LEAST(attempts, 30))::bigint, $4::bigint) * INTERVAL '1 microsecond',
+			dead_lettered_at = CASE WHEN $5::bigint > 0 AND queued_at <= LOCALTIMESTAMP - $5::bigint * INTERVAL '1 microsecond' THEN LOCALTIMESTAMP END,
+			last_error = $6,
+			lease_owner = NULL,
+			lease_expires_at = NULL
+		WHERE id = $1 AND lease_owner = $2 AND status IN ('NEW', 'PROCESSING')
+		RETURNING dead_lettered_at IS NOT NULL
+	`
+
+	var deadLettered bool
+
+	err := executorFromContext(ctx, r.db).
+		QueryRowContext(ctx, query, orderID, owner, policy.BaseDelay.Microseconds(), policy.MaxDelay.Microseconds(), policy.MaxAge.Microseconds(), lastError).
+		Scan(&deadLettered)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deadLettered, nil
+}
+
+// GetDeadLetterOrders returns dead-lettered orders, most recently dead-lettered first.
+func (r *DBOrderRepository) GetDeadLetterOrders(ctx context.Context, limit int64, offset int64) ([]dtos.DeadLetterOrder, error) {
+	op := "orderRepo.getDeadLetterOrders"
+
+	stmt := table.Orders.SELECT(
+		table.Orders.ID,
+		table.Orders.UserID,
+		table.Orders.Status,
+		table.Orders.Attempts,
+		table.Orders.LastError,
+		table.Orders.CreatedAt,
+		table.Orders.QueuedAt,
+		table.Orders.DeadLetteredAt,
+	).
+		WHERE(table.Orders.DeadLetteredAt.IS_NOT_NULL()).
+		ORDER_BY(table.Orders.DeadLetteredAt.DESC(), table.Orders.ID).
+		LIMIT(limit).
+		OFFSET(offset)
+
+	var dest []model.Orders
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.DeadLetterOrder, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dtos.DeadLetterOrder, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapDeadLetterOrderEntityToDto(entity)
 	}
 
 	return result, nil
 }
 
-func (r *DBOrderRepository) GetOrdersForProcessing(ctx context.Context, pool int64) ([]string, error) {
-	op := "orderRepo.getOrdersForProcessing"
+// RequeueOrder puts a dead-lettered order back into processing with a fresh attempt counter and max age.
+// ErrOrderNotFound is returned when there is no such dead-lettered order.
+func (r *DBOrderRepository) RequeueOrder(ctx context.Context, orderID string) error {
+	op := "orderRepo.requeueOrder"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.Attempts, table.Orders.NextAttemptAt, table.Orders.QueuedAt, table.Orders.DeadLetteredAt, table.Orders.LastError).
+		SET(0, postgres.NULL, postgres.LOCALTIMESTAMP(), postgres.NULL, postgres.NULL).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID)).AND(table.Orders.DeadLetteredAt.IS_NOT_NULL()))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	return nil
+}
+
+// UpdateOrder moves an order that is still being processed forward. Orders with a final status are changed
+// only through SetAccrual, for them ErrOrderFinalized is returned.
+func (r *DBOrderRepository) UpdateOrder(ctx context.Context, orderID string, status string, accrual *points.Points) error {
+	op := "orderRepo.updateOrder"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.Status, table.Orders.Accrual, table.Orders.UpdatedAt).
+		SET(status, accrualToMinor(accrual), postgres.LOCALTIMESTAMP()).
+		WHERE(
+			table.Orders.ID.EQ(postgres.String(orderID)).
+				AND(table.Orders.Status.IN(postgres.String(OrderStatusNew), postgres.String(OrderStatusProcessing))),
+		)
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("%s: %w", op, ErrOrderFinalized)
+	}
+
+	return nil
+}
+
+// GetOrdersForRecheck returns orders processed within the window whose accrual was not checked for
+// staleAfter, least recently checked first.
+func (r *DBOrderRepository) GetOrdersForRecheck(ctx context.Context, window time.Duration, staleAfter time.Duration, limit int64) ([]string, error) {
+	op := "orderRepo.getOrdersForRecheck"
 
 	stmt := table.Orders.SELECT(table.Orders.ID).
 		WHERE(
-			table.Orders.Status.IN(postgres.String(OrderStatusNew), postgres.String(OrderStatusProcessing)),
+			table.Orders.Status.EQ(postgres.String(OrderStatusProcessed)).
+				AND(table.Orders.UpdatedAt.GT_EQ(postgres.LOCALTIMESTAMP().SUB(postgres.INTERVALd(window)))).
+				AND(table.Orders.AccrualCheckedAt.IS_NULL().OR(table.Orders.AccrualCheckedAt.LT(postgres.LOCALTIMESTAMP().SUB(postgres.INTERVALd(staleAfter))))),
 		).
-		LIMIT(pool)
+		ORDER_BY(table.Orders.AccrualCheckedAt.ASC().NULLS_FIRST()).
+		LIMIT(limit)
 
 	var dest []model.Orders
 
-	err := stmt.QueryContext(ctx, r.db, &dest)
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
 
 	if err != nil {
 		return make([]string, 0), fmt.Errorf("%s: %w", op, err)
@@ -120,19 +413,270 @@ func (r *DBOrderRepository) GetOrdersForProcessing(ctx context.Context, pool int
 	return result, nil
 }
 
-func (r *DBOrderRepository) UpdateOrder(ctx context.Context, orderID string, status string, accrual *float64) error {
-	stmt := table.Orders.UPDATE(table.Orders.Status, table.Orders.Accrual).SET(status, accrual).WHERE(table.Orders.ID.EQ(postgres.String(orderID)))
+// LockOrder returns the order and locks it until the end of the current transaction.
+// It must be called within Transactor.WithinTransaction.
+func (r *DBOrderRepository) LockOrder(ctx context.Context, orderID string) (dtos.Order, error) {
+	op := "orderRepo.lockOrder"
 
-	_, err := stmt.ExecContext(ctx, r.db)
+	stmt := table.Orders.SELECT(table.Orders.ID, table.Orders.UserID, table.Orders.Accrual, table.Orders.Status, table.Orders.CreatedAt, table.Orders.UpdatedAt).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID))).
+		FOR(postgres.UPDATE())
 
-	return err
+	var dest model.Orders
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return dtos.Order{}, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	if err != nil {
+		return dtos.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapOrderEntityToDto(dest), nil
+}
+
+// SetAccrual overwrites status and accrual of an order regardless of its status and marks the accrual checked.
+// It is used by the reversal flow, which records the change with CreateAdjustment.
+func (r *DBOrderRepository) SetAccrual(ctx context.Context, orderID string, status string, accrual *points.Points) error {
+	op := "orderRepo.setAccrual"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.Status, table.Orders.Accrual, table.Orders.AccrualCheckedAt).
+		SET(status, accrualToMinor(accrual), postgres.LOCALTIMESTAMP()).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBOrderRepository) MarkAccrualChecked(ctx context.Context, orderID string) error {
+	op := "orderRepo.markAccrualChecked"
+
+	stmt := table.Orders.
+		UPDATE(table.Orders.AccrualCheckedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(table.Orders.ID.EQ(postgres.String(orderID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBOrderRepository) CreateAdjustment(ctx context.Context, adjustment dtos.OrderAdjustment) (int64, error) {
+	op := "orderRepo.createAdjustment"
+
+	stmt := table.OrderAdjustments.
+		INSERT(table.OrderAdjustments.OrderID, table.OrderAdjustments.UserID, table.OrderAdjustments.PreviousAccrual, table.OrderAdjustments.Accrual, table.OrderAdjustments.Amount, table.OrderAdjustments.Reason).
+		VALUES(adjustment.OrderID, adjustment.UserID, adjustment.PreviousAccrual.Minor(), adjustment.Accrual.Minor(), adjustment.Amount.Minor(), adjustment.Reason).
+		RETURNING(table.OrderAdjustments.ID)
+
+	var dest model.OrderAdjustments
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dest.ID, nil
+}
+
+// nextStatusChangeSeqQuery takes the next seq of the status transitions of a user. The user row stays locked until
+// the transaction commits, so transitions of the user commit in seq order and streams resume after a seq safely.
+const nextStatusChangeSeqQuery = `UPDATE users SET status_change_seq = status_change_seq + 1`
+
+// CreateStatusChange records the transition with the next seq of the order owner.
+func (r *DBOrderRepository) CreateStatusChange(ctx context.Context, change dtos.OrderStatusChange) error {
+	op := "orderRepo.createStatusChange"
+
+	var fromStatus *string
+
+	if change.FromStatus != "" {
+		fromStatus = &change.FromStatus
+	}
+
+	query := `
+		WITH seq AS (
+			` + nextStatusChangeSeqQuery + ` WHERE id = (SELECT user_id FROM orders WHERE id = $1) RETURNING id, status_change_seq
+		)
+		INSERT INTO order_status_history (order_id, user_id, seq, from_status, to_status, accrual, source)
+		SELECT $1, id, status_change_seq, $2, $3, $4, $5 FROM seq
+	`
+
+	res, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, change.OrderID, fromStatus, change.Status, accrualToMinor(change.Accrual), change.Source)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	created, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if created == 0 {
+		return fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	return nil
+}
+
+// GetStatusHistory returns the status transitions of the order, oldest first.
+func (r *DBOrderRepository) GetStatusHistory(ctx context.Context, orderID string) ([]dtos.OrderStatusChange, error) {
+	op := "orderRepo.getStatusHistory"
+
+	stmt := table.OrderStatusHistory.
+		SELECT(table.OrderStatusHistory.AllColumns).
+		WHERE(table.OrderStatusHistory.OrderID.EQ(postgres.String(orderID))).
+		ORDER_BY(table.OrderStatusHistory.ID)
+
+	var dest []model.OrderStatusHistory
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.OrderStatusChange, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dtos.OrderStatusChange, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapOrderStatusChangeEntityToDto(entity)
+	}
+
+	return result, nil
+}
+
+// GetUserStatusChanges returns up to limit status transitions of the user orders with seq after afterSeq, oldest first.
+func (r *DBOrderRepository) GetUserStatusChanges(ctx context.Context, userID int, afterSeq int64, limit int64) ([]dtos.OrderStatusChange, error) {
+	op := "orderRepo.getUserStatusChanges"
+
+	stmt := table.OrderStatusHistory.
+		SELECT(table.OrderStatusHistory.AllColumns).
+		WHERE(
+			table.OrderStatusHistory.UserID.EQ(postgres.Int(int64(userID))).
+				AND(table.OrderStatusHistory.Seq.GT(postgres.Int64(afterSeq))),
+		).
+		ORDER_BY(table.OrderStatusHistory.Seq).
+		LIMIT(limit)
+
+	var dest []model.OrderStatusHistory
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.OrderStatusChange, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dtos.OrderStatusChange, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapOrderStatusChangeEntityToDto(entity)
+	}
+
+	return result, nil
+}
+
+// GetLastUserStatusChangeSeq returns the seq of the latest committed status transition of the user orders,
+// zero if there are none.
+func (r *DBOrderRepository) GetLastUserStatusChangeSeq(ctx context.Context, userID int) (int64, error) {
+	op := "orderRepo.getLastUserStatusChangeSeq"
+
+	stmt := table.Users.
+		SELECT(table.Users.StatusChangeSeq).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	var dest model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dest.StatusChangeSeq, nil
+}
+
+// ListTransactions returns uploads and accruals of the user orders and their later adjustments and reversals.
+// An accrual keeps the amount first credited for the order, changes made by rechecks are separate transactions.
+func (r *DBOrderRepository) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	op := "orderRepo.listTransactions"
+
+	source := `
+		SELECT 'ORDER' AS type, id AS reference, id AS order_id, '' AS counterparty, status, 0::bigint AS amount, created_at AS occurred_at
+		FROM orders
+		WHERE user_id = $1
+		UNION ALL
+		SELECT
+			'ACCRUAL',
+			o.id,
+			o.id,
+			'',
+			o.status,
+			COALESCE(a.previous_accrual, o.accrual) AS amount,
+			o.updated_at AS occurred_at
+		FROM orders o
+		LEFT JOIN LATERAL (
+			SELECT previous_accrual
+			FROM order_adjustments
+			WHERE order_id = o.id
+			ORDER BY created_at, id
+			LIMIT 1
+		) a ON TRUE
+		WHERE
+			o.user_id = $1 AND
+			(a.previous_accrual IS NOT NULL OR o.status = 'PROCESSED') AND
+			COALESCE(a.previous_accrual, o.accrual) > 0
+		UNION ALL
+		SELECT
+			CASE WHEN amount < 0 THEN 'REVERSAL' ELSE 'ADJUSTMENT' END,
+			id::text,
+			order_id,
+			'',
+			'',
+			amount,
+			created_at
+		FROM order_adjustments
+		WHERE user_id = $1
+	`
+
+	return queryTransactions(ctx, executorFromContext(ctx, r.db), op, source, filter)
+}
+
+func accrualToMinor(accrual *points.Points) *int64 {
+	if accrual == nil {
+		return nil
+	}
+
+	minor := accrual.Minor()
+
+	return &minor
 }
 
 func mapOrderEntityToDto(entity model.Orders) dtos.Order {
+	var accrual *points.Points
+
+	if entity.Accrual != nil {
+		value := points.FromMinor(*entity.Accrual)
+		accrual = &value
+	}
+
 	return dtos.Order{
 		ID:        entity.ID,
 		UserID:    int(entity.UserID),
-		Accrual:   entity.Accrual,
+		Accrual:   accrual,
 		Status:    entity.Status,
 		CreatedAt: entity.CreatedAt,
 		UpdatedAt: entity.UpdatedAt,
@@ -140,6 +684,62 @@ func mapOrderEntityToDto(entity model.Orders) dtos.Order {
 
 }
 
+func mapDeadLetterOrderEntityToDto(entity model.Orders) dtos.DeadLetterOrder {
+	order := dtos.DeadLetterOrder{
+		ID:        entity.ID,
+		UserID:    int(entity.UserID),
+		Status:    entity.Status,
+		Attempts:  int(entity.Attempts),
+		CreatedAt: entity.CreatedAt,
+		QueuedAt:  entity.QueuedAt,
+	}
+
+	if entity.LastError != nil {
+		order.LastError = *entity.LastError
+	}
+
+	if entity.DeadLetteredAt != nil {
+		order.DeadLetteredAt = *entity.DeadLetteredAt
+	}
+
+	return order
+}
+
+func mapOrderStatusChangeEntityToDto(entity model.OrderStatusHistory) dtos.OrderStatusChange {
+	change := dtos.OrderStatusChange{
+		ID:        entity.ID,
+		Seq:       entity.Seq,
+		OrderID:   entity.OrderID,
+		Status:    entity.ToStatus,
+		Source:    entity.Source,
+		CreatedAt: entity.CreatedAt,
+	}
+
+	if entity.FromStatus != nil {
+		change.FromStatus = *entity.FromStatus
+	}
+
+	if entity.Accrual != nil {
+		accrual := points.FromMinor(*entity.Accrual)
+		change.Accrual = &accrual
+	}
+
+	return change
+}
+
+func mapOrderAdjustmentEntityToDto(entity model.OrderAdjustments) dtos.OrderAdjustment {
+	return dtos.OrderAdjustment{
+		ID:              entity.ID,
+		OrderID:         entity.OrderID,
+		UserID:          int(entity.UserID),
+		PreviousAccrual: points.FromMinor(entity.PreviousAccrual),
+		Accrual:         points.FromMinor(entity.Accrual),
+		Amount:          points.FromMinor(entity.Amount),
+		Reason:          entity.Reason,
+		CreatedAt:       entity.CreatedAt,
+	}
+}
+
 var _ OrderRepository = (*DBOrderRepository)(nil)
 
 func NewDBOrderRepository(db *sql.DB) *DBOrderRepository {
@@ -12,8 +12,10 @@ package repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dtos "github.com/sodiqit/gophermart/internal/server/dtos"
+	points "github.com/sodiqit/gophermart/pkg/points"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,21 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClaimOrdersForProcessing mocks base method.
+func (m *MockOrderRepository) ClaimOrdersForProcessing(ctx context.Context, owner string, leaseTTL, pushTimeout time.Duration, limit int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOrdersForProcessing", ctx, owner, leaseTTL, pushTimeout, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOrdersForProcessing indicates an expected call of ClaimOrdersForProcessing.
+func (mr *MockOrderRepositoryMockRecorder) ClaimOrdersForProcessing(ctx, owner, leaseTTL, pushTimeout, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOrdersForProcessing", reflect.TypeOf((*MockOrderRepository)(nil).ClaimOrdersForProcessing), ctx, owner, leaseTTL, pushTimeout, limit)
+}
+
 // Create mocks base method.
 func (m *MockOrderRepository) Create(ctx context.Context, userID int, orderNumber, status string) (string, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +72,35 @@ func (mr *MockOrderRepositoryMockRecorder) Create(ctx, userID, orderNumber, stat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, userID, orderNumber, status)
 }
 
+// CreateAdjustment mocks base method.
+func (m *MockOrderRepository) CreateAdjustment(ctx context.Context, adjustment dtos.OrderAdjustment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdjustment", ctx, adjustment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdjustment indicates an expected call of CreateAdjustment.
+func (mr *MockOrderRepositoryMockRecorder) CreateAdjustment(ctx, adjustment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdjustment", reflect.TypeOf((*MockOrderRepository)(nil).CreateAdjustment), ctx, adjustment)
+}
+
+// CreateStatusChange mocks base method.
+func (m *MockOrderRepository) CreateStatusChange(ctx context.Context, change dtos.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatusChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStatusChange indicates an expected call of CreateStatusChange.
+func (mr *MockOrderRepositoryMockRecorder) CreateStatusChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatusChange", reflect.TypeOf((*MockOrderRepository)(nil).CreateStatusChange), ctx, change)
+}
+
 // FindByOrderNumber mocks base method.
 func (m *MockOrderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (dtos.Order, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +116,36 @@ func (mr *MockOrderRepositoryMockRecorder) FindByOrderNumber(ctx, orderNumber an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderNumber", reflect.TypeOf((*MockOrderRepository)(nil).FindByOrderNumber), ctx, orderNumber)
 }
 
+// GetDeadLetterOrders mocks base method.
+func (m *MockOrderRepository) GetDeadLetterOrders(ctx context.Context, limit, offset int64) ([]dtos.DeadLetterOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterOrders", ctx, limit, offset)
+	ret0, _ := ret[0].([]dtos.DeadLetterOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterOrders indicates an expected call of GetDeadLetterOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetDeadLetterOrders(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetDeadLetterOrders), ctx, limit, offset)
+}
+
+// GetLastUserStatusChangeSeq mocks base method.
+func (m *MockOrderRepository) GetLastUserStatusChangeSeq(ctx context.Context, userID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastUserStatusChangeSeq", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastUserStatusChangeSeq indicates an expected call of GetLastUserStatusChangeSeq.
+func (mr *MockOrderRepositoryMockRecorder) GetLastUserStatusChangeSeq(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastUserStatusChangeSeq", reflect.TypeOf((*MockOrderRepository)(nil).GetLastUserStatusChangeSeq), ctx, userID)
+}
+
 // GetListByUser mocks base method.
 func (m *MockOrderRepository) GetListByUser(ctx context.Context, userID int) ([]dtos.Order, error) {
 	m.ctrl.T.Helper()
@@ -85,23 +161,168 @@ func (mr *MockOrderRepositoryMockRecorder) GetListByUser(ctx, userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByUser", reflect.TypeOf((*MockOrderRepository)(nil).GetListByUser), ctx, userID)
 }
 
-// GetOrdersForProcessing mocks base method.
-func (m *MockOrderRepository) GetOrdersForProcessing(ctx context.Context, pool int64) ([]string, error) {
+// GetOrdersForRecheck mocks base method.
+func (m *MockOrderRepository) GetOrdersForRecheck(ctx context.Context, window, staleAfter time.Duration, limit int64) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersForProcessing", ctx, pool)
+	ret := m.ctrl.Call(m, "GetOrdersForRecheck", ctx, window, staleAfter, limit)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrdersForProcessing indicates an expected call of GetOrdersForProcessing.
-func (mr *MockOrderRepositoryMockRecorder) GetOrdersForProcessing(ctx, pool any) *gomock.Call {
+// GetOrdersForRecheck indicates an expected call of GetOrdersForRecheck.
+func (mr *MockOrderRepositoryMockRecorder) GetOrdersForRecheck(ctx, window, staleAfter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForRecheck", reflect.TypeOf((*MockOrderRepository)(nil).GetOrdersForRecheck), ctx, window, staleAfter, limit)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockOrderRepository) GetStatusHistory(ctx context.Context, orderID string) ([]dtos.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, orderID)
+	ret0, _ := ret[0].([]dtos.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockOrderRepositoryMockRecorder) GetStatusHistory(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockOrderRepository)(nil).GetStatusHistory), ctx, orderID)
+}
+
+// GetUserStatusChanges mocks base method.
+func (m *MockOrderRepository) GetUserStatusChanges(ctx context.Context, userID int, afterSeq, limit int64) ([]dtos.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStatusChanges", ctx, userID, afterSeq, limit)
+	ret0, _ := ret[0].([]dtos.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStatusChanges indicates an expected call of GetUserStatusChanges.
+func (mr *MockOrderRepositoryMockRecorder) GetUserStatusChanges(ctx, userID, afterSeq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatusChanges", reflect.TypeOf((*MockOrderRepository)(nil).GetUserStatusChanges), ctx, userID, afterSeq, limit)
+}
+
+// ListTransactions mocks base method.
+func (m *MockOrderRepository) ListTransactions(ctx context.Context, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, filter)
+	ret0, _ := ret[0].([]dtos.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockOrderRepositoryMockRecorder) ListTransactions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockOrderRepository)(nil).ListTransactions), ctx, filter)
+}
+
+// LockOrder mocks base method.
+func (m *MockOrderRepository) LockOrder(ctx context.Context, orderID string) (dtos.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOrder", ctx, orderID)
+	ret0, _ := ret[0].(dtos.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOrder indicates an expected call of LockOrder.
+func (mr *MockOrderRepositoryMockRecorder) LockOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOrder", reflect.TypeOf((*MockOrderRepository)(nil).LockOrder), ctx, orderID)
+}
+
+// MarkAccrualChecked mocks base method.
+func (m *MockOrderRepository) MarkAccrualChecked(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAccrualChecked", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAccrualChecked indicates an expected call of MarkAccrualChecked.
+func (mr *MockOrderRepositoryMockRecorder) MarkAccrualChecked(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAccrualChecked", reflect.TypeOf((*MockOrderRepository)(nil).MarkAccrualChecked), ctx, orderID)
+}
+
+// MarkOrderPushed mocks base method.
+func (m *MockOrderRepository) MarkOrderPushed(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderPushed", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOrderPushed indicates an expected call of MarkOrderPushed.
+func (mr *MockOrderRepositoryMockRecorder) MarkOrderPushed(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderPushed", reflect.TypeOf((*MockOrderRepository)(nil).MarkOrderPushed), ctx, orderID)
+}
+
+// ReleaseOrder mocks base method.
+func (m *MockOrderRepository) ReleaseOrder(ctx context.Context, orderID, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseOrder", ctx, orderID, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseOrder indicates an expected call of ReleaseOrder.
+func (mr *MockOrderRepositoryMockRecorder) ReleaseOrder(ctx, orderID, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseOrder", reflect.TypeOf((*MockOrderRepository)(nil).ReleaseOrder), ctx, orderID, owner)
+}
+
+// RequeueOrder mocks base method.
+func (m *MockOrderRepository) RequeueOrder(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueOrder", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueOrder indicates an expected call of RequeueOrder.
+func (mr *MockOrderRepositoryMockRecorder) RequeueOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueOrder", reflect.TypeOf((*MockOrderRepository)(nil).RequeueOrder), ctx, orderID)
+}
+
+// ScheduleRetry mocks base method.
+func (m *MockOrderRepository) ScheduleRetry(ctx context.Context, orderID, owner string, policy dtos.RetryPolicy, lastError string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleRetry", ctx, orderID, owner, policy, lastError)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleRetry indicates an expected call of ScheduleRetry.
+func (mr *MockOrderRepositoryMockRecorder) ScheduleRetry(ctx, orderID, owner, policy, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleRetry", reflect.TypeOf((*MockOrderRepository)(nil).ScheduleRetry), ctx, orderID, owner, policy, lastError)
+}
+
+// SetAccrual mocks base method.
+func (m *MockOrderRepository) SetAccrual(ctx context.Context, orderID, status string, accrual *points.Points) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccrual", ctx, orderID, status, accrual)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccrual indicates an expected call of SetAccrual.
+func (mr *MockOrderRepositoryMockRecorder) SetAccrual(ctx, orderID, status, accrual any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersForProcessing", reflect.TypeOf((*MockOrderRepository)(nil).GetOrdersForProcessing), ctx, pool)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccrual", reflect.TypeOf((*MockOrderRepository)(nil).SetAccrual), ctx, orderID, status, accrual)
 }
 
 // UpdateOrder mocks base method.
-func (m *MockOrderRepository) UpdateOrder(ctx context.Context, orderID, status string, accrual *float64) error {
+func (m *MockOrderRepository) UpdateOrder(ctx context.Context, orderID, status string, accrual *points.Points) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateOrder", ctx, orderID, status, accrual)
 	ret0, _ := ret[0].(error)
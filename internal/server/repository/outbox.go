@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+const (
+	OutboxEventOrderProcessed       = "order.processed"
+	OutboxEventOrderAccrualAdjusted = "order.accrual_adjusted"
+	OutboxEventWithdrawalCreated    = "balance.withdrawal_created"
+)
+
+type OutboxRepository interface {
+	Add(ctx context.Context, eventType string, aggregateID string, payload any) error
+	ClaimPending(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.OutboxEvent, error)
+	MarkPublished(ctx context.Context, eventID int64) error
+	ScheduleRetry(ctx context.Context, eventID int64, owner string, policy dtos.RetryPolicy, lastError string) error
+}
+
+type DBOutboxRepository struct {
+	db *sql.DB
+}
+
+// Add writes the event to the outbox. Called with the context of a transaction the event is published
+// only if the transaction commits.
+func (r *DBOutboxRepository) Add(ctx context.Context, eventType string, aggregateID string, payload any) error {
+	op := "outboxRepo.add"
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt := table.OutboxEvents.
+		INSERT(table.OutboxEvents.EventType, table.OutboxEvents.AggregateID, table.OutboxEvents.Payload).
+		VALUES(eventType, aggregateID, string(body))
+
+	_, err = stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimPending leases up to limit unpublished events due for publishing to owner for leaseTTL, oldest first.
+// Events leased by other owners are skipped until their lease expires, so an event is published by one relay
+// at a time. The claim commits on its own, events are published outside of any transaction.
+func (r *DBOutboxRepository) ClaimPending(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.OutboxEvent, error) {
+	op := "outboxRepo.claimPending"
+
+	now := postgres.LOCALTIMESTAMP()
+
+	claimable := table.OutboxEvents.SELECT(table.OutboxEvents.ID).
+		WHERE(table.OutboxEvents.PublishedAt.IS_NULL().
+			AND(table.OutboxEvents.NextAttemptAt.IS_NULL().OR(table.OutboxEvents.NextAttemptAt.LT_EQ(now))).
+			AND(table.OutboxEvents.LeaseExpiresAt.IS_NULL().OR(table.OutboxEvents.LeaseExpiresAt.LT_EQ(now)))).
+		ORDER_BY(table.OutboxEvents.ID.ASC()).
+		LIMIT(limit).
+		FOR(postgres.UPDATE().SKIP_LOCKED())
+
+	stmt := table.OutboxEvents.
+		UPDATE(table.OutboxEvents.LeaseOwner, table.OutboxEvents.LeaseExpiresAt).
+		SET(owner, now.ADD(postgres.INTERVALd(leaseTTL))).
+		WHERE(table.OutboxEvents.ID.IN(claimable)).
+		RETURNING(table.OutboxEvents.AllColumns)
+
+	var dest []model.OutboxEvents
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.OutboxEvent, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(dest, func(i, j int) bool { return dest[i].ID < dest[j].ID })
+
+	result := make([]dtos.OutboxEvent, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapOutboxEventEntityToDto(entity)
+	}
+
+	return result, nil
+}
+
+// MarkPublished records the event as published and ends its lease.
+func (r *DBOutboxRepository) MarkPublished(ctx context.Context, eventID int64) error {
+	op := "outboxRepo.markPublished"
+
+	stmt := table.OutboxEvents.
+		UPDATE(table.OutboxEvents.PublishedAt, table.OutboxEvents.LastError, table.OutboxEvents.LeaseOwner, table.OutboxEvents.LeaseExpiresAt).
+		SET(postgres.LOCALTIMESTAMP(), postgres.NULL, postgres.NULL, postgres.NULL).
+		WHERE(table.OutboxEvents.ID.EQ(postgres.Int64(eventID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ScheduleRetry ends the lease of owner on the event and postpones its next publish attempt by the policy
+// backoff. Events are retried until published, the policy max age is ignored. An event leased by another
+// owner is left untouched.
+func (r *DBOutboxRepository) ScheduleRetry(ctx context.Context, eventID int64, owner string, policy dtos.RetryPolicy, lastError string) error {
+	op := "outboxRepo.scheduleRetry"
+
+	// attempts in SET refers to the value before the update, so the first retry waits for the base delay
+	query := `
+		UPDATE outbox_events SET
+			attempts = attempts + 1,
+			next_attempt_at = LOCALTIMESTAMP + LEAST($2::bigint * power(2, LEAST(attempts, 30))::bigint, $3::bigint) * INTERVAL '1 microsecond',
+			last_error = $4,
+			lease_owner = NULL,
+			lease_expires_at = NULL
+		WHERE id = $1 AND lease_owner = $5
+	`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, eventID, policy.BaseDelay.Microseconds(), policy.MaxDelay.Microseconds(), lastError, owner)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func mapOutboxEventEntityToDto(entity model.OutboxEvents) dtos.OutboxEvent {
+	return dtos.OutboxEvent{
+		ID:          entity.ID,
+		Type:        entity.EventType,
+		AggregateID: entity.AggregateID,
+		Payload:     json.RawMessage(entity.Payload),
+		CreatedAt:   entity.CreatedAt,
+		Attempts:    int(entity.Attempts),
+	}
+}
+
+var _ OutboxRepository = (*DBOutboxRepository)(nil)
+
+func NewDBOutboxRepository(db *sql.DB) *DBOutboxRepository {
+	return &DBOutboxRepository{db: db}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+var ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, userID int, tokenHash string, ttl time.Duration) error
+	LockPasswordResetToken(ctx context.Context, tokenHash string) (dtos.PasswordResetToken, error)
+	UsePasswordResetTokens(ctx context.Context, userID int) error
+}
+
+type DBPasswordResetRepository struct {
+	db *sql.DB
+}
+
+func (r *DBPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, userID int, tokenHash string, ttl time.Duration) error {
+	op := "passwordResetRepo.createPasswordResetToken"
+
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, LOCALTIMESTAMP + $3 * INTERVAL '1 microsecond')
+	`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, userID, tokenHash, ttl.Microseconds())
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LockPasswordResetToken returns the token with the hash and locks it until the end of the current transaction,
+// so a token resets the password once even if it is presented concurrently. It must be called within Transactor.WithinTransaction.
+func (r *DBPasswordResetRepository) LockPasswordResetToken(ctx context.Context, tokenHash string) (dtos.PasswordResetToken, error) {
+	op := "passwordResetRepo.lockPasswordResetToken"
+
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, expires_at <= LOCALTIMESTAMP
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	var token dtos.PasswordResetToken
+
+	err := executorFromContext(ctx, r.db).
+		QueryRowContext(ctx, query, tokenHash).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.Expired)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return dtos.PasswordResetToken{}, fmt.Errorf("%s: %w", op, ErrPasswordResetTokenNotFound)
+	}
+
+	if err != nil {
+		return dtos.PasswordResetToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// UsePasswordResetTokens marks all unused tokens of the user used, so no token outlives a reset.
+func (r *DBPasswordResetRepository) UsePasswordResetTokens(ctx context.Context, userID int) error {
+	op := "passwordResetRepo.usePasswordResetTokens"
+
+	stmt := table.PasswordResetTokens.
+		UPDATE(table.PasswordResetTokens.UsedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(
+			table.PasswordResetTokens.UserID.EQ(postgres.Int(int64(userID))).
+				AND(table.PasswordResetTokens.UsedAt.IS_NULL()),
+		)
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var _ PasswordResetRepository = (*DBPasswordResetRepository)(nil)
+
+func NewDBPasswordResetRepository(db *sql.DB) *DBPasswordResetRepository {
+	return &DBPasswordResetRepository{db: db}
+}
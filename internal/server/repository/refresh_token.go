@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+var ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, userID int, familyID string, tokenHash string, ttl time.Duration) error
+	LockRefreshToken(ctx context.Context, tokenHash string) (dtos.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, tokenID int64) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int) error
+}
+
+type DBRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func (r *DBRefreshTokenRepository) CreateRefreshToken(ctx context.Context, userID int, familyID string, tokenHash string, ttl time.Duration) error {
+	op := "refreshTokenRepo.createRefreshToken"
+
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, LOCALTIMESTAMP + $4 * INTERVAL '1 microsecond')
+	`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, userID, familyID, tokenHash, ttl.Microseconds())
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LockRefreshToken returns the token with the hash and locks it until the end of the current transaction,
+// so a token is exchanged once even if it is presented concurrently. It must be called within Transactor.WithinTransaction.
+func (r *DBRefreshTokenRepository) LockRefreshToken(ctx context.Context, tokenHash string) (dtos.RefreshToken, error) {
+	op := "refreshTokenRepo.lockRefreshToken"
+
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at, expires_at <= LOCALTIMESTAMP
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	var token dtos.RefreshToken
+
+	err := executorFromContext(ctx, r.db).
+		QueryRowContext(ctx, query, tokenHash).
+		Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.CreatedAt, &token.UsedAt, &token.RevokedAt, &token.Expired)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return dtos.RefreshToken{}, fmt.Errorf("%s: %w", op, ErrRefreshTokenNotFound)
+	}
+
+	if err != nil {
+		return dtos.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (r *DBRefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, tokenID int64) error {
+	op := "refreshTokenRepo.markRefreshTokenUsed"
+
+	stmt := table.RefreshTokens.
+		UPDATE(table.RefreshTokens.UsedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(table.RefreshTokens.ID.EQ(postgres.Int64(tokenID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokenFamily revokes all tokens of the family which are not revoked yet.
+func (r *DBRefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	op := "refreshTokenRepo.revokeRefreshTokenFamily"
+
+	stmt := table.RefreshTokens.
+		UPDATE(table.RefreshTokens.RevokedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(
+			table.RefreshTokens.FamilyID.EQ(postgres.String(familyID)).
+				AND(table.RefreshTokens.RevokedAt.IS_NULL()),
+		)
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes all tokens of the user which are not revoked yet.
+func (r *DBRefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	op := "refreshTokenRepo.revokeUserRefreshTokens"
+
+	stmt := table.RefreshTokens.
+		UPDATE(table.RefreshTokens.RevokedAt).
+		SET(postgres.LOCALTIMESTAMP()).
+		WHERE(
+			table.RefreshTokens.UserID.EQ(postgres.Int(int64(userID))).
+				AND(table.RefreshTokens.RevokedAt.IS_NULL()),
+		)
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+var _ RefreshTokenRepository = (*DBRefreshTokenRepository)(nil)
+
+func NewDBRefreshTokenRepository(db *sql.DB) *DBRefreshTokenRepository {
+	return &DBRefreshTokenRepository{db: db}
+}
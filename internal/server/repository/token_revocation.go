@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+)
+
+type TokenRevocationRepository interface {
+	RevokeToken(ctx context.Context, jti string, userID int, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeUserTokens(ctx context.Context, userID int, before time.Time) error
+	GetUserTokensRevokedBefore(ctx context.Context, userID int) (*time.Time, error)
+	DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error)
+}
+
+type DBTokenRevocationRepository struct {
+	db *sql.DB
+}
+
+// RevokeToken records the access token as revoked until it expires. Revoking the token again is a no-op.
+func (r *DBTokenRevocationRepository) RevokeToken(ctx context.Context, jti string, userID int, expiresAt time.Time) error {
+	op := "tokenRevocationRepo.revokeToken"
+
+	stmt := table.RevokedTokens.
+		INSERT(table.RevokedTokens.Jti, table.RevokedTokens.UserID, table.RevokedTokens.ExpiresAt).
+		VALUES(jti, userID, expiresAt).
+		ON_CONFLICT(table.RevokedTokens.Jti).
+		DO_NOTHING()
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	op := "tokenRevocationRepo.isTokenRevoked"
+
+	stmt := table.RevokedTokens.
+		SELECT(table.RevokedTokens.Jti).
+		WHERE(table.RevokedTokens.Jti.EQ(postgres.String(jti)))
+
+	var dest []model.RevokedTokens
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(dest) > 0, nil
+}
+
+// RevokeUserTokens revokes every access token of the user issued before the time.
+func (r *DBTokenRevocationRepository) RevokeUserTokens(ctx context.Context, userID int, before time.Time) error {
+	op := "tokenRevocationRepo.revokeUserTokens"
+
+	stmt := table.Users.
+		UPDATE(table.Users.TokensRevokedBefore).
+		SET(postgres.TimestampT(before)).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetUserTokensRevokedBefore returns the time access tokens of the user issued before are revoked,
+// nil if all sessions of the user have never been logged out. It returns ErrUserNotFound for a deleted user.
+func (r *DBTokenRevocationRepository) GetUserTokensRevokedBefore(ctx context.Context, userID int) (*time.Time, error) {
+	op := "tokenRevocationRepo.getUserTokensRevokedBefore"
+
+	stmt := table.Users.
+		SELECT(table.Users.TokensRevokedBefore).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	var dest []model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(dest) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	return dest[0].TokensRevokedBefore, nil
+}
+
+func (r *DBTokenRevocationRepository) DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error) {
+	op := "tokenRevocationRepo.deleteExpiredRevokedTokens"
+
+	stmt := table.RevokedTokens.
+		DELETE().
+		WHERE(table.RevokedTokens.ExpiresAt.LT_EQ(postgres.TimestampT(now)))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+var _ TokenRevocationRepository = (*DBTokenRevocationRepository)(nil)
+
+func NewDBTokenRevocationRepository(db *sql.DB) *DBTokenRevocationRepository {
+	return &DBTokenRevocationRepository{db: db}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/pkg/points"
+)
+
+const (
+	TransactionTypeAccrual     = "ACCRUAL"
+	TransactionTypeAdjustment  = "ADJUSTMENT"
+	TransactionTypeReversal    = "REVERSAL"
+	TransactionTypeWithdrawal  = "WITHDRAWAL"
+	TransactionTypeTransferOut = "TRANSFER_OUT"
+	TransactionTypeTransferIn  = "TRANSFER_IN"
+	TransactionTypeExpiration  = "EXPIRATION"
+	// TransactionTypeOrder is an order upload, it does not change the balance and is listed only in statements
+	TransactionTypeOrder = "ORDER"
+)
+
+// transactionsQuery pages through source, a query of the user ($1) transactions with columns
+// type, reference, order_id, counterparty, status, amount and occurred_at. Type and reference are compared
+// byte-wise, so the order matches the one of the cursor built by the caller.
+func transactionsQuery(source string, ascending bool) string {
+	direction, comparison := "DESC", "<"
+
+	if ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	return `
+		WITH tx AS (` + source + `)
+		SELECT type, reference, order_id, counterparty, status, amount, occurred_at
+		FROM tx
+		WHERE
+			($2::timestamp IS NULL OR occurred_at >= $2) AND
+			($3::timestamp IS NULL OR occurred_at < $3) AND
+			($4::text[] IS NULL OR type = ANY($4)) AND
+			($5::timestamp IS NULL OR (occurred_at, type COLLATE "C", reference COLLATE "C") ` + comparison + ` ($5, $6, $7))
+		ORDER BY occurred_at ` + direction + `, type COLLATE "C" ` + direction + `, reference COLLATE "C" ` + direction + `
+		LIMIT $8
+	`
+}
+
+func transactionsArgs(filter dtos.TransactionFilter) []any {
+	args := []any{filter.UserID, filter.From, filter.To, nil, nil, nil, nil, filter.Limit}
+
+	if len(filter.Types) > 0 {
+		args[3] = filter.Types
+	}
+
+	if filter.After != nil {
+		args[4] = filter.After.OccurredAt
+		args[5] = filter.After.Type
+		args[6] = filter.After.Reference
+	}
+
+	return args
+}
+
+func queryTransactions(ctx context.Context, exec executor, op string, source string, filter dtos.TransactionFilter) ([]dtos.Transaction, error) {
+	result := make([]dtos.Transaction, 0)
+
+	rows, err := exec.QueryContext(ctx, transactionsQuery(source, filter.Ascending), transactionsArgs(filter)...)
+
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var amount int64
+		var transaction dtos.Transaction
+
+		if err := rows.Scan(&transaction.Type, &transaction.Reference, &transaction.OrderID, &transaction.Counterparty, &transaction.Status, &amount, &transaction.OccurredAt); err != nil {
+			return result, fmt.Errorf("%s: %w", op, err)
+		}
+
+		transaction.Amount = points.FromMinor(amount)
+
+		result = append(result, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
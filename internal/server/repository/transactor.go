@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Transactor runs a function inside a database transaction. Repositories called with the
+// context passed to fn execute their statements inside that transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+type DBTransactor struct {
+	db *sql.DB
+}
+
+func (t *DBTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	op := "transactor.withinTransaction"
+
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txContextKey{}, tx))
+
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("%s: rollback: %w", op, rbErr))
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return nil
+}
+
+var _ Transactor = (*DBTransactor)(nil)
+
+func NewDBTransactor(db *sql.DB) *DBTransactor {
+	return &DBTransactor{db: db}
+}
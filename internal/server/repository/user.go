@@ -16,7 +16,10 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user dtos.User) (int, error)
 	FindByLogin(ctx context.Context, username string) (dtos.User, error)
+	FindByID(ctx context.Context, userID int) (dtos.User, error)
 	Exist(ctx context.Context, login string) (bool, error)
+	Update(ctx context.Context, userID int, update dtos.UserUpdate) error
+	Delete(ctx context.Context, userID int) error
 }
 
 type DBUserRepository struct {
@@ -55,6 +58,22 @@ func (r *DBUserRepository) FindByLogin(ctx context.Context, username string) (dt
 	return mapUserEntityToDto(dest), nil
 }
 
+func (r *DBUserRepository) FindByID(ctx context.Context, userID int) (dtos.User, error) {
+	op := "userRepo.findByID"
+
+	stmt := table.Users.SELECT(table.Users.ID, table.Users.Login, table.Users.PasswordHash, table.Users.CreatedAt).WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	var dest model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return dtos.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapUserEntityToDto(dest), nil
+}
+
 func (r *DBUserRepository) Exist(ctx context.Context, login string) (bool, error) {
 	op := "userRepo.exist"
 
@@ -79,6 +98,73 @@ func (r *DBUserRepository) Exist(ctx context.Context, login string) (bool, error
 	return exist, nil
 }
 
+// Update sets the fields of the user which are not nil. It returns ErrUserNotFound if the user does not exist.
+func (r *DBUserRepository) Update(ctx context.Context, userID int, update dtos.UserUpdate) error {
+	op := "userRepo.update"
+
+	var columns postgres.ColumnList
+	var values []interface{}
+
+	if update.Login != nil {
+		columns = append(columns, table.Users.Login)
+		values = append(values, *update.Login)
+	}
+
+	if update.PasswordHash != nil {
+		columns = append(columns, table.Users.PasswordHash)
+		values = append(values, *update.PasswordHash)
+	}
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	stmt := table.Users.
+		UPDATE(columns).
+		SET(values[0], values[1:]...).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkUserAffected(op, res)
+}
+
+// Delete deletes the user, everything the user owns is deleted along by the foreign keys.
+// It returns ErrUserNotFound if the user does not exist.
+func (r *DBUserRepository) Delete(ctx context.Context, userID int) error {
+	op := "userRepo.delete"
+
+	stmt := table.Users.
+		DELETE().
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID))))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkUserAffected(op, res)
+}
+
+func checkUserAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	return nil
+}
+
 func mapUserEntityToDto(userEntity model.Users) dtos.User {
 	return dtos.User{
 		ID:           int(userEntity.ID),
@@ -0,0 +1,404 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/model"
+	"github.com/sodiqit/gophermart/gen/gophermart_db/public/table"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+)
+
+const (
+	WebhookEventOrderStatusChanged = "order.status_changed"
+	WebhookEventWithdrawalCreated  = "balance.withdrawal_created"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "PENDING"
+	WebhookDeliveryStatusDelivered = "DELIVERED"
+	WebhookDeliveryStatusFailed    = "FAILED"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+var ErrWebhookAlreadyExists = errors.New("webhook already exists")
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, userID int, url string, secret string) (dtos.Webhook, error)
+	LockUserWebhooks(ctx context.Context, userID int) error
+	CountWebhooks(ctx context.Context, userID int) (int64, error)
+	GetWebhook(ctx context.Context, userID int, webhookID int64) (dtos.Webhook, error)
+	GetWebhooksByUser(ctx context.Context, userID int) ([]dtos.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID int, webhookID int64) error
+	GetDeliveries(ctx context.Context, webhookID int64, limit int64, offset int64) ([]dtos.WebhookDelivery, error)
+	EnqueueDeliveries(ctx context.Context, userID int, eventType string, payload any) error
+	ClaimDueDeliveries(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, deliveryID int64, responseStatus int) error
+	ScheduleDeliveryRetry(ctx context.Context, deliveryID int64, owner string, policy dtos.RetryPolicy, responseStatus *int, lastError string) (bool, error)
+}
+
+type DBWebhookRepository struct {
+	db *sql.DB
+}
+
+// CreateWebhook registers the url for the user, registering it twice returns ErrWebhookAlreadyExists.
+func (r *DBWebhookRepository) CreateWebhook(ctx context.Context, userID int, url string, secret string) (dtos.Webhook, error) {
+	op := "webhookRepo.createWebhook"
+
+	stmt := table.UserWebhooks.
+		INSERT(table.UserWebhooks.UserID, table.UserWebhooks.Url, table.UserWebhooks.Secret).
+		VALUES(userID, url, secret).
+		ON_CONFLICT(table.UserWebhooks.UserID, table.UserWebhooks.Url).DO_NOTHING().
+		RETURNING(table.UserWebhooks.AllColumns)
+
+	var dest model.UserWebhooks
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return dtos.Webhook{}, fmt.Errorf("%s: %w", op, ErrWebhookAlreadyExists)
+	}
+
+	if err != nil {
+		return dtos.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapWebhookEntityToDto(dest), nil
+}
+
+// LockUserWebhooks locks the user row until the end of the current transaction, so registrations of webhooks
+// of the same user are serialized with the check of their count. It must be called within Transactor.WithinTransaction.
+func (r *DBWebhookRepository) LockUserWebhooks(ctx context.Context, userID int) error {
+	op := "webhookRepo.lockUserWebhooks"
+
+	stmt := table.Users.SELECT(table.Users.ID).
+		WHERE(table.Users.ID.EQ(postgres.Int(int64(userID)))).
+		FOR(postgres.NO_KEY_UPDATE())
+
+	var dest model.Users
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *DBWebhookRepository) CountWebhooks(ctx context.Context, userID int) (int64, error) {
+	op := "webhookRepo.countWebhooks"
+
+	stmt := table.UserWebhooks.
+		SELECT(postgres.COUNT(table.UserWebhooks.ID)).
+		WHERE(table.UserWebhooks.UserID.EQ(postgres.Int(int64(userID))))
+
+	query, args := stmt.Sql()
+
+	var count int64
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// GetWebhook returns the webhook of the user, ErrWebhookNotFound is returned for webhooks of other users.
+func (r *DBWebhookRepository) GetWebhook(ctx context.Context, userID int, webhookID int64) (dtos.Webhook, error) {
+	op := "webhookRepo.getWebhook"
+
+	stmt := table.UserWebhooks.
+		SELECT(table.UserWebhooks.AllColumns).
+		WHERE(table.UserWebhooks.ID.EQ(postgres.Int64(webhookID)).AND(table.UserWebhooks.UserID.EQ(postgres.Int(int64(userID)))))
+
+	var dest model.UserWebhooks
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if errors.Is(err, qrm.ErrNoRows) {
+		return dtos.Webhook{}, fmt.Errorf("%s: %w", op, ErrWebhookNotFound)
+	}
+
+	if err != nil {
+		return dtos.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapWebhookEntityToDto(dest), nil
+}
+
+func (r *DBWebhookRepository) GetWebhooksByUser(ctx context.Context, userID int) ([]dtos.Webhook, error) {
+	op := "webhookRepo.getWebhooksByUser"
+
+	stmt := table.UserWebhooks.
+		SELECT(table.UserWebhooks.AllColumns).
+		WHERE(table.UserWebhooks.UserID.EQ(postgres.Int(int64(userID)))).
+		ORDER_BY(table.UserWebhooks.ID)
+
+	var dest []model.UserWebhooks
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.Webhook, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dtos.Webhook, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapWebhookEntityToDto(entity)
+	}
+
+	return result, nil
+}
+
+// DeleteWebhook deletes the webhook of the user with its delivery log. ErrWebhookNotFound is returned
+// for webhooks of other users.
+func (r *DBWebhookRepository) DeleteWebhook(ctx context.Context, userID int, webhookID int64) error {
+	op := "webhookRepo.deleteWebhook"
+
+	stmt := table.UserWebhooks.
+		DELETE().
+		WHERE(table.UserWebhooks.ID.EQ(postgres.Int64(webhookID)).AND(table.UserWebhooks.UserID.EQ(postgres.Int(int64(userID)))))
+
+	res, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleted, err := res.RowsAffected()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", op, ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns a page of the webhook delivery log, newest first.
+func (r *DBWebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, limit int64, offset int64) ([]dtos.WebhookDelivery, error) {
+	op := "webhookRepo.getDeliveries"
+
+	stmt := table.WebhookDeliveries.
+		SELECT(table.WebhookDeliveries.AllColumns).
+		WHERE(table.WebhookDeliveries.WebhookID.EQ(postgres.Int64(webhookID))).
+		ORDER_BY(table.WebhookDeliveries.ID.DESC()).
+		LIMIT(limit).
+		OFFSET(offset)
+
+	var dest []model.WebhookDeliveries
+
+	err := stmt.QueryContext(ctx, executorFromContext(ctx, r.db), &dest)
+
+	if err != nil {
+		return make([]dtos.WebhookDelivery, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dtos.WebhookDelivery, len(dest))
+
+	for i, entity := range dest {
+		result[i] = mapWebhookDeliveryEntityToDto(entity)
+	}
+
+	return result, nil
+}
+
+// EnqueueDeliveries schedules a delivery of the event to every webhook of the user. Called with the context
+// of a transaction the event is delivered only if the transaction commits.
+func (r *DBWebhookRepository) EnqueueDeliveries(ctx context.Context, userID int, eventType string, payload any) error {
+	op := "webhookRepo.enqueueDeliveries"
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+		SELECT id, $2, $3::jsonb FROM user_webhooks WHERE user_id = $1
+	`
+
+	_, err = executorFromContext(ctx, r.db).ExecContext(ctx, query, userID, eventType, string(body))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases up to limit pending deliveries due for sending to owner for leaseTTL, oldest first,
+// with the url and secret of their webhooks. Deliveries leased by other owners are skipped until their lease
+// expires, so a delivery is sent by one dispatcher at a time. The claim commits on its own, deliveries are sent
+// outside of any transaction.
+func (r *DBWebhookRepository) ClaimDueDeliveries(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.WebhookDelivery, error) {
+	op := "webhookRepo.claimDueDeliveries"
+
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries SET
+				lease_owner = $2,
+				lease_expires_at = LOCALTIMESTAMP + $3::bigint * INTERVAL '1 microsecond'
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status = 'PENDING'
+					AND (next_attempt_at IS NULL OR next_attempt_at <= LOCALTIMESTAMP)
+					AND (lease_expires_at IS NULL OR lease_expires_at <= LOCALTIMESTAMP)
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, webhook_id, event_type, payload, status, attempts, created_at
+		)
+		SELECT d.id, d.webhook_id, d.event_type, d.payload, d.status, d.attempts, d.created_at, w.url, w.secret
+		FROM claimed d
+		JOIN user_webhooks w ON w.id = d.webhook_id
+		ORDER BY d.id
+	`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, limit, owner, leaseTTL.Microseconds())
+
+	if err != nil {
+		return make([]dtos.WebhookDelivery, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	result := make([]dtos.WebhookDelivery, 0)
+
+	for rows.Next() {
+		var delivery dtos.WebhookDelivery
+		var payload string
+
+		err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &payload, &delivery.Status, &delivery.Attempts, &delivery.CreatedAt, &delivery.URL, &delivery.Secret)
+
+		if err != nil {
+			return make([]dtos.WebhookDelivery, 0), fmt.Errorf("%s: %w", op, err)
+		}
+
+		delivery.Payload = json.RawMessage(payload)
+
+		result = append(result, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return make([]dtos.WebhookDelivery, 0), fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// MarkDelivered records the successful attempt and ends the lease of the delivery.
+func (r *DBWebhookRepository) MarkDelivered(ctx context.Context, deliveryID int64, responseStatus int) error {
+	op := "webhookRepo.markDelivered"
+
+	stmt := table.WebhookDeliveries.
+		UPDATE(table.WebhookDeliveries.Status, table.WebhookDeliveries.Attempts, table.WebhookDeliveries.ResponseStatus, table.WebhookDeliveries.NextAttemptAt, table.WebhookDeliveries.DeliveredAt, table.WebhookDeliveries.LeaseOwner, table.WebhookDeliveries.LeaseExpiresAt).
+		SET(WebhookDeliveryStatusDelivered, table.WebhookDeliveries.Attempts.ADD(postgres.Int(1)), responseStatus, postgres.NULL, postgres.LOCALTIMESTAMP(), postgres.NULL, postgres.NULL).
+		WHERE(table.WebhookDeliveries.ID.EQ(postgres.Int64(deliveryID)))
+
+	_, err := stmt.ExecContext(ctx, executorFromContext(ctx, r.db))
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ScheduleDeliveryRetry records the failed attempt, ends the lease of owner on the delivery and postpones the next
+// attempt by the policy backoff. Once the delivery is retried for longer than the policy max age it is marked failed
+// instead. It returns whether the delivery failed. responseStatus is nil when the webhook did not respond.
+// A delivery leased by another owner is left untouched.
+func (r *DBWebhookRepository) ScheduleDeliveryRetry(ctx context.Context, deliveryID int64, owner string, policy dtos.RetryPolicy, responseStatus *int, lastError string) (bool, error) {
+	op := "webhookRepo.scheduleDeliveryRetry"
+
+	// attempts in SET refers to the value before the update, so the first retry waits for the base delay
+	query := `
+		UPDATE webhook_deliveries SET
+			attempts = attempts + 1,
+			status = CASE WHEN $4::bigint > 0 AND created_at <= LOCALTIMESTAMP - $4::bigint * INTERVAL '1 microsecond' THEN 'FAILED' ELSE 'PENDING' END,
+			next_attempt_at = LOCALTIMESTAMP + LEAST($2::bigint * power(2, LEAST(attempts, 30))::bigint, $3::bigint) * INTERVAL '1 microsecond',
+			response_status = $5,
+			last_error = $6,
+			lease_owner = NULL,
+			lease_expires_at = NULL
+		WHERE id = $1 AND lease_owner = $7
+		RETURNING status = 'FAILED'
+	`
+
+	var failed bool
+
+	err := executorFromContext(ctx, r.db).
+		QueryRowContext(ctx, query, deliveryID, policy.BaseDelay.Microseconds(), policy.MaxDelay.Microseconds(), policy.MaxAge.Microseconds(), responseStatus, lastError, owner).
+		Scan(&failed)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failed, nil
+}
+
+func mapWebhookEntityToDto(entity model.UserWebhooks) dtos.Webhook {
+	return dtos.Webhook{
+		ID:        entity.ID,
+		UserID:    int(entity.UserID),
+		URL:       entity.Url,
+		Secret:    entity.Secret,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func mapWebhookDeliveryEntityToDto(entity model.WebhookDeliveries) dtos.WebhookDelivery {
+	delivery := dtos.WebhookDelivery{
+		ID:        entity.ID,
+		WebhookID: entity.WebhookID,
+		EventType: entity.EventType,
+		Payload:   json.RawMessage(entity.Payload),
+		Status:    entity.Status,
+		Attempts:  int(entity.Attempts),
+		CreatedAt: entity.CreatedAt,
+	}
+
+	if entity.Status == WebhookDeliveryStatusPending {
+		delivery.NextAttemptAt = entity.NextAttemptAt
+	}
+
+	if entity.ResponseStatus != nil {
+		responseStatus := int(*entity.ResponseStatus)
+		delivery.ResponseStatus = &responseStatus
+	}
+
+	if entity.LastError != nil {
+		delivery.LastError = *entity.LastError
+	}
+
+	delivery.DeliveredAt = entity.DeliveredAt
+
+	return delivery
+}
+
+var _ WebhookRepository = (*DBWebhookRepository)(nil)
+
+func NewDBWebhookRepository(db *sql.DB) *DBWebhookRepository {
+	return &DBWebhookRepository{db: db}
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+var ErrWebhookURLNotAllowed = errors.New("webhook url must resolve to a public address")
+
+// addressGuard keeps webhooks from reaching the network gophermart runs in: loopback, private, link-local
+// (cloud metadata at 169.254.169.254 included) and other non-public addresses are rejected. Urls are checked
+// at registration and the resolved address is checked again when a delivery dials it, so a host resolving
+// to another address after registration is rejected too.
+type addressGuard struct {
+	allowPrivate bool
+	resolver     *net.Resolver
+}
+
+// checkURL resolves the host of the url and returns ErrWebhookURLNotAllowed unless all its addresses are public.
+func (g addressGuard) checkURL(ctx context.Context, rawURL string) error {
+	if g.allowPrivate {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+
+	if err != nil || u.Hostname() == "" {
+		return ErrWebhookURLNotAllowed
+	}
+
+	_, err = g.resolve(ctx, u.Hostname())
+
+	return err
+}
+
+// dialContext dials the first public address of the host, the address is resolved and checked once
+// so the connection goes to the address that was checked.
+func (g addressGuard) dialContext(dialer *net.Dialer) func(ctx context.Context, network string, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		if g.allowPrivate {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		host, port, err := net.SplitHostPort(addr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := g.resolve(ctx, host)
+
+		if err != nil {
+			return nil, fmt.Errorf("dial %s: %w", host, err)
+		}
+
+		var conn net.Conn
+
+		for _, ip := range ips {
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+
+			if err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, err
+	}
+}
+
+// resolve returns the addresses of the host, ErrWebhookURLNotAllowed is returned when the host does not
+// resolve or any of its addresses is not public.
+func (g addressGuard) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	addrs, err := g.resolver.LookupIPAddr(ctx, host)
+
+	if err != nil || len(addrs) == 0 {
+		return nil, ErrWebhookURLNotAllowed
+	}
+
+	ips := make([]net.IP, len(addrs))
+
+	for i, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return nil, ErrWebhookURLNotAllowed
+		}
+
+		ips[i] = addr.IP
+	}
+
+	return ips, nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, not routable on the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+func newAddressGuard(allowPrivate bool) addressGuard {
+	return addressGuard{
+		allowPrivate: allowPrivate,
+		resolver:     net.DefaultResolver,
+	}
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/config"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+const (
+	dispatchBatchSize = 20
+	// dispatchLeaseTTL bounds the time a dispatcher sends a claimed batch, deliveries left are claimed again after it
+	dispatchLeaseTTL = 5 * time.Minute
+)
+
+type WebhookContainer struct {
+	Controller *WebhookController
+	GRPCServer *WebhookServer
+	Dispatcher *Dispatcher
+}
+
+func NewContainer(config *config.Config, logger logger.Logger, tokenService auth.TokenService, webhookRepo repository.WebhookRepository, transactor repository.Transactor) *WebhookContainer {
+	webhookService := NewSimpleWebhookService(webhookRepo, transactor, config.WebhookAllowPrivateNetworks)
+	webhookController := NewController(logger, tokenService, webhookService)
+	webhookServer := NewWebhookServer(logger, webhookService)
+	dispatcher := NewDispatcher(webhookRepo, logger, dispatchLeaseTTL, config.WebhookDispatchInterval, dispatchBatchSize, dtos.RetryPolicy{
+		BaseDelay: config.WebhookRetryBaseDelay,
+		MaxDelay:  config.WebhookRetryMaxDelay,
+		MaxAge:    config.WebhookMaxDeliveryAge,
+	}, config.WebhookAllowPrivateNetworks)
+
+	return &WebhookContainer{
+		Controller: webhookController,
+		GRPCServer: webhookServer,
+		Dispatcher: dispatcher,
+	}
+}
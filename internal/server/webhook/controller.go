@@ -0,0 +1,251 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/utils"
+)
+
+type WebhookController struct {
+	logger         logger.Logger
+	tokenService   auth.TokenService
+	webhookService WebhookService
+}
+
+func (c *WebhookController) Route() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Use(auth.JWTAuth(c.tokenService))
+
+	r.With(middleware.AllowContentType("application/json")).Post("/", c.handleRegisterWebhook)
+	r.Get("/", c.handleGetWebhooks)
+	r.Delete("/{webhookID}", c.handleDeleteWebhook)
+	r.Get("/{webhookID}/deliveries", c.handleGetDeliveries)
+
+	return r
+}
+
+// handleRegisterWebhook godoc
+//
+//	@Summary		register webhook
+//	@Description	Order status changes and withdrawals of the user are posted to the url. The body is signed with the secret, X-Gophermart-Signature header is "sha256=" followed by the hex HMAC-SHA256 of the body
+//	@Tags			webhook
+//
+//	@Param			body	body	RegisterWebhookRequestDTO	true	"url and secret of the webhook"
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	dtos.Webhook
+//	@Failure		400
+//	@Failure		401
+//	@Failure		409	string	true	"Webhook already registered"
+//	@Failure		422	string	true	"Webhooks limit exceeded or url not resolving to a public address"
+//	@Failure		500
+//	@Router			/api/user/webhooks [post]
+func (c *WebhookController) handleRegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	op := "webhookController.handleRegisterWebhook"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	var dto RegisterWebhookRequestDTO
+
+	err := utils.ValidateJSONBody(r.Context(), r.Body, &dto)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := c.webhookService.Register(r.Context(), user.ID, dto.URL, dto.Secret)
+
+	switch {
+	case errors.Is(err, ErrWebhookAlreadyExists):
+		http.Error(w, "Webhook already registered", http.StatusConflict)
+		return
+	case errors.Is(err, ErrWebhookLimitExceeded):
+		http.Error(w, "Webhooks limit exceeded", http.StatusUnprocessableEntity)
+		return
+	case errors.Is(err, ErrWebhookURLNotAllowed):
+		http.Error(w, "Webhook url must resolve to a public address", http.StatusUnprocessableEntity)
+		return
+	case err != nil:
+		logger.Errorw("error while register webhook", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, webhook, logger)
+}
+
+// handleGetWebhooks godoc
+//
+//	@Summary		get list of user webhooks
+//	@Tags			webhook
+//
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}	dtos.Webhook
+//	@Failure		401
+//	@Failure		500
+//	@Router			/api/user/webhooks [get]
+func (c *WebhookController) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	op := "webhookController.handleGetWebhooks"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	webhooks, err := c.webhookService.GetWebhooks(r.Context(), user.ID)
+
+	if err != nil {
+		logger.Errorw("error while get webhooks", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhooks, logger)
+}
+
+// handleDeleteWebhook godoc
+//
+//	@Summary		delete webhook
+//	@Description	Pending deliveries to the webhook are dropped with its delivery log
+//	@Tags			webhook
+//
+//	@Param			webhookID	path	int	true	"Webhook ID"
+//	@Security		ApiKeyAuth
+//	@Success		204
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/user/webhooks/{webhookID} [delete]
+func (c *WebhookController) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	op := "webhookController.handleDeleteWebhook"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+
+	if err != nil {
+		http.Error(w, "Invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	err = c.webhookService.Delete(r.Context(), user.ID, webhookID)
+
+	if errors.Is(err, ErrWebhookNotFound) {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while delete webhook", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetDeliveries godoc
+//
+//	@Summary		get delivery log of webhook
+//	@Description	Deliveries newest first. A delivery is PENDING until the webhook answers 2xx, it is FAILED once retried for too long
+//	@Tags			webhook
+//
+//	@Param			webhookID	path	int	true	"Webhook ID"
+//	@Param			limit		query	int	false	"page size, 50 by default and 100 at most"
+//	@Param			offset		query	int	false	"number of deliveries to skip"
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}	dtos.WebhookDelivery
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		500
+//	@Router			/api/user/webhooks/{webhookID}/deliveries [get]
+func (c *WebhookController) handleGetDeliveries(w http.ResponseWriter, r *http.Request) {
+	op := "webhookController.handleGetDeliveries"
+
+	logger := c.logger.With("op", op)
+
+	user := auth.ExtractUserFromContext(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "webhookID"), 10, 64)
+
+	if err != nil {
+		http.Error(w, "Invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseInt(r.URL.Query().Get("limit"))
+
+	if err != nil {
+		http.Error(w, "Invalid limit", http.StatusBadRequest)
+		return
+	}
+
+	offset, err := parseInt(r.URL.Query().Get("offset"))
+
+	if err != nil {
+		http.Error(w, "Invalid offset", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := c.webhookService.GetDeliveries(r.Context(), user.ID, webhookID, limit, offset)
+
+	if errors.Is(err, ErrWebhookNotFound) {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		logger.Errorw("error while get webhook deliveries", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries, logger)
+}
+
+func parseInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any, logger logger.Logger) {
+	result, err := json.Marshal(value)
+
+	if err != nil {
+		logger.Errorw("error while serialize to json", "err", err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(result)
+}
+
+func NewController(logger logger.Logger, tokenService auth.TokenService, webhookService WebhookService) *WebhookController {
+	return &WebhookController{
+		logger,
+		tokenService,
+		webhookService,
+	}
+}
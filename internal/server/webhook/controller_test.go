@@ -0,0 +1,176 @@
+package webhook_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/webhook"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := chi.NewRouter()
+
+	webhookServiceMock := webhook.NewMockWebhookService(ctrl)
+	tokenServiceMock := auth.NewMockTokenService(ctrl)
+	logger := logger.New("info")
+
+	c := webhook.NewController(logger, tokenServiceMock, webhookServiceMock)
+
+	r.Mount("/webhooks", c.Route())
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := resty.New().SetBaseURL(ts.URL)
+
+	validBody := `{"url":"https://example.com/hook","secret":"0123456789abcdef"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:           "should return 401 if token invalid",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           validBody,
+			expectedStatus: http.StatusUnauthorized,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{}, errors.New("invalid"))
+			},
+		},
+		{
+			name:           "should return 400 if url invalid",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           `{"url":"ftp://example.com","secret":"0123456789abcdef"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should return 400 if secret too short",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           `{"url":"https://example.com/hook","secret":"short"}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should register webhook",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           validBody,
+			expectedStatus: http.StatusCreated,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Register(gomock.Any(), 1, "https://example.com/hook", "0123456789abcdef").Return(dtos.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hook"}, nil)
+			},
+		},
+		{
+			name:           "should return 409 if webhook already registered",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           validBody,
+			expectedStatus: http.StatusConflict,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Register(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(dtos.Webhook{}, webhook.ErrWebhookAlreadyExists)
+			},
+		},
+		{
+			name:           "should return 422 if webhooks limit exceeded",
+			method:         http.MethodPost,
+			url:            "/webhooks",
+			body:           validBody,
+			expectedStatus: http.StatusUnprocessableEntity,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Register(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(dtos.Webhook{}, webhook.ErrWebhookLimitExceeded)
+			},
+		},
+		{
+			name:           "should delete webhook",
+			method:         http.MethodDelete,
+			url:            "/webhooks/3",
+			expectedStatus: http.StatusNoContent,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Delete(gomock.Any(), 1, int64(3)).Return(nil)
+			},
+		},
+		{
+			name:           "should return 404 if webhook not found",
+			method:         http.MethodDelete,
+			url:            "/webhooks/3",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().Delete(gomock.Any(), 1, int64(3)).Return(webhook.ErrWebhookNotFound)
+			},
+		},
+		{
+			name:           "should return 400 if limit invalid",
+			method:         http.MethodGet,
+			url:            "/webhooks/3/deliveries?limit=abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().GetDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:           "should return deliveries",
+			method:         http.MethodGet,
+			url:            "/webhooks/3/deliveries?limit=10&offset=20",
+			expectedStatus: http.StatusOK,
+			setupMock: func() {
+				tokenServiceMock.EXPECT().Validate(gomock.Any()).Return(&auth.Claims{TokenUser: auth.TokenUser{ID: 1}}, nil)
+				webhookServiceMock.EXPECT().GetDeliveries(gomock.Any(), 1, int64(3), int64(10), int64(20)).Return([]dtos.WebhookDelivery{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+			req := client.R()
+
+			req.Method = tc.method
+			req.URL = tc.url
+
+			if tc.body != "" {
+				req.SetBody(tc.body)
+				req.SetHeader("Content-Type", "application/json")
+			}
+
+			req.SetHeader("Authorization", "Bearer test")
+
+			resp, err := req.Send()
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode())
+		})
+	}
+}
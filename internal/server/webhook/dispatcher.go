@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/outbox"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/pkg/signature"
+)
+
+// deliveryTimeout bounds a single attempt, a slow webhook must not hold the batch for long.
+const deliveryTimeout = 5 * time.Second
+
+// Notification is the body posted to a webhook, signed the same way as the outbox webhook publisher does.
+// ID is the id of the delivery, webhooks deduplicate notifications by it.
+type Notification struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Dispatcher sends pending deliveries to user webhooks. A batch of deliveries is leased to the dispatcher before
+// it is sent, so several gophermart replicas can run dispatchers side by side. Deliveries are sent outside of any
+// transaction, slow webhooks hold no row locks. A failed delivery is retried with backoff until
+// it succeeds or is retried for longer than the policy max age.
+type Dispatcher struct {
+	webhookRepo repository.WebhookRepository
+	logger      logger.Logger
+	httpClient  *resty.Client
+	owner       string
+	leaseTTL    time.Duration
+	interval    time.Duration
+	batchSize   int64
+	retryPolicy dtos.RetryPolicy
+}
+
+// Run sends due deliveries every interval until ctx is done. Full batches are followed by the next one right away.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if d.interval <= 0 {
+		return nil
+	}
+
+	d.logger.Infow("start dispatching webhook deliveries")
+
+	for {
+		dispatched, err := d.dispatchBatch(ctx)
+
+		if err != nil && ctx.Err() == nil {
+			d.logger.Errorw("failed to dispatch webhook deliveries", "err", err)
+		}
+
+		if err == nil && int64(dispatched) == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Infow("stop dispatching webhook deliveries")
+			return ctx.Err()
+		case <-time.After(d.interval):
+		}
+	}
+}
+
+// dispatchBatch sends a batch of due deliveries and returns its size. Deliveries left when the lease is
+// about to expire are not sent, they are claimed again once it expires.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	op := "webhookDispatcher.dispatchBatch"
+
+	deadline := time.Now().Add(d.leaseTTL)
+
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.owner, d.leaseTTL, d.batchSize)
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, delivery := range deliveries {
+		// a delivery is sent for deliveryTimeout at most, it must end before the lease does
+		if !time.Now().Add(deliveryTimeout).Before(deadline) {
+			d.logger.Warnw("webhook delivery lease expired before the batch was sent", "deliveryID", delivery.ID)
+			break
+		}
+
+		responseStatus, err := d.send(ctx, delivery)
+
+		if err == nil {
+			if err := d.webhookRepo.MarkDelivered(ctx, delivery.ID, *responseStatus); err != nil {
+				return len(deliveries), fmt.Errorf("%s: %w", op, err)
+			}
+
+			continue
+		}
+
+		failed, retryErr := d.webhookRepo.ScheduleDeliveryRetry(ctx, delivery.ID, d.owner, d.retryPolicy, responseStatus, err.Error())
+
+		if retryErr != nil {
+			return len(deliveries), fmt.Errorf("%s: %w", op, retryErr)
+		}
+
+		if failed {
+			d.logger.Warnw("webhook delivery failed", "deliveryID", delivery.ID, "webhookID", delivery.WebhookID, "attempts", delivery.Attempts+1, "err", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send posts the delivery to its webhook and returns the response status, nil when there was no response.
+// Any response but 2xx is a failure.
+func (d *Dispatcher) send(ctx context.Context, delivery dtos.WebhookDelivery) (*int, error) {
+	body, err := json.Marshal(Notification{
+		ID:         delivery.ID,
+		Type:       delivery.EventType,
+		Payload:    delivery.Payload,
+		OccurredAt: delivery.CreatedAt,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	response, err := d.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(outbox.EventIDHeader, fmt.Sprint(delivery.ID)).
+		SetHeader(outbox.EventTypeHeader, delivery.EventType).
+		SetHeader(outbox.SignatureHeader, signature.Sign(delivery.Secret, body)).
+		SetBody(body).
+		Post(delivery.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	responseStatus := response.StatusCode()
+
+	if !response.IsSuccess() {
+		return &responseStatus, fmt.Errorf("webhook responded with status %d", responseStatus)
+	}
+
+	return &responseStatus, nil
+}
+
+// NewDispatcher creates a dispatcher sending deliveries to public addresses only, unless allowPrivateNetworks is set.
+func NewDispatcher(webhookRepo repository.WebhookRepository, logger logger.Logger, leaseTTL time.Duration, interval time.Duration, batchSize int64, retryPolicy dtos.RetryPolicy, allowPrivateNetworks bool) *Dispatcher {
+	// no proxy, it would dial the webhook instead of the guarded dialer
+	transport := &http.Transport{
+		DialContext:           newAddressGuard(allowPrivateNetworks).dialContext(&net.Dialer{Timeout: deliveryTimeout}),
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   deliveryTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &Dispatcher{
+		webhookRepo: webhookRepo,
+		logger:      logger,
+		httpClient:  resty.New().SetTransport(transport).SetRedirectPolicy(resty.NoRedirectPolicy()),
+		owner:       repository.NewLeaseOwner(),
+		leaseTTL:    leaseTTL,
+		interval:    interval,
+		batchSize:   batchSize,
+		retryPolicy: retryPolicy,
+	}
+}
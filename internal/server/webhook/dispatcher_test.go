@@ -0,0 +1,155 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/outbox"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/internal/server/webhook"
+	"github.com/sodiqit/gophermart/pkg/signature"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDispatcher_run(t *testing.T) {
+	retryPolicy := dtos.RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, MaxAge: time.Hour}
+	secret := "0123456789abcdef"
+
+	var received []webhook.Notification
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.True(t, signature.Valid(secret, body, r.Header.Get(outbox.SignatureHeader)))
+
+		var notification webhook.Notification
+		require.NoError(t, json.Unmarshal(body, &notification))
+
+		received = append(received, notification)
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	deliveries := []dtos.WebhookDelivery{
+		{ID: 1, WebhookID: 1, EventType: repository.WebhookEventOrderStatusChanged, Payload: []byte(`{"number":"2377225624"}`), URL: ts.URL + "/ok", Secret: secret},
+		{ID: 2, WebhookID: 2, EventType: repository.WebhookEventWithdrawalCreated, Payload: []byte(`{"order":"2377225624"}`), URL: ts.URL + "/broken", Secret: secret},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unavailable := http.StatusServiceUnavailable
+
+	gomock.InOrder(
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Minute, int64(10)).Return(deliveries, nil),
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Minute, int64(10)).DoAndReturn(func(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.WebhookDelivery, error) {
+			cancel()
+			return nil, nil
+		}),
+	)
+
+	webhookRepoMock.EXPECT().MarkDelivered(gomock.Any(), int64(1), http.StatusNoContent).Return(nil)
+	webhookRepoMock.EXPECT().ScheduleDeliveryRetry(gomock.Any(), int64(2), gomock.Any(), retryPolicy, &unavailable, "webhook responded with status 503").Return(false, nil)
+
+	d := webhook.NewDispatcher(webhookRepoMock, logger.New("info"), time.Minute, time.Millisecond, 10, retryPolicy, true)
+
+	err := d.Run(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, received, 2)
+	require.Equal(t, int64(1), received[0].ID)
+	require.Equal(t, repository.WebhookEventOrderStatusChanged, received[0].Type)
+	require.JSONEq(t, `{"number":"2377225624"}`, string(received[0].Payload))
+}
+
+func TestDispatcher_runWithExpiredLease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deliveries := []dtos.WebhookDelivery{
+		{ID: 1, WebhookID: 1, EventType: repository.WebhookEventOrderStatusChanged, Payload: []byte(`{"number":"2377225624"}`), URL: "http://127.0.0.1:1/ok"},
+	}
+
+	gomock.InOrder(
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Second, int64(10)).Return(deliveries, nil),
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Second, int64(10)).DoAndReturn(func(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.WebhookDelivery, error) {
+			cancel()
+			return nil, nil
+		}),
+	)
+
+	// a lease shorter than a delivery attempt leaves the batch to be claimed again
+	webhookRepoMock.EXPECT().MarkDelivered(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	webhookRepoMock.EXPECT().ScheduleDeliveryRetry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	d := webhook.NewDispatcher(webhookRepoMock, logger.New("info"), time.Second, time.Millisecond, 10, dtos.RetryPolicy{}, true)
+
+	require.ErrorIs(t, d.Run(ctx), context.Canceled)
+}
+
+func TestDispatcher_runNotPublicAddress(t *testing.T) {
+	var received int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deliveries := []dtos.WebhookDelivery{
+		{ID: 1, WebhookID: 1, EventType: repository.WebhookEventOrderStatusChanged, Payload: []byte(`{"number":"2377225624"}`), URL: ts.URL + "/ok"},
+	}
+
+	gomock.InOrder(
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Minute, int64(10)).Return(deliveries, nil),
+		webhookRepoMock.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), time.Minute, int64(10)).DoAndReturn(func(ctx context.Context, owner string, leaseTTL time.Duration, limit int64) ([]dtos.WebhookDelivery, error) {
+			cancel()
+			return nil, nil
+		}),
+	)
+
+	// the loopback address of the test server is rejected at dial time
+	webhookRepoMock.EXPECT().MarkDelivered(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	webhookRepoMock.EXPECT().ScheduleDeliveryRetry(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(func(ctx context.Context, deliveryID int64, owner string, policy dtos.RetryPolicy, responseStatus *int, lastError string) (bool, error) {
+		require.Contains(t, lastError, webhook.ErrWebhookURLNotAllowed.Error())
+		return false, nil
+	})
+
+	d := webhook.NewDispatcher(webhookRepoMock, logger.New("info"), time.Minute, time.Millisecond, 10, dtos.RetryPolicy{}, false)
+
+	require.ErrorIs(t, d.Run(ctx), context.Canceled)
+	require.Zero(t, received)
+}
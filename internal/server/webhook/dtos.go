@@ -0,0 +1,6 @@
+package webhook
+
+type RegisterWebhookRequestDTO struct {
+	URL    string `json:"url" validate:"required,http_url,max=2048"`
+	Secret string `json:"secret" validate:"required,min=16,max=255"`
+}
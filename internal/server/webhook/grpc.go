@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bufbuild/protovalidate-go"
+	proto "github.com/sodiqit/gophermart/gen/proto/webhook/v1"
+	"github.com/sodiqit/gophermart/internal/logger"
+	"github.com/sodiqit/gophermart/internal/server/auth"
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookServer struct {
+	proto.UnimplementedWebhookServiceServer
+	logger         logger.Logger
+	webhookService WebhookService
+	validator      *protovalidate.Validator
+}
+
+func (s *WebhookServer) RegisterWebhook(ctx context.Context, in *proto.RegisterWebhookRequest) (*proto.RegisterWebhookResponse, error) {
+	logger := s.logger.With("op", proto.WebhookService_RegisterWebhook_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	webhook, err := s.webhookService.Register(ctx, user.ID, in.Url, in.Secret)
+
+	switch {
+	case errors.Is(err, ErrWebhookAlreadyExists):
+		return nil, status.Error(codes.AlreadyExists, "Webhook already registered")
+	case errors.Is(err, ErrWebhookLimitExceeded):
+		return nil, status.Error(codes.ResourceExhausted, "Webhooks limit exceeded")
+	case errors.Is(err, ErrWebhookURLNotAllowed):
+		return nil, status.Error(codes.InvalidArgument, "Webhook url must resolve to a public address")
+	case err != nil:
+		logger.Errorw("failed to register webhook", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.RegisterWebhookResponse{Webhook: mapWebhookToProto(webhook)}, nil
+}
+
+func (s *WebhookServer) ListWebhooks(ctx context.Context, in *proto.ListWebhooksRequest) (*proto.ListWebhooksResponse, error) {
+	var response proto.ListWebhooksResponse
+
+	logger := s.logger.With("op", proto.WebhookService_ListWebhooks_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	webhooks, err := s.webhookService.GetWebhooks(ctx, user.ID)
+
+	if err != nil {
+		logger.Errorw("failed to get webhooks", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, mapWebhookToProto(webhook))
+	}
+
+	return &response, nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, in *proto.DeleteWebhookRequest) (*proto.DeleteWebhookResponse, error) {
+	logger := s.logger.With("op", proto.WebhookService_DeleteWebhook_FullMethodName)
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	err := s.webhookService.Delete(ctx, user.ID, in.WebhookId)
+
+	if errors.Is(err, ErrWebhookNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	if err != nil {
+		logger.Errorw("failed to delete webhook", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	return &proto.DeleteWebhookResponse{}, nil
+}
+
+func (s *WebhookServer) ListDeliveries(ctx context.Context, in *proto.ListDeliveriesRequest) (*proto.ListDeliveriesResponse, error) {
+	var response proto.ListDeliveriesResponse
+
+	logger := s.logger.With("op", proto.WebhookService_ListDeliveries_FullMethodName)
+
+	err := s.validator.Validate(in)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := auth.ExtractUserFromContext(ctx)
+
+	deliveries, err := s.webhookService.GetDeliveries(ctx, user.ID, in.WebhookId, in.Limit, in.Offset)
+
+	if errors.Is(err, ErrWebhookNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found")
+	}
+
+	if err != nil {
+		logger.Errorw("failed to get webhook deliveries", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, mapDeliveryToProto(delivery))
+	}
+
+	return &response, nil
+}
+
+func mapWebhookToProto(webhook dtos.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Id:        webhook.ID,
+		Url:       webhook.URL,
+		CreatedAt: timestamppb.New(webhook.CreatedAt),
+	}
+}
+
+func mapDeliveryToProto(delivery dtos.WebhookDelivery) *proto.Delivery {
+	result := &proto.Delivery{
+		Id:        delivery.ID,
+		WebhookId: delivery.WebhookID,
+		Event:     delivery.EventType,
+		Payload:   string(delivery.Payload),
+		Status:    proto.Delivery_Status(proto.Delivery_Status_value[delivery.Status]),
+		Attempts:  int32(delivery.Attempts),
+		LastError: delivery.LastError,
+		CreatedAt: timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.ResponseStatus != nil {
+		responseStatus := int32(*delivery.ResponseStatus)
+		result.ResponseStatus = &responseStatus
+	}
+
+	if delivery.NextAttemptAt != nil {
+		result.NextAttemptAt = timestamppb.New(*delivery.NextAttemptAt)
+	}
+
+	if delivery.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+
+	return result
+}
+
+func NewWebhookServer(logger logger.Logger, webhookService WebhookService) *WebhookServer {
+	v, err := protovalidate.New()
+	if err != nil {
+		panic(err)
+	}
+	return &WebhookServer{
+		logger:         logger,
+		webhookService: webhookService,
+		validator:      v,
+	}
+}
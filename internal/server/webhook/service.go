@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+)
+
+const (
+	MaxWebhooksPerUser     = 5
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 100
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+var ErrWebhookAlreadyExists = errors.New("webhook already registered")
+var ErrWebhookLimitExceeded = errors.New("webhooks limit exceeded")
+
+type WebhookService interface {
+	Register(ctx context.Context, userID int, url string, secret string) (dtos.Webhook, error)
+	GetWebhooks(ctx context.Context, userID int) ([]dtos.Webhook, error)
+	Delete(ctx context.Context, userID int, webhookID int64) error
+	GetDeliveries(ctx context.Context, userID int, webhookID int64, limit int64, offset int64) ([]dtos.WebhookDelivery, error)
+}
+
+type SimpleWebhookService struct {
+	webhookRepo  repository.WebhookRepository
+	transactor   repository.Transactor
+	addressGuard addressGuard
+}
+
+// Register adds the webhook of the user. A user has at most MaxWebhooksPerUser webhooks, the count is checked
+// under the lock of the user row so concurrent registrations can not exceed it. ErrWebhookURLNotAllowed
+// is returned for urls not resolving to a public address.
+func (s *SimpleWebhookService) Register(ctx context.Context, userID int, url string, secret string) (dtos.Webhook, error) {
+	op := "webhookService.register"
+
+	if err := s.addressGuard.checkURL(ctx, url); err != nil {
+		return dtos.Webhook{}, err
+	}
+
+	var webhook dtos.Webhook
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.LockUserWebhooks(ctx, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		count, err := s.webhookRepo.CountWebhooks(ctx, userID)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if count >= MaxWebhooksPerUser {
+			return ErrWebhookLimitExceeded
+		}
+
+		webhook, err = s.webhookRepo.CreateWebhook(ctx, userID, url, secret)
+
+		if errors.Is(err, repository.ErrWebhookAlreadyExists) {
+			return ErrWebhookAlreadyExists
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return dtos.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *SimpleWebhookService) GetWebhooks(ctx context.Context, userID int) ([]dtos.Webhook, error) {
+	return s.webhookRepo.GetWebhooksByUser(ctx, userID)
+}
+
+// Delete removes the webhook of the user, pending deliveries to it are dropped.
+func (s *SimpleWebhookService) Delete(ctx context.Context, userID int, webhookID int64) error {
+	op := "webhookService.delete"
+
+	err := s.webhookRepo.DeleteWebhook(ctx, userID, webhookID)
+
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return ErrWebhookNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns a page of the delivery log of the user webhook, limit is clamped to MaxDeliveriesLimit.
+func (s *SimpleWebhookService) GetDeliveries(ctx context.Context, userID int, webhookID int64, limit int64, offset int64) ([]dtos.WebhookDelivery, error) {
+	op := "webhookService.getDeliveries"
+
+	_, err := s.webhookRepo.GetWebhook(ctx, userID, webhookID)
+
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if limit <= 0 {
+		limit = DefaultDeliveriesLimit
+	}
+
+	if limit > MaxDeliveriesLimit {
+		limit = MaxDeliveriesLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveries(ctx, webhookID, limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// NewSimpleWebhookService creates a service registering urls of public addresses only, unless allowPrivateNetworks is set.
+func NewSimpleWebhookService(webhookRepo repository.WebhookRepository, transactor repository.Transactor, allowPrivateNetworks bool) *SimpleWebhookService {
+	return &SimpleWebhookService{
+		webhookRepo:  webhookRepo,
+		transactor:   transactor,
+		addressGuard: newAddressGuard(allowPrivateNetworks),
+	}
+}
@@ -0,0 +1,195 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sodiqit/gophermart/internal/server/dtos"
+	"github.com/sodiqit/gophermart/internal/server/repository"
+	"github.com/sodiqit/gophermart/internal/server/webhook"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookService_register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := webhook.NewSimpleWebhookService(webhookRepoMock, transactorMock, true)
+
+	url := "https://example.com/hook"
+	secret := "0123456789abcdef"
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError error
+		wantErr       bool
+	}{
+		{
+			name: "should register webhook",
+			setupMock: func() {
+				webhookRepoMock.EXPECT().LockUserWebhooks(gomock.Any(), 1).Return(nil)
+				webhookRepoMock.EXPECT().CountWebhooks(gomock.Any(), 1).Return(int64(0), nil)
+				webhookRepoMock.EXPECT().CreateWebhook(gomock.Any(), 1, url, secret).Return(dtos.Webhook{ID: 1, UserID: 1, URL: url}, nil)
+			},
+		},
+		{
+			name: "should return error if user is not locked",
+			setupMock: func() {
+				webhookRepoMock.EXPECT().LockUserWebhooks(gomock.Any(), 1).Return(errors.New("lock failed"))
+				webhookRepoMock.EXPECT().CountWebhooks(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return error if limit exceeded",
+			setupMock: func() {
+				webhookRepoMock.EXPECT().LockUserWebhooks(gomock.Any(), 1).Return(nil)
+				webhookRepoMock.EXPECT().CountWebhooks(gomock.Any(), 1).Return(int64(webhook.MaxWebhooksPerUser), nil)
+				webhookRepoMock.EXPECT().CreateWebhook(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: webhook.ErrWebhookLimitExceeded,
+			wantErr:       true,
+		},
+		{
+			name: "should return error if webhook already registered",
+			setupMock: func() {
+				webhookRepoMock.EXPECT().LockUserWebhooks(gomock.Any(), 1).Return(nil)
+				webhookRepoMock.EXPECT().CountWebhooks(gomock.Any(), 1).Return(int64(1), nil)
+				webhookRepoMock.EXPECT().CreateWebhook(gomock.Any(), 1, url, secret).Return(dtos.Webhook{}, repository.ErrWebhookAlreadyExists)
+			},
+			expectedError: webhook.ErrWebhookAlreadyExists,
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			_, err := s.Register(context.Background(), 1, url, secret)
+
+			if tc.expectedError != nil {
+				require.True(t, errors.Is(err, tc.expectedError))
+			}
+
+			if tc.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookService_registerNotPublicURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+
+	transactorMock := repository.NewMockTransactor(ctrl)
+
+	transactorMock.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}).AnyTimes()
+
+	s := webhook.NewSimpleWebhookService(webhookRepoMock, transactorMock, false)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "should reject loopback address", url: "http://127.0.0.1:8080/hook"},
+		{name: "should reject ipv6 loopback address", url: "http://[::1]/hook"},
+		{name: "should reject private address", url: "https://10.0.0.5/hook"},
+		{name: "should reject link-local address of cloud metadata", url: "http://169.254.169.254/latest/meta-data"},
+		{name: "should reject unspecified address", url: "http://0.0.0.0/hook"},
+		{name: "should reject url without host", url: "http:///hook"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			webhookRepoMock.EXPECT().CreateWebhook(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := s.Register(context.Background(), 1, tc.url, "0123456789abcdef")
+
+			require.ErrorIs(t, err, webhook.ErrWebhookURLNotAllowed)
+		})
+	}
+
+	webhookRepoMock.EXPECT().LockUserWebhooks(gomock.Any(), 1).Return(nil)
+	webhookRepoMock.EXPECT().CountWebhooks(gomock.Any(), 1).Return(int64(0), nil)
+	webhookRepoMock.EXPECT().CreateWebhook(gomock.Any(), 1, "https://93.184.216.34/hook", gomock.Any()).Return(dtos.Webhook{ID: 1}, nil)
+
+	_, err := s.Register(context.Background(), 1, "https://93.184.216.34/hook", "0123456789abcdef")
+
+	require.NoError(t, err)
+}
+
+func TestWebhookService_getDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepoMock := repository.NewMockWebhookRepository(ctrl)
+
+	s := webhook.NewSimpleWebhookService(webhookRepoMock, repository.NewMockTransactor(ctrl), true)
+
+	tests := []struct {
+		name          string
+		limit         int64
+		offset        int64
+		setupMock     func()
+		expectedError error
+	}{
+		{
+			name:  "should use default limit",
+			limit: 0,
+			setupMock: func() {
+				webhookRepoMock.EXPECT().GetWebhook(gomock.Any(), 1, int64(3)).Return(dtos.Webhook{ID: 3, UserID: 1}, nil)
+				webhookRepoMock.EXPECT().GetDeliveries(gomock.Any(), int64(3), int64(webhook.DefaultDeliveriesLimit), int64(0)).Return(nil, nil)
+			},
+		},
+		{
+			name:   "should clamp limit",
+			limit:  1000,
+			offset: 20,
+			setupMock: func() {
+				webhookRepoMock.EXPECT().GetWebhook(gomock.Any(), 1, int64(3)).Return(dtos.Webhook{ID: 3, UserID: 1}, nil)
+				webhookRepoMock.EXPECT().GetDeliveries(gomock.Any(), int64(3), int64(webhook.MaxDeliveriesLimit), int64(20)).Return(nil, nil)
+			},
+		},
+		{
+			name:  "should return error if webhook of another user",
+			limit: 10,
+			setupMock: func() {
+				webhookRepoMock.EXPECT().GetWebhook(gomock.Any(), 1, int64(3)).Return(dtos.Webhook{}, repository.ErrWebhookNotFound)
+				webhookRepoMock.EXPECT().GetDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedError: webhook.ErrWebhookNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			_, err := s.GetDeliveries(context.Background(), 1, 3, tc.limit, tc.offset)
+
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package points implements exact fixed-point arithmetic for loyalty points.
+//
+// An amount is stored as an integer number of minor units, one minor unit is a hundredth of a point,
+// so sums and differences never drift. Rounding happens only when a value with more than two
+// fractional digits enters the system (parsing or converting from float64): such values are rounded
+// half away from zero to the nearest minor unit, e.g. 0.005 becomes 0.01 and -0.005 becomes -0.01.
+//
+// In JSON an amount is written as a plain number without trailing zeros (500, 729.98, 0.5),
+// which matches the format produced by float64 before.
+package points
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units in one point.
+const Scale = 100
+
+var ErrInvalidAmount = errors.New("invalid points amount")
+
+type Points int64
+
+func FromMinor(minor int64) Points {
+	return Points(minor)
+}
+
+// FromFloat converts f to points rounding it half away from zero to the nearest minor unit.
+func FromFloat(f float64) Points {
+	return Points(math.Round(f * Scale))
+}
+
+// Parse reads an exact decimal number, an exponent is allowed ("1.5", "729.98", "1e2").
+func Parse(s string) (Points, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	r.Mul(r, big.NewRat(Scale, 1))
+
+	minor, err := roundHalfAwayFromZero(r)
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, s)
+	}
+
+	return Points(minor), nil
+}
+
+func (p Points) Minor() int64 {
+	return int64(p)
+}
+
+func (p Points) Float64() float64 {
+	return float64(p) / Scale
+}
+
+func (p Points) String() string {
+	sign := ""
+	minor := int64(p)
+
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	whole := strconv.FormatInt(minor/Scale, 10)
+	fraction := strings.TrimRight(fmt.Sprintf("%02d", minor%Scale), "0")
+
+	if fraction == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + fraction
+}
+
+func (p Points) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p Points) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText reads an amount from configuration values, see Parse.
+func (p *Points) UnmarshalText(data []byte) error {
+	parsed, err := Parse(string(data))
+
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
+
+// UnmarshalJSON accepts both a JSON number and a string containing a number.
+func (p *Points) UnmarshalJSON(data []byte) error {
+	s := string(data)
+
+	if s == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
+
+func roundHalfAwayFromZero(r *big.Rat) (int64, error) {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if negative {
+		quo.Neg(quo)
+	}
+
+	if !quo.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+
+	return quo.Int64(), nil
+}
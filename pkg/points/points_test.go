@@ -0,0 +1,120 @@
+package points_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sodiqit/gophermart/pkg/points"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		expectedResult points.Points
+		wantErr        bool
+	}{
+		{
+			name:           "integer",
+			value:          "500",
+			expectedResult: points.FromMinor(50000),
+		},
+		{
+			name:           "two fractional digits",
+			value:          "729.98",
+			expectedResult: points.FromMinor(72998),
+		},
+		{
+			name:           "exponent",
+			value:          "1.5e2",
+			expectedResult: points.FromMinor(15000),
+		},
+		{
+			name:           "rounds half away from zero",
+			value:          "0.005",
+			expectedResult: points.FromMinor(1),
+		},
+		{
+			name:           "rounds negative half away from zero",
+			value:          "-0.005",
+			expectedResult: points.FromMinor(-1),
+		},
+		{
+			name:           "rounds down below half",
+			value:          "0.0049999",
+			expectedResult: points.FromMinor(0),
+		},
+		{
+			name:    "not a number",
+			value:   "abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := points.Parse(tt.value)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, got)
+		})
+	}
+}
+
+func TestPoints_String(t *testing.T) {
+	tests := []struct {
+		value          points.Points
+		expectedResult string
+	}{
+		{value: points.FromMinor(50000), expectedResult: "500"},
+		{value: points.FromMinor(72998), expectedResult: "729.98"},
+		{value: points.FromMinor(50), expectedResult: "0.5"},
+		{value: points.FromMinor(-5), expectedResult: "-0.05"},
+		{value: points.FromMinor(0), expectedResult: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedResult, func(t *testing.T) {
+			require.Equal(t, tt.expectedResult, tt.value.String())
+		})
+	}
+}
+
+func TestPoints_JSON(t *testing.T) {
+	var dest struct {
+		Sum     points.Points  `json:"sum"`
+		Accrual *points.Points `json:"accrual,omitempty"`
+	}
+
+	err := json.Unmarshal([]byte(`{"sum": 0.1, "accrual": "0.2"}`), &dest)
+
+	require.NoError(t, err)
+	require.Equal(t, points.FromMinor(30), dest.Sum+*dest.Accrual)
+
+	result, err := json.Marshal(dest)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"sum": 0.1, "accrual": 0.2}`, string(result))
+}
+
+func TestPoints_Text(t *testing.T) {
+	var p points.Points
+
+	err := p.UnmarshalText([]byte("1000.5"))
+
+	require.NoError(t, err)
+	require.Equal(t, points.FromMinor(100050), p)
+
+	text, err := p.MarshalText()
+
+	require.NoError(t, err)
+	require.Equal(t, "1000.5", string(text))
+
+	require.Error(t, p.UnmarshalText([]byte("ten")))
+}
@@ -0,0 +1,38 @@
+// Package signature signs payloads exchanged with other services with HMAC-SHA256 keyed with a shared secret.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const prefix = "sha256="
+
+// Sign returns "sha256=" followed by the hex HMAC-SHA256 of the payload keyed with secret.
+func Sign(secret string, payload []byte) string {
+	return prefix + hex.EncodeToString(digest(secret, payload))
+}
+
+// Valid reports whether signature is the signature of the payload, digests are compared in constant time.
+func Valid(secret string, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(received, digest(secret, payload))
+}
+
+func digest(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,60 @@
+package signature_test
+
+import (
+	"testing"
+
+	"github.com/sodiqit/gophermart/pkg/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test case 2 of RFC 4231
+	require.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature.Sign("Jefe", []byte("what do ya want for nothing?")))
+}
+
+func TestValid(t *testing.T) {
+	payload := []byte(`{"order":"2377225624"}`)
+
+	tests := []struct {
+		name           string
+		signature      string
+		expectedResult bool
+	}{
+		{
+			name:           "valid signature",
+			signature:      signature.Sign("secret", payload),
+			expectedResult: true,
+		},
+		{
+			name:           "signature of another payload",
+			signature:      signature.Sign("secret", []byte(`{"order":"12345678903"}`)),
+			expectedResult: false,
+		},
+		{
+			name:           "signature with another secret",
+			signature:      signature.Sign("other", payload),
+			expectedResult: false,
+		},
+		{
+			name:           "signature without prefix",
+			signature:      signature.Sign("secret", payload)[len("sha256="):],
+			expectedResult: false,
+		},
+		{
+			name:           "signature not in hex",
+			signature:      "sha256=not-hex",
+			expectedResult: false,
+		},
+		{
+			name:           "empty signature",
+			signature:      "",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedResult, signature.Valid("secret", payload, tc.signature))
+		})
+	}
+}